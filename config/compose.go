@@ -0,0 +1,348 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/imdario/mergo"
+	shepherdConfig "github.com/rancher/shepherd/pkg/config"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ExtendsKey is the top level key that lists the base files a config is composed from.
+	ExtendsKey = "extends"
+
+	// OverlaysEnvironmentKey is the environment variable with a comma separated list of overlays, which are applied in order on top
+	// of the cattle config.
+	OverlaysEnvironmentKey = "CATTLE_TEST_CONFIG_OVERLAYS"
+
+	filePrefix       = "file:"
+	redactedValue    = "REDACTED"
+	effectivePattern = "effective-cattle-config-*.yaml"
+)
+
+var (
+	substitution = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+	// secretKeys are the YAML keys of the config structs that hold secrets. Keys that contain password, secret or token are
+	// treated as secrets as well, unless they only name a secret, such as tlsSecretName.
+	secretKeys = []string{"accessKey", "awsAccessKey", "authEncodedJson", "kubeconfigContent"}
+
+	effectiveConfigs = map[string]bool{}
+)
+
+// LoadEffectiveConfig is a function that will compose the cattle config at path. The base files listed under extends are merged first,
+// in order, with later files overriding earlier ones and the config itself overriding its bases. Bases may extend other files, and
+// relative paths are resolved from the directory of the file that lists them. The overlays are then merged on top, in order. In
+// every file, ${ENV_VAR} is replaced with the value of the environment variable and ${file:path} with the contents of the file.
+func LoadEffectiveConfig(path string, overlays []string) (map[string]any, error) {
+	effectiveConfig, err := loadComposedFile(path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, overlay := range overlays {
+		overlayConfig, err := loadComposedFile(overlay, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		err = mergo.Merge(&effectiveConfig, overlayConfig, mergo.WithOverride)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return effectiveConfig, nil
+}
+
+// ComposeCattleConfig is a function that will compose the cattle config set in CATTLE_TEST_CONFIG with the overlays set in
+// CATTLE_TEST_CONFIG_OVERLAYS. When the config uses extends, overlays or substitutions, the effective config is written to a
+// temporary file and CATTLE_TEST_CONFIG is pointed at it, so that shepherd loads the same config as the test suites. The file holds
+// the substituted secrets, so it is removed and CATTLE_TEST_CONFIG is restored once t, usually the suite, finishes.
+func ComposeCattleConfig(t *testing.T) error {
+	path := os.Getenv(shepherdConfig.ConfigEnvironmentKey)
+	if path == "" || effectiveConfigs[path] {
+		return nil
+	}
+
+	overlays := OverlaysFromEnvironment()
+
+	if len(overlays) == 0 {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		rawConfig := map[string]any{}
+
+		err = yaml.Unmarshal(data, &rawConfig)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+
+		if _, ok := rawConfig[ExtendsKey]; !ok && !substitution.Match(data) {
+			return nil
+		}
+	}
+
+	effectiveConfig, err := LoadEffectiveConfig(path, overlays)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(effectiveConfig)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp("", effectivePattern)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	_, err = file.Write(data)
+	if err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+
+	effectiveConfigs[file.Name()] = true
+
+	t.Cleanup(func() {
+		delete(effectiveConfigs, file.Name())
+		os.Remove(file.Name())
+		os.Setenv(shepherdConfig.ConfigEnvironmentKey, path)
+	})
+
+	return os.Setenv(shepherdConfig.ConfigEnvironmentKey, file.Name())
+}
+
+// OverlaysFromEnvironment is a function that will return the overlays set in CATTLE_TEST_CONFIG_OVERLAYS, in order.
+func OverlaysFromEnvironment() []string {
+	var overlays []string
+	for _, overlay := range strings.Split(os.Getenv(OverlaysEnvironmentKey), ",") {
+		if overlay = strings.TrimSpace(overlay); overlay != "" {
+			overlays = append(overlays, overlay)
+		}
+	}
+
+	return overlays
+}
+
+// RedactConfig is a function that will return a copy of the cattle config with the values of every secret replaced, so that the
+// effective config can be printed.
+func RedactConfig(cattleConfig map[string]any) map[string]any {
+	return redact("", cattleConfig).(map[string]any)
+}
+
+// loadComposedFile is a helper function that will load a file, substitute its values and merge it on top of the files it extends.
+// The chain of files being loaded is tracked to report cycles.
+func loadComposedFile(path string, chain []string) (map[string]any, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, parent := range chain {
+		if parent == absPath {
+			return nil, fmt.Errorf("%s extends itself: %s", path, strings.Join(append(chain, absPath), " -> "))
+		}
+	}
+
+	chain = append(chain, absPath)
+
+	data, err := os.ReadFile(absPath)
+	if err != nil {
+		return nil, err
+	}
+
+	fileConfig := map[string]any{}
+
+	err = yaml.Unmarshal(data, &fileConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	dir := filepath.Dir(absPath)
+
+	substituted, err := substitute(fileConfig, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to substitute values in %s: %w", path, err)
+	}
+
+	fileConfig = substituted.(map[string]any)
+
+	bases, err := extendedFiles(fileConfig[ExtendsKey])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	delete(fileConfig, ExtendsKey)
+
+	composedConfig := map[string]any{}
+	for _, base := range bases {
+		if !filepath.IsAbs(base) {
+			base = filepath.Join(dir, base)
+		}
+
+		baseConfig, err := loadComposedFile(base, chain)
+		if err != nil {
+			return nil, err
+		}
+
+		err = mergo.Merge(&composedConfig, baseConfig, mergo.WithOverride)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = mergo.Merge(&composedConfig, fileConfig, mergo.WithOverride)
+	if err != nil {
+		return nil, err
+	}
+
+	return composedConfig, nil
+}
+
+// extendedFiles is a helper function that will return the base files listed under extends, which is either a single path or a list.
+func extendedFiles(value any) ([]string, error) {
+	switch extends := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{extends}, nil
+	case []any:
+		var bases []string
+		for _, base := range extends {
+			path, ok := base.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s must be a list of paths", ExtendsKey)
+			}
+
+			bases = append(bases, path)
+		}
+
+		return bases, nil
+	default:
+		return nil, fmt.Errorf("%s must be a list of paths", ExtendsKey)
+	}
+}
+
+// substitute is a helper function that will replace ${ENV_VAR} and ${file:path} in every string value. Every missing environment
+// variable or unreadable file is reported.
+func substitute(value any, dir string) (any, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		var errs []error
+		for key, item := range v {
+			substituted, err := substitute(item, dir)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			v[key] = substituted
+		}
+
+		return v, errors.Join(errs...)
+	case []any:
+		var errs []error
+		for i, item := range v {
+			substituted, err := substitute(item, dir)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			v[i] = substituted
+		}
+
+		return v, errors.Join(errs...)
+	case string:
+		var errs []error
+		substituted := substitution.ReplaceAllStringFunc(v, func(match string) string {
+			name := substitution.FindStringSubmatch(match)[1]
+
+			if filePath, ok := strings.CutPrefix(name, filePrefix); ok {
+				if !filepath.IsAbs(filePath) {
+					filePath = filepath.Join(dir, filePath)
+				}
+
+				contents, err := os.ReadFile(filePath)
+				if err != nil {
+					errs = append(errs, err)
+					return match
+				}
+
+				return strings.TrimRight(string(contents), "\n")
+			}
+
+			envValue, ok := os.LookupEnv(name)
+			if !ok {
+				errs = append(errs, fmt.Errorf("environment variable %s is not set", name))
+				return match
+			}
+
+			return envValue
+		})
+
+		return substituted, errors.Join(errs...)
+	default:
+		return v, nil
+	}
+}
+
+// redact is a helper function that will copy a value, replacing the values of secret keys.
+func redact(key string, value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for itemKey, item := range v {
+			redacted[itemKey] = redact(itemKey, item)
+		}
+
+		return redacted
+	case []any:
+		redacted := make([]any, len(v))
+		for i, item := range v {
+			redacted[i] = redact(key, item)
+		}
+
+		return redacted
+	default:
+		if v != nil && v != "" && isSecretKey(key) {
+			return redactedValue
+		}
+
+		return v
+	}
+}
+
+// isSecretKey is a helper function that will return whether a YAML key holds a secret.
+func isSecretKey(key string) bool {
+	lower := strings.ToLower(key)
+	if strings.HasSuffix(lower, "name") {
+		return false
+	}
+
+	if strings.Contains(lower, "password") || strings.Contains(lower, "secret") || strings.Contains(lower, "token") {
+		return true
+	}
+
+	for _, secretKey := range secretKeys {
+		if strings.EqualFold(key, secretKey) {
+			return true
+		}
+	}
+
+	return false
+}
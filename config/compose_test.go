@@ -0,0 +1,133 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	shepherdConfig "github.com/rancher/shepherd/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, dir, name, contents string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+
+	return path
+}
+
+func TestLoadEffectiveConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TEST_AWS_SECRET_KEY", "env-secret")
+
+	writeFile(t, dir, "bases/aws.yaml", `
+terraform:
+  provider: aws
+  awsConfig:
+    region: us-east-2
+    ami: ami-base
+  awsCredentials:
+    awsSecretKey: ${TEST_AWS_SECRET_KEY}
+    awsAccessKey: ${file:access-key}
+`)
+	writeFile(t, dir, "bases/access-key", "file-access\n")
+	writeFile(t, dir, "bases/rke2.yaml", `
+extends: aws.yaml
+terraform:
+  module: ec2_rke2
+  cni: calico
+`)
+	path := writeFile(t, dir, "cattle-config.yaml", `
+extends:
+  - bases/rke2.yaml
+terraform:
+  awsConfig:
+    ami: ami-override
+terratest:
+  nodepools:
+    - quantity: 1
+`)
+	overlay := writeFile(t, dir, "overlays/cilium.yaml", `
+terraform:
+  cni: cilium
+terratest:
+  nodepools:
+    - quantity: 3
+`)
+
+	effectiveConfig, err := LoadEffectiveConfig(path, []string{overlay})
+	require.NoError(t, err)
+
+	require.NotContains(t, effectiveConfig, ExtendsKey)
+	require.Equal(t, map[string]any{
+		"terraform": map[string]any{
+			"provider": "aws",
+			"module":   "ec2_rke2",
+			"cni":      "cilium",
+			"awsConfig": map[string]any{
+				"region": "us-east-2",
+				"ami":    "ami-override",
+			},
+			"awsCredentials": map[string]any{
+				"awsSecretKey": "env-secret",
+				"awsAccessKey": "file-access",
+			},
+		},
+		"terratest": map[string]any{
+			"nodepools": []any{map[string]any{"quantity": float64(3)}},
+		},
+	}, effectiveConfig)
+
+	redacted := RedactConfig(effectiveConfig)
+	credentials := redacted["terraform"].(map[string]any)["awsCredentials"].(map[string]any)
+	require.Equal(t, redactedValue, credentials["awsSecretKey"])
+	require.Equal(t, redactedValue, credentials["awsAccessKey"])
+	require.Equal(t, "ami-override", redacted["terraform"].(map[string]any)["awsConfig"].(map[string]any)["ami"])
+	require.Equal(t, "env-secret", effectiveConfig["terraform"].(map[string]any)["awsCredentials"].(map[string]any)["awsSecretKey"])
+}
+
+func TestLoadEffectiveConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	path := writeFile(t, dir, "a.yaml", "extends: [b.yaml]\n")
+	writeFile(t, dir, "b.yaml", "extends: [a.yaml]\n")
+
+	_, err := LoadEffectiveConfig(path, nil)
+	require.ErrorContains(t, err, "extends itself")
+
+	path = writeFile(t, dir, "missing.yaml", "terraform:\n  awsCredentials:\n    awsSecretKey: ${TEST_UNSET_VARIABLE}\n")
+
+	_, err = LoadEffectiveConfig(path, nil)
+	require.ErrorContains(t, err, "environment variable TEST_UNSET_VARIABLE is not set")
+}
+
+func TestComposeCattleConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	plain := writeFile(t, dir, "plain.yaml", "terraform:\n  module: ec2_rke2\n")
+	t.Setenv(shepherdConfig.ConfigEnvironmentKey, plain)
+	t.Setenv(OverlaysEnvironmentKey, "")
+
+	require.NoError(t, ComposeCattleConfig(t))
+	require.Equal(t, plain, os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+
+	overlay := writeFile(t, dir, "overlay.yaml", "terraform:\n  module: ec2_k3s\n")
+	t.Setenv(OverlaysEnvironmentKey, overlay)
+
+	var effectivePath string
+	t.Run("Suite", func(t *testing.T) {
+		require.NoError(t, ComposeCattleConfig(t))
+
+		effectivePath = os.Getenv(shepherdConfig.ConfigEnvironmentKey)
+		require.NotEqual(t, plain, effectivePath)
+
+		require.Equal(t, "ec2_k3s", shepherdConfig.LoadConfigFromFile(effectivePath)["terraform"].(map[string]any)["module"])
+
+		require.NoError(t, ComposeCattleConfig(t))
+		require.Equal(t, effectivePath, os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	})
+
+	require.NoFileExists(t, effectivePath)
+	require.Equal(t, plain, os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+}
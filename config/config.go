@@ -39,6 +39,10 @@ const (
 	RancherPrivileged PSACT = "rancher-privileged"
 	RancherRestricted PSACT = "rancher-restricted"
 
+	RestoreNone              = "none"
+	RestoreKubernetesVersion = "kubernetesVersion"
+	RestoreAll               = "all"
+
 	defaultFilename      = "defaults.yaml"
 	provisioningFilename = "provisioning.yaml"
 )
@@ -77,6 +81,7 @@ func (c TestClientName) String() string {
 }
 
 type Nodepool struct {
+	Name              string `json:"name,omitempty" yaml:"name,omitempty"` // Name of the node pool of a hosted cluster, required by eks_import, aks_import and gke_import.
 	Quantity          int64  `json:"quantity,omitempty" yaml:"quantity,omitempty"`
 	Etcd              bool   `json:"etcd,omitempty" yaml:"etcd,omitempty"`
 	Controlplane      bool   `json:"controlplane,omitempty" yaml:"controlplane,omitempty"`
@@ -87,6 +92,47 @@ type Nodepool struct {
 	MaxSize           int64  `json:"maxSize,omitempty" yaml:"maxSize,omitempty"`
 	MinSize           int64  `json:"minSize,omitempty" yaml:"minSize,omitempty"`
 	MaxPodsConstraint int64  `json:"maxPodsConstraint,omitempty" yaml:"maxPodsConstraint,omitempty"`
+
+	MachineConfig *MachineConfig `json:"machineConfig,omitempty" yaml:"machineConfig,omitempty"` // Overrides of the node provider config for the machines of this pool.
+
+	Labels                      map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`                                           // Kubernetes labels of the nodes of this pool.
+	Taints                      []Taint           `json:"taints,omitempty" yaml:"taints,omitempty"`                                           // Kubernetes taints of the nodes of this pool.
+	DrainBeforeDelete           bool              `json:"drainBeforeDelete,omitempty" yaml:"drainBeforeDelete,omitempty"`                     // Drain the nodes of this pool before they are deleted.
+	NodeStartupTimeoutSeconds   int64             `json:"nodeStartupTimeoutSeconds,omitempty" yaml:"nodeStartupTimeoutSeconds,omitempty"`     // RKE2/K3s only.
+	UnhealthyNodeTimeoutSeconds int64             `json:"unhealthyNodeTimeoutSeconds,omitempty" yaml:"unhealthyNodeTimeoutSeconds,omitempty"` // RKE2/K3s only.
+	MaxUnhealthy                string            `json:"maxUnhealthy,omitempty" yaml:"maxUnhealthy,omitempty"`                               // RKE2/K3s only, a number or a percentage.
+
+	Mode           string              `json:"mode,omitempty" yaml:"mode,omitempty"`                     // AKS only, System or User. Defaults to the mode of the azureConfig.
+	Autoscaling    bool                `json:"autoscaling,omitempty" yaml:"autoscaling,omitempty"`       // AKS and GKE only, scales the pool between minSize and maxSize.
+	Spot           bool                `json:"spot,omitempty" yaml:"spot,omitempty"`                     // EKS spot instances of the instanceType and GKE preemptible nodes.
+	LaunchTemplate *LaunchTemplate     `json:"launchTemplate,omitempty" yaml:"launchTemplate,omitempty"` // EKS only, launch template of the nodes.
+	Management     *NodepoolManagement `json:"management,omitempty" yaml:"management,omitempty"`         // GKE only, defaults to the GKE defaults.
+}
+
+type LaunchTemplate struct {
+	ID      string `json:"id,omitempty" yaml:"id,omitempty"`
+	Name    string `json:"name,omitempty" yaml:"name,omitempty"`
+	Version int64  `json:"version,omitempty" yaml:"version,omitempty"`
+}
+
+type NodepoolManagement struct {
+	AutoRepair  bool `json:"autoRepair,omitempty" yaml:"autoRepair,omitempty"`
+	AutoUpgrade bool `json:"autoUpgrade,omitempty" yaml:"autoUpgrade,omitempty"`
+}
+
+type Taint struct {
+	Key    string `json:"key,omitempty" yaml:"key,omitempty"`
+	Value  string `json:"value,omitempty" yaml:"value,omitempty"`
+	Effect string `json:"effect,omitempty" yaml:"effect,omitempty"` // Defaults to NoSchedule.
+}
+
+type MachineConfig struct {
+	AMI          string `json:"ami,omitempty" yaml:"ami,omitempty"`                   // AWS AMI.
+	CPUCount     string `json:"cpuCount,omitempty" yaml:"cpuCount,omitempty"`         // Harvester and vSphere CPU count.
+	DiskSize     string `json:"diskSize,omitempty" yaml:"diskSize,omitempty"`         // Azure, Harvester and vSphere disk size.
+	InstanceType string `json:"instanceType,omitempty" yaml:"instanceType,omitempty"` // AWS instance type, Azure size or Linode type.
+	MemorySize   string `json:"memorySize,omitempty" yaml:"memorySize,omitempty"`     // Harvester and vSphere memory size.
+	RootSize     int64  `json:"rootSize,omitempty" yaml:"rootSize,omitempty"`         // AWS root volume size.
 }
 
 type Proxy struct {
@@ -112,7 +158,7 @@ type Standalone struct {
 	AirgapInternalFQDN             string `json:"airgapInternalFQDN,omitempty" yaml:"airgapInternalFQDN,omitempty"`
 	BootstrapPassword              string `json:"bootstrapPassword,omitempty" yaml:"bootstrapPassword,omitempty"`
 	CertManagerVersion             string `json:"certManagerVersion,omitempty" yaml:"certManagerVersion,omitempty"`
-	CertType                       string `json:"certType,omitempty" yaml:"certType,omitempty"`
+	CertType                       string `json:"certType,omitempty" yaml:"certType,omitempty"` // Type of certificate Rancher is installed with.
 	ChartVersion                   string `json:"chartVersion,omitempty" yaml:"chartVersion,omitempty"`
 	K3SVersion                     string `json:"k3sVersion,omitempty" yaml:"k3sVersion,omitempty"`
 	RancherAgentImage              string `json:"rancherAgentImage,omitempty" yaml:"rancherAgentImage,omitempty"`
@@ -167,54 +213,117 @@ type TerraformConfig struct {
 	GithubConfig                        authproviders.GithubConfig   `json:"githubConfig,omitempty" yaml:"githubConfig,omitempty"`
 	OktaConfig                          authproviders.OktaConfig     `json:"oktaConfig,omitempty" yaml:"oktaConfig,omitempty"`
 	OpenLDAPConfig                      authproviders.OpenLDAPConfig `json:"openLDAPConfig,omitempty" yaml:"openLDAPConfig,omitempty"`
-	AuthProvider                        string                       `json:"authProvider,omitempty" yaml:"authProvider,omitempty"`
-	ResourcePrefix                      string                       `json:"resourcePrefix,omitempty" yaml:"resourcePrefix,omitempty"`
-	CNI                                 string                       `json:"cni,omitempty" yaml:"cni,omitempty"`
+	AuthProvider                        string                       `json:"authProvider,omitempty" yaml:"authProvider,omitempty"`     // Auth provider to configure in the auth suites.
+	ResourcePrefix                      string                       `json:"resourcePrefix,omitempty" yaml:"resourcePrefix,omitempty"` // Prefix of every resource name, made unique per test case.
+	CNI                                 string                       `json:"cni,omitempty" yaml:"cni,omitempty"`                       // CNI of the downstream cluster.
 	ChartValues                         string                       `json:"chartValues,omitempty" yaml:"chartValues,omitempty"`
 	DisableKubeProxy                    string                       `json:"disable-kube-proxy,omitempty" yaml:"disable-kube-proxy,omitempty"`
 	DefaultClusterRoleForProjectMembers string                       `json:"defaultClusterRoleForProjectMembers,omitempty" yaml:"defaultClusterRoleForProjectMembers,omitempty"`
 	EnableNetworkPolicy                 bool                         `json:"enableNetworkPolicy,omitempty" yaml:"enableNetworkPolicy,omitempty"`
+	HostedClusterName                   string                       `json:"hostedClusterName,omitempty" yaml:"hostedClusterName,omitempty"` // Name of the existing EKS, AKS or GKE cluster registered by the import modules.
 	ETCD                                *rkev1.ETCD                  `json:"etcd,omitempty" yaml:"etcd,omitempty"`
 	ETCDRKE1                            *management.ETCDService      `json:"etcdRKE1,omitempty" yaml:"etcdRKE1,omitempty"`
-	Module                              string                       `json:"module,omitempty" yaml:"module,omitempty"`
+	Module                              string                       `json:"module,omitempty" yaml:"module,omitempty"` // Module to provision, which selects the provider, distro and provisioning mode.
 	NetworkPlugin                       string                       `json:"networkPlugin,omitempty" yaml:"networkPlugin,omitempty"`
-	PrivateKeyPath                      string                       `json:"privateKeyPath,omitempty" yaml:"privateKeyPath,omitempty"`
+	PrivateKeyPath                      string                       `json:"privateKeyPath,omitempty" yaml:"privateKeyPath,omitempty"` // Path of the SSH private key used to reach the nodes of custom, import and airgap modules.
 	PrivateRegistries                   *PrivateRegistries           `json:"privateRegistries,omitempty" yaml:"privateRegistries,omitempty"`
 	Proxy                               *Proxy                       `json:"proxy,omitempty" yaml:"proxy,omitempty"`
-	Provider                            string                       `json:"provider,omitempty" yaml:"provider,omitempty"`
+	Provider                            string                       `json:"provider,omitempty" yaml:"provider,omitempty"` // Infrastructure provider of the standalone Rancher server and its nodes.
 	Standalone                          *Standalone                  `json:"standalone,omitempty" yaml:"standalone,omitempty"`
 	StandaloneRegistry                  *StandaloneRegistry          `json:"standaloneRegistry,omitempty" yaml:"standaloneRegistry,omitempty"`
 	TimeSleep                           string                       `json:"timeSleep,omitempty" yaml:"timeSleep,omitempty"`
-	WindowsPrivateKeyPath               string                       `json:"windowsPrivateKeyPath,omitempty" yaml:"windowsPrivateKeyPath,omitempty"`
+	UpgradeStrategy                     *UpgradeStrategy             `json:"upgradeStrategy,omitempty" yaml:"upgradeStrategy,omitempty"`             // Rolling upgrade strategy of RKE2/K3s node driver clusters.
+	WindowsPrivateKeyPath               string                       `json:"windowsPrivateKeyPath,omitempty" yaml:"windowsPrivateKeyPath,omitempty"` // Path of the private key of the Windows nodes.
+}
+
+type UpgradeStrategy struct {
+	ControlPlaneConcurrency  string        `json:"controlPlaneConcurrency,omitempty" yaml:"controlPlaneConcurrency,omitempty"`   // Number or percentage of control plane nodes upgraded at once.
+	WorkerConcurrency        string        `json:"workerConcurrency,omitempty" yaml:"workerConcurrency,omitempty"`               // Number or percentage of worker nodes upgraded at once.
+	ControlPlaneDrainOptions *DrainOptions `json:"controlPlaneDrainOptions,omitempty" yaml:"controlPlaneDrainOptions,omitempty"` // Drain options of the control plane nodes.
+	WorkerDrainOptions       *DrainOptions `json:"workerDrainOptions,omitempty" yaml:"workerDrainOptions,omitempty"`             // Drain options of the worker nodes.
+}
+
+type DrainOptions struct {
+	Enabled                         bool  `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Force                           bool  `json:"force,omitempty" yaml:"force,omitempty"`
+	IgnoreDaemonSets                *bool `json:"ignoreDaemonSets,omitempty" yaml:"ignoreDaemonSets,omitempty"` // Defaults to true.
+	DeleteEmptyDirData              bool  `json:"deleteEmptyDirData,omitempty" yaml:"deleteEmptyDirData,omitempty"`
+	DisableEviction                 bool  `json:"disableEviction,omitempty" yaml:"disableEviction,omitempty"`
+	GracePeriod                     int64 `json:"gracePeriod,omitempty" yaml:"gracePeriod,omitempty"` // Seconds, defaults to the grace period of each pod.
+	Timeout                         int64 `json:"timeout,omitempty" yaml:"timeout,omitempty"`         // Seconds, defaults to no timeout.
+	SkipWaitForDeleteTimeoutSeconds int64 `json:"skipWaitForDeleteTimeoutSeconds,omitempty" yaml:"skipWaitForDeleteTimeoutSeconds,omitempty"`
+}
+
+type Backend struct {
+	Type                 string `json:"type,omitempty" yaml:"type,omitempty"` // Terraform backend type.
+	Path                 string `json:"path,omitempty" yaml:"path,omitempty"`
+	Bucket               string `json:"bucket,omitempty" yaml:"bucket,omitempty"`
+	Key                  string `json:"key,omitempty" yaml:"key,omitempty"`
+	Region               string `json:"region,omitempty" yaml:"region,omitempty"`
+	Endpoint             string `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
+	Prefix               string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+	ResourceGroupName    string `json:"resourceGroupName,omitempty" yaml:"resourceGroupName,omitempty"`
+	StorageAccountName   string `json:"storageAccountName,omitempty" yaml:"storageAccountName,omitempty"`
+	ContainerName        string `json:"containerName,omitempty" yaml:"containerName,omitempty"`
+	Address              string `json:"address,omitempty" yaml:"address,omitempty"`
+	LockAddress          string `json:"lockAddress,omitempty" yaml:"lockAddress,omitempty"`
+	UnlockAddress        string `json:"unlockAddress,omitempty" yaml:"unlockAddress,omitempty"`
+	SkipCertVerification bool   `json:"skipCertVerification,omitempty" yaml:"skipCertVerification,omitempty"`
 }
 
 type Snapshots struct {
-	CreateSnapshot  bool   `json:"createSnapshot,omitempty" yaml:"createSnapshot,omitempty"`
-	RestoreSnapshot bool   `json:"restoreSnapshot,omitempty" yaml:"restoreSnapshot,omitempty"`
-	SnapshotName    string `json:"snapshotName,omitempty" yaml:"snapshotName,omitempty"`
-	SnapshotRestore string `json:"snapshotRestore,omitempty" yaml:"snapshotRestore,omitempty"`
+	CreateSnapshot  bool        `json:"createSnapshot,omitempty" yaml:"createSnapshot,omitempty"`
+	RestoreSnapshot bool        `json:"restoreSnapshot,omitempty" yaml:"restoreSnapshot,omitempty"`
+	SnapshotName    string      `json:"snapshotName,omitempty" yaml:"snapshotName,omitempty"`
+	SnapshotRestore string      `json:"snapshotRestore,omitempty" yaml:"snapshotRestore,omitempty"`
+	S3              *SnapshotS3 `json:"s3,omitempty" yaml:"s3,omitempty"` // Stores the etcd snapshots of RKE2/K3s node driver clusters in S3 as well.
+}
+
+type SnapshotS3 struct {
+	AccessKey     string `json:"accessKey,omitempty" yaml:"accessKey,omitempty"`
+	SecretKey     string `json:"secretKey,omitempty" yaml:"secretKey,omitempty"`
+	Bucket        string `json:"bucket,omitempty" yaml:"bucket,omitempty"`
+	Endpoint      string `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`     // Host and port of the S3 API, without a scheme.
+	EndpointCA    string `json:"endpointCA,omitempty" yaml:"endpointCA,omitempty"` // PEM encoded CA of the endpoint.
+	Folder        string `json:"folder,omitempty" yaml:"folder,omitempty"`
+	Region        string `json:"region,omitempty" yaml:"region,omitempty"`
+	SkipSSLVerify bool   `json:"skipSSLVerify,omitempty" yaml:"skipSSLVerify,omitempty"`
+	MinIO         bool   `json:"minio,omitempty" yaml:"minio,omitempty"` // Deploys a MinIO server in the local cluster, which sets the credentials, endpoint and CA.
+}
+
+type RotateCertificates struct {
+	Generation     int64    `json:"generation,omitempty" yaml:"generation,omitempty"`         // Generation of the rotation of RKE2/K3s clusters, bump it to rotate again. Defaults to 1.
+	Services       []string `json:"services,omitempty" yaml:"services,omitempty"`             // Services to rotate the certificates of, all services if empty.
+	CACertificates bool     `json:"caCertificates,omitempty" yaml:"caCertificates,omitempty"` // Rotates the CA certificates of RKE1 clusters as well.
 }
 
 type TerratestConfig struct {
-	AKSKubernetesVersion         string     `json:"aksKubernetesVersion,omitempty" yaml:"aksKubernetesVersion,omitempty"`
-	EKSKubernetesVersion         string     `json:"eksKubernetesVersion,omitempty" yaml:"eksKubernetesVersion,omitempty"`
-	GKEKubernetesVersion         string     `json:"gkeKubernetesVersion,omitempty" yaml:"gkeKubernetesVersion,omitempty"`
-	KubernetesVersion            string     `json:"kubernetesVersion,omitempty" yaml:"kubernetesVersion,omitempty"`
-	LocalQaseReporting           bool       `json:"localQaseReporting,omitempty" yaml:"localQaseReporting,omitempty" default:"false"`
-	EtcdCount                    int64      `json:"etcdCount,omitempty" yaml:"etcdCount,omitempty"`
-	ControlPlaneCount            int64      `json:"controlPlaneCount,omitempty" yaml:"controlPlaneCount,omitempty"`
-	WorkerCount                  int64      `json:"workerCount,omitempty" yaml:"workerCount,omitempty"`
-	Nodepools                    []Nodepool `json:"nodepools,omitempty" yaml:"nodepools,omitempty"`
-	PathToRepo                   string     `json:"pathToRepo,omitempty" yaml:"pathToRepo,omitempty"`
-	PSACT                        string     `json:"psact,omitempty" yaml:"psact,omitempty"`
-	SnapshotInput                Snapshots  `json:"snapshotInput,omitempty" yaml:"snapshotInput,omitempty"`
-	StandaloneLogging            bool       `json:"standaloneLogging,omitempty" yaml:"standaloneLogging,omitempty"`
-	TFLogging                    bool       `json:"tfLogging,omitempty" yaml:"tfLogging,omitempty"`
-	UpgradedAKSKubernetesVersion string     `json:"upgradedAKSKubernetesVersion,omitempty" yaml:"upgradedAKSKubernetesVersion,omitempty"`
-	UpgradedEKSKubernetesVersion string     `json:"upgradedEKSKubernetesVersion,omitempty" yaml:"upgradedEKSKubernetesVersion,omitempty"`
-	UpgradedGKEKubernetesVersion string     `json:"upgradedGKEKubernetesVersion,omitempty" yaml:"upgradedGKEKubernetesVersion,omitempty"`
-	UpgradedKubernetesVersion    string     `json:"upgradedKubernetesVersion,omitempty" yaml:"upgradedKubernetesVersion,omitempty"`
-	WindowsNodeCount             int64      `json:"windowsNodeCount,omitempty" yaml:"windowsNodeCount,omitempty"`
+	AKSKubernetesVersion         string              `json:"aksKubernetesVersion,omitempty" yaml:"aksKubernetesVersion,omitempty"`
+	Backend                      *Backend            `json:"backend,omitempty" yaml:"backend,omitempty"`
+	EKSKubernetesVersion         string              `json:"eksKubernetesVersion,omitempty" yaml:"eksKubernetesVersion,omitempty"`
+	Engine                       string              `json:"engine,omitempty" yaml:"engine,omitempty"` // Execution engine, defaults to terraform.
+	GKEKubernetesVersion         string              `json:"gkeKubernetesVersion,omitempty" yaml:"gkeKubernetesVersion,omitempty"`
+	IdempotencyCheck             bool                `json:"idempotencyCheck,omitempty" yaml:"idempotencyCheck,omitempty"`
+	KubernetesVersion            string              `json:"kubernetesVersion,omitempty" yaml:"kubernetesVersion,omitempty"` // Kubernetes version of the downstream cluster.
+	KeepWorkspaceOnFailure       bool                `json:"keepWorkspaceOnFailure,omitempty" yaml:"keepWorkspaceOnFailure,omitempty"`
+	LocalQaseReporting           bool                `json:"localQaseReporting,omitempty" yaml:"localQaseReporting,omitempty" default:"false"`
+	EtcdCount                    int64               `json:"etcdCount,omitempty" yaml:"etcdCount,omitempty"`
+	ControlPlaneCount            int64               `json:"controlPlaneCount,omitempty" yaml:"controlPlaneCount,omitempty"`
+	WorkerCount                  int64               `json:"workerCount,omitempty" yaml:"workerCount,omitempty"`
+	Nodepools                    []Nodepool          `json:"nodepools,omitempty" yaml:"nodepools,omitempty"`   // Node pools of the downstream cluster.
+	PathToRepo                   string              `json:"pathToRepo,omitempty" yaml:"pathToRepo,omitempty"` // Path to this repo from the user's go directory.
+	PluginCacheDir               string              `json:"pluginCacheDir,omitempty" yaml:"pluginCacheDir,omitempty"`
+	ProviderSchema               string              `json:"providerSchema,omitempty" yaml:"providerSchema,omitempty"`
+	PSACT                        string              `json:"psact,omitempty" yaml:"psact,omitempty"`                           // Pod security admission configuration template of the downstream cluster.
+	RotateCertificates           *RotateCertificates `json:"rotateCertificates,omitempty" yaml:"rotateCertificates,omitempty"` // Rotates the certificates of RKE1/RKE2/K3s node driver clusters.
+	SnapshotInput                Snapshots           `json:"snapshotInput,omitempty" yaml:"snapshotInput,omitempty"`
+	StandaloneLogging            bool                `json:"standaloneLogging,omitempty" yaml:"standaloneLogging,omitempty"`
+	TFLogging                    bool                `json:"tfLogging,omitempty" yaml:"tfLogging,omitempty"`
+	UpgradedAKSKubernetesVersion string              `json:"upgradedAKSKubernetesVersion,omitempty" yaml:"upgradedAKSKubernetesVersion,omitempty"`
+	UpgradedEKSKubernetesVersion string              `json:"upgradedEKSKubernetesVersion,omitempty" yaml:"upgradedEKSKubernetesVersion,omitempty"`
+	UpgradedGKEKubernetesVersion string              `json:"upgradedGKEKubernetesVersion,omitempty" yaml:"upgradedGKEKubernetesVersion,omitempty"`
+	UpgradedKubernetesVersion    string              `json:"upgradedKubernetesVersion,omitempty" yaml:"upgradedKubernetesVersion,omitempty"`
+	WindowsNodeCount             int64               `json:"windowsNodeCount,omitempty" yaml:"windowsNodeCount,omitempty"`
 }
 
 // LoadTFPConfigs loads the TFP configurations from the provided map
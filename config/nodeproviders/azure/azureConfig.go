@@ -6,6 +6,7 @@ type Config struct {
 	CustomData              string   `json:"customData,omitempty" yaml:"customData,omitempty"`
 	DiskSize                string   `json:"diskSize,omitempty" yaml:"diskSize,omitempty"`
 	DNS                     string   `json:"dns,omitempty" yaml:"dns,omitempty"`
+	DNSZone                 string   `json:"dnsZone,omitempty" yaml:"dnsZone,omitempty"`
 	FaultDomainCount        string   `json:"faultDomainCount,omitempty" yaml:"faultDomainCount,omitempty"`
 	Image                   string   `json:"image,omitempty" yaml:"image,omitempty"`
 	Location                string   `json:"location,omitempty" yaml:"location,omitempty"`
@@ -21,6 +22,7 @@ type Config struct {
 	OpenPort                []string `json:"openPort,omitempty" yaml:"openPort,omitempty"`
 	OutboundType            string   `json:"outboundType,omitempty" yaml:"outboundType,omitempty"`
 	OSDiskSizeGB            int64    `json:"osDiskSizeGB,omitempty" yaml:"osDiskSizeGB,omitempty"`
+	PrivateCluster          bool     `json:"privateCluster,omitempty" yaml:"privateCluster,omitempty"`
 	PrivateIPAddress        string   `json:"privateIpAddress,omitempty" yaml:"privateIpAddress,omitempty"`
 	ResourceGroup           string   `json:"resourceGroup,omitempty" yaml:"resourceGroup,omitempty"`
 	ResourceLocation        string   `json:"resourceLocation,omitempty" yaml:"resourceLocation,omitempty"`
@@ -1,8 +1,20 @@
 package google
 
 type Config struct {
-	Network    string `json:"network,omitempty" yaml:"network,omitempty"`
-	ProjectID  string `json:"projectID,omitempty" yaml:"projectID,omitempty"`
-	Subnetwork string `json:"subnetwork,omitempty" yaml:"subnetwork,omitempty"`
-	Region     string `json:"region,omitempty" yaml:"region,omitempty"`
+	DiskSize              string   `json:"diskSize,omitempty" yaml:"diskSize,omitempty"`
+	DiskType              string   `json:"diskType,omitempty" yaml:"diskType,omitempty"`
+	EnablePrivateEndpoint bool     `json:"enablePrivateEndpoint,omitempty" yaml:"enablePrivateEndpoint,omitempty"`
+	EnablePrivateNodes    bool     `json:"enablePrivateNodes,omitempty" yaml:"enablePrivateNodes,omitempty"`
+	MachineImage          string   `json:"machineImage,omitempty" yaml:"machineImage,omitempty"`
+	MachineType           string   `json:"machineType,omitempty" yaml:"machineType,omitempty"`
+	ManagedZone           string   `json:"managedZone,omitempty" yaml:"managedZone,omitempty"`
+	MasterIPv4CIDRBlock   string   `json:"masterIpv4CidrBlock,omitempty" yaml:"masterIpv4CidrBlock,omitempty"`
+	Network               string   `json:"network,omitempty" yaml:"network,omitempty"`
+	OpenPort              []string `json:"openPort,omitempty" yaml:"openPort,omitempty"`
+	ProjectID             string   `json:"projectID,omitempty" yaml:"projectID,omitempty"`
+	Subnetwork            string   `json:"subnetwork,omitempty" yaml:"subnetwork,omitempty"`
+	Region                string   `json:"region,omitempty" yaml:"region,omitempty"`
+	Tags                  []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Username              string   `json:"username,omitempty" yaml:"username,omitempty"`
+	Zone                  string   `json:"zone,omitempty" yaml:"zone,omitempty"`
 }
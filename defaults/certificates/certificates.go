@@ -0,0 +1,47 @@
+package certificates
+
+// Services whose certificates can be rotated on RKE2/K3s clusters.
+const (
+	Admin             = "admin"
+	APIServer         = "api-server"
+	AuthProxy         = "auth-proxy"
+	CloudController   = "cloud-controller"
+	ControllerManager = "controller-manager"
+	Etcd              = "etcd"
+	K3sController     = "k3s-controller"
+	K3sServer         = "k3s-server"
+	KubeProxy         = "kube-proxy"
+	Kubelet           = "kubelet"
+	RKE2Controller    = "rke2-controller"
+	RKE2Server        = "rke2-server"
+	Scheduler         = "scheduler"
+)
+
+// Services whose certificates can be rotated on RKE1 clusters, in addition to etcd, kubelet and kube-proxy.
+const (
+	KubeAPIServer         = "kube-apiserver"
+	KubeControllerManager = "kube-controller-manager"
+	KubeScheduler         = "kube-scheduler"
+)
+
+// Ports on which the nodes serve the certificates of the services, as reached from outside the node.
+const (
+	APIServerPort = 6443
+	EtcdPort      = 2379
+	KubeletPort   = 10250
+)
+
+// RKE2Services returns the services whose certificates can be rotated on RKE2 clusters.
+func RKE2Services() []string {
+	return []string{Admin, APIServer, AuthProxy, CloudController, ControllerManager, Etcd, KubeProxy, Kubelet, RKE2Controller, RKE2Server, Scheduler}
+}
+
+// K3sServices returns the services whose certificates can be rotated on K3s clusters.
+func K3sServices() []string {
+	return []string{Admin, APIServer, AuthProxy, CloudController, ControllerManager, Etcd, K3sController, K3sServer, KubeProxy, Kubelet, Scheduler}
+}
+
+// RKE1Services returns the services whose certificates can be rotated on RKE1 clusters.
+func RKE1Services() []string {
+	return []string{Etcd, Kubelet, KubeAPIServer, KubeControllerManager, KubeProxy, KubeScheduler}
+}
@@ -15,6 +15,7 @@ const (
 	SecondHighestVersion = "second"
 
 	MainTF          = "/main.tf"
+	SecretsTFVars   = "/secrets.auto.tfvars.json"
 	RKEDebugLog     = "/rke_debug.log"
 	TerraformFolder = "/.terraform"
 	TFState         = "/terraform.tfstate"
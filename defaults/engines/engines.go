@@ -0,0 +1,9 @@
+package engines
+
+const (
+	Terraform = "terraform"
+	Tofu      = "tofu"
+
+	TerraformRegistry = "registry.terraform.io"
+	TofuRegistry      = "registry.opentofu.org"
+)
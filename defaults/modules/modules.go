@@ -5,16 +5,26 @@ const (
 	EKS = "eks"
 	GKE = "gke"
 
+	AKSImport = "aks_import"
+	EKSImport = "eks_import"
+	GKEImport = "gke_import"
+
 	AzureRKE1 = "azure_rke1"
 	AzureRKE2 = "azure_rke2"
 	AzureK3s  = "azure_k3s"
 
+	CustomAzureRKE2 = "azure_rke2_custom"
+	CustomAzureK3s  = "azure_k3s_custom"
+
 	CustomEC2RKE1            = "ec2_rke1_custom"
 	CustomEC2RKE2            = "ec2_rke2_custom"
 	CustomEC2RKE2Windows2019 = "ec2_rke2_windows_2019_custom"
 	CustomEC2RKE2Windows2022 = "ec2_rke2_windows_2022_custom"
 	CustomEC2K3s             = "ec2_k3s_custom"
 
+	CustomGCERKE2 = "gce_rke2_custom"
+	CustomGCEK3s  = "gce_k3s_custom"
+
 	CustomVsphereRKE1 = "vsphere_rke1_custom"
 	CustomVsphereRKE2 = "vsphere_rke2_custom"
 	CustomVsphereK3s  = "vsphere_k3s_custom"
@@ -24,6 +34,9 @@ const (
 	EC2RKE2 = "ec2_rke2"
 	EC2K3s  = "ec2_k3s"
 
+	GCERKE2 = "gce_rke2"
+	GCEK3s  = "gce_k3s"
+
 	HarvesterRKE1 = "harvester_rke1"
 	HarvesterRKE2 = "harvester_rke2"
 	HarvesterK3s  = "harvester_k3s"
@@ -34,6 +47,12 @@ const (
 	ImportEC2RKE2Windows2022 = "ec2_rke2_windows_2022_import"
 	ImportEC2K3s             = "ec2_k3s_import"
 
+	ImportAzureRKE2 = "azure_rke2_import"
+	ImportAzureK3s  = "azure_k3s_import"
+
+	ImportGCERKE2 = "gce_rke2_import"
+	ImportGCEK3s  = "gce_k3s_import"
+
 	ImportVsphereRKE1 = "vsphere_rke1_import"
 	ImportVsphereRKE2 = "vsphere_rke2_import"
 	ImportVsphereK3s  = "vsphere_k3s_import"
@@ -2,6 +2,8 @@ package providers
 
 const (
 	AWS       = "aws"
+	Azure     = "azure"
+	Google    = "google"
 	Linode    = "linode"
 	Harvester = "harvester"
 	Vsphere   = "vsphere"
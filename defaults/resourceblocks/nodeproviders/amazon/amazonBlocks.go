@@ -27,4 +27,9 @@ const (
 	DesiredSize  = "desired_size"
 	MaxSize      = "max_size"
 	MinSize      = "min_size"
+
+	LaunchTemplate       = "launch_template"
+	LaunchTemplateID     = "id"
+	RequestSpotInstances = "request_spot_instances"
+	SpotInstanceTypes    = "spot_instance_types"
 )
@@ -22,6 +22,7 @@ const (
 	NetworkDNSServiceIP     = "network_dns_service_ip"
 	NetworkDockerBridgeCIDR = "network_docker_bridge_cidr"
 	NetworkServiceCIDR      = "network_service_cidr"
+	PrivateCluster          = "private_cluster"
 
 	AvailabilitySet   = "availability_set"
 	CustomData        = "custom_data"
@@ -47,4 +48,7 @@ const (
 	OSDiskSizeGB        = "os_disk_size_gb"
 	Taints              = "taints"
 	VMSize              = "vm_size"
+	EnableAutoScaling   = "enable_auto_scaling"
+	MaxCount            = "max_count"
+	MinCount            = "min_count"
 )
@@ -10,8 +10,39 @@ const (
 
 	GKEConfig = "gke_config_v2"
 
+	GoogleConfig = "google_config"
+	DiskSize     = "disk_size"
+	DiskType     = "disk_type"
+	MachineImage = "machine_image"
+	MachineType  = "machine_type"
+	OpenPort     = "open_port"
+	Project      = "project"
+	SubNetwork   = "sub_network"
+	Tags         = "tags"
+	Username     = "username"
+	Zone         = "zone"
+
+	PrivateClusterConfig  = "private_cluster_config"
+	EnablePrivateEndpoint = "enable_private_endpoint"
+	EnablePrivateNodes    = "enable_private_nodes"
+	MasterIPv4CIDRBlock   = "master_ipv4_cidr_block"
+	IPAllocationPolicy    = "ip_allocation_policy"
+	UseIPAliases          = "use_ip_aliases"
+
 	NodePools         = "node_pools"
 	InitialNodeCount  = "initial_node_count"
 	MaxPodsConstraint = "max_pods_constraint"
 	Version           = "version"
+
+	Autoscaling  = "autoscaling"
+	MaxNodeCount = "max_node_count"
+	MinNodeCount = "min_node_count"
+	NodeConfig   = "config"
+	Preemptible  = "preemptible"
+	Taints       = "taints"
+	TaintKey     = "key"
+	TaintEffect  = "effect"
+	Management   = "management"
+	AutoRepair   = "auto_repair"
+	AutoUpgrade  = "auto_upgrade"
 )
@@ -9,6 +9,7 @@ const (
 	LinodeConfig           = "linode_config"
 	LinodeCredentialConfig = "linode_credential_config"
 	Image                  = "image"
+	InstanceType           = "instance_type"
 	Interface              = "interface"
 	Mode                   = "mode"
 	NodeBalancerID         = "nodebalancer_id"
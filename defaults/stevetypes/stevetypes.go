@@ -3,7 +3,11 @@ package stevetypes
 const (
 	Deployment   = "apps.deployment"
 	Ingress      = "networking.k8s.io.ingress"
+	Job          = "batch.job"
 	Machine      = "cluster.x-k8s.io.machine"
+	Namespace    = "namespace"
+	Node         = "node"
 	Provisioning = "provisioning.cattle.io.cluster"
+	Secret       = "secret"
 	Service      = "service"
 )
@@ -6,18 +6,39 @@ import (
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/rancher/shepherd/clients/rancher"
 	"github.com/rancher/shepherd/pkg/config"
+	tfpConfig "github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/framework"
 	"github.com/sirupsen/logrus"
 )
 
-// Cleanup is a function that will run terraform destroy and cleanup Terraform resources.
+// Cleanup is a function that will run terraform destroy and cleanup Terraform resources. If the test failed and the
+// workspace is kept for debugging, the resources are kept as well. If the keyPath is a workspace created by
+// framework.SetupWorkspace, the workspace is removed whenever it is not kept, even if cleanup is disabled.
 func Cleanup(t *testing.T, terraformOptions *terraform.Options, keyPath string) {
 	rancherConfig := new(rancher.Config)
 	config.LoadConfig(configs.Rancher, rancherConfig)
 
-	if *rancherConfig.Cleanup {
+	terratestConfig := new(tfpConfig.TerratestConfig)
+	config.LoadConfig(tfpConfig.TerratestConfigurationFileKey, terratestConfig)
+
+	keepWorkspace := framework.KeepWorkspace(t, terratestConfig.KeepWorkspaceOnFailure)
+
+	if *rancherConfig.Cleanup && !keepWorkspace {
 		logrus.Infof("Cleaning up Terraform resources...")
 		terraform.Destroy(t, terraformOptions)
+	}
+
+	if framework.IsWorkspace(keyPath) {
+		err := framework.RemoveWorkspace(t, keyPath, terratestConfig.KeepWorkspaceOnFailure)
+		if err != nil {
+			logrus.Warning(err)
+		}
+
+		return
+	}
+
+	if *rancherConfig.Cleanup {
 		err := TFFilesCleanup(keyPath)
 		if err != nil {
 			logrus.Warning(err)
@@ -29,12 +29,19 @@ func TFFilesCleanup(keyPath string) error {
 		delete_file = keyPath + delete_file
 		err = os.Remove(delete_file)
 
-		if err != nil {
+		// A remote backend keeps the state away from the main.tf file, so there is nothing to delete locally.
+		if err != nil && !os.IsNotExist(err) {
 			logrus.Errorf("Failed to delete terraform.tfstate, terraform.tfstate.backup, and terraform.lock.hcl files. Error: %v", err)
 			return err
 		}
 	}
 
+	err = os.Remove(keyPath + configs.SecretsTFVars)
+	if err != nil && !os.IsNotExist(err) {
+		logrus.Errorf("Failed to delete %s file. Error: %v", configs.SecretsTFVars, err)
+		return err
+	}
+
 	err = os.RemoveAll(keyPath + configs.TerraformFolder)
 	if err != nil {
 		logrus.Errorf("Failed to delete .terraform folder. Error: %v", err)
@@ -0,0 +1,64 @@
+package framework
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/engines"
+)
+
+// Engine is a function that will return the execution engine set in terratest.engine, defaulting to Terraform.
+func Engine(terratestConfig *config.TerratestConfig) string {
+	if terratestConfig == nil || terratestConfig.Engine == "" {
+		return engines.Terraform
+	}
+
+	return terratestConfig.Engine
+}
+
+// ValidateEngine is a function that will validate that the execution engine is supported.
+func ValidateEngine(engine string) error {
+	switch engine {
+	case engines.Terraform, engines.Tofu:
+		return nil
+	default:
+		return fmt.Errorf("unsupported engine %q, must be one of %s or %s", engine, engines.Terraform, engines.Tofu)
+	}
+}
+
+// ProviderSource is a function that will qualify a provider source address with the registry host of the engine, so the provider
+// is always installed from the registry that is being certified. Addresses that already include a host are returned as is.
+func ProviderSource(engine, source string) string {
+	if strings.Count(source, "/") > 1 {
+		return source
+	}
+
+	if engine == engines.Tofu {
+		return engines.TofuRegistry + "/" + source
+	}
+
+	return source
+}
+
+// EngineVersion is a function that will return the version reported by the binary of the engine, such as 1.8.5.
+func EngineVersion(engine string) (string, error) {
+	output, err := exec.Command(engine, "version", "-json").Output()
+	if err != nil {
+		return "", err
+	}
+
+	// OpenTofu keeps the terraform_version key for compatibility.
+	version := struct {
+		Version string `json:"terraform_version"`
+	}{}
+
+	err = json.Unmarshal(output, &version)
+	if err != nil {
+		return "", err
+	}
+
+	return version.Version, nil
+}
@@ -0,0 +1,25 @@
+package framework
+
+import (
+	"testing"
+
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/engines"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine(t *testing.T) {
+	require.Equal(t, engines.Terraform, Engine(nil))
+	require.Equal(t, engines.Terraform, Engine(&config.TerratestConfig{}))
+	require.Equal(t, engines.Tofu, Engine(&config.TerratestConfig{Engine: engines.Tofu}))
+
+	require.NoError(t, ValidateEngine(engines.Terraform))
+	require.NoError(t, ValidateEngine(engines.Tofu))
+	require.Error(t, ValidateEngine("pulumi"))
+}
+
+func TestProviderSource(t *testing.T) {
+	require.Equal(t, "rancher/rancher2", ProviderSource(engines.Terraform, "rancher/rancher2"))
+	require.Equal(t, "registry.opentofu.org/rancher/rancher2", ProviderSource(engines.Tofu, "rancher/rancher2"))
+	require.Equal(t, "terraform.local/local/rancher2", ProviderSource(engines.Tofu, "terraform.local/local/rancher2"))
+}
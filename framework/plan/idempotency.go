@@ -0,0 +1,99 @@
+package plan
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	idempotencyPlanFile = "tfplan-idempotency"
+
+	// NonEmptyPlan starts the report of a non-empty plan after apply, so the Qase reporter can find it in the test output.
+	NonEmptyPlan = "Non-empty plan after apply:"
+
+	emptyPlanExitCode   = 0
+	changedPlanExitCode = 2
+)
+
+// CheckIdempotency is a function that will run terraform plan -detailed-exitcode after a successful apply. A non-empty plan means
+// the provider has a perpetual diff, so the offending resources and attribute paths are logged to the test output and returned.
+func CheckIdempotency(t *testing.T, terraformOptions *terraform.Options) error {
+	planOptions := *terraformOptions
+	planOptions.PlanFilePath = filepath.Join(terraformOptions.TerraformDir, idempotencyPlanFile)
+
+	defer os.Remove(planOptions.PlanFilePath)
+
+	logrus.Infof("Checking that the applied configuration has an empty plan...")
+
+	exitCode, err := terraform.PlanExitCodeE(t, &planOptions)
+	if err != nil {
+		return err
+	}
+
+	switch exitCode {
+	case emptyPlanExitCode:
+		return nil
+	case changedPlanExitCode:
+	default:
+		return fmt.Errorf("terraform plan failed with exit code %d", exitCode)
+	}
+
+	planStruct, err := terraform.ShowWithStructE(t, &planOptions)
+	if err != nil {
+		return err
+	}
+
+	report := ReportChanges(planStruct)
+	t.Log(report)
+
+	return fmt.Errorf("%s", report)
+}
+
+// ReportChanges is a function that will describe every planned resource and output change, with the attribute paths that changed.
+func ReportChanges(planStruct *terraform.PlanStruct) string {
+	lines := []string{NonEmptyPlan}
+
+	addresses := make([]string, 0, len(planStruct.ResourceChangesMap))
+	for address := range planStruct.ResourceChangesMap {
+		addresses = append(addresses, address)
+	}
+
+	sort.Strings(addresses)
+
+	for _, address := range addresses {
+		resourceChange := planStruct.ResourceChangesMap[address]
+		if resourceChange.Change == nil || resourceChange.Change.Actions.NoOp() || resourceChange.Change.Actions.Read() {
+			continue
+		}
+
+		lines = append(lines, fmt.Sprintf("%s is planned to %s", address, ActionName(resourceChange.Change.Actions)))
+
+		diff := Diff(resourceChange.Change)
+		if diff != "" {
+			lines = append(lines, diff)
+		}
+	}
+
+	outputs := make([]string, 0, len(planStruct.RawPlan.OutputChanges))
+	for name, change := range planStruct.RawPlan.OutputChanges {
+		if change != nil && !change.Actions.NoOp() {
+			outputs = append(outputs, name)
+		}
+	}
+
+	sort.Strings(outputs)
+
+	for _, name := range outputs {
+		change := planStruct.RawPlan.OutputChanges[name]
+		lines = append(lines, fmt.Sprintf("output.%s is planned to %s", name, ActionName(change.Actions)))
+	}
+
+	return strings.Join(lines, "\n")
+}
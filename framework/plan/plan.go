@@ -0,0 +1,321 @@
+package plan
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	planFile = "tfplan"
+
+	NoOp    = "no-op"
+	Create  = "create"
+	Read    = "read"
+	Update  = "update"
+	Delete  = "delete"
+	Replace = "replace"
+
+	knownAfterApply = "(known after apply)"
+	sensitiveValue  = "(sensitive value)"
+)
+
+// Rule is the set of actions allowed on every resource whose address matches the pattern. Patterns use path.Match syntax,
+// such as rancher2_cluster_v2.* or rancher2_cloud_credential.*, and resources not matching any rule may have any action.
+type Rule struct {
+	Pattern string
+	Actions []string
+}
+
+type diffLine struct {
+	attribute string
+	text      string
+}
+
+// UpdateOnly is the set of rules that allow clusters and cloud credentials to be updated in place, but never destroyed or
+// replaced. This is what upgrades and snapshot operations are expected to plan.
+var UpdateOnly = []Rule{
+	{Pattern: "rancher2_cluster.*", Actions: []string{NoOp, Update}},
+	{Pattern: "rancher2_cluster_v2.*", Actions: []string{NoOp, Update}},
+	{Pattern: "rancher2_cloud_credential.*", Actions: []string{NoOp, Update}},
+}
+
+// PlanAndApply is a function that will run terraform plan -out and terraform show -json, check the planned action of every
+// resource against the rules and only then apply the saved plan. The test fails with the offending changes when a rule is violated.
+func PlanAndApply(t *testing.T, terraformOptions *terraform.Options, rules []Rule) error {
+	planOptions := *terraformOptions
+	planOptions.PlanFilePath = filepath.Join(terraformOptions.TerraformDir, planFile)
+
+	defer os.Remove(planOptions.PlanFilePath)
+
+	_, err := terraform.PlanE(t, &planOptions)
+	if err != nil {
+		return err
+	}
+
+	planStruct, err := terraform.ShowWithStructE(t, &planOptions)
+	if err != nil {
+		return err
+	}
+
+	err = CheckPlan(planStruct, rules)
+	if err != nil {
+		return err
+	}
+
+	logrus.Infof("Planned changes match the expected actions, applying the saved plan...")
+
+	_, err = terraform.ApplyE(t, &planOptions)
+
+	return err
+}
+
+// CheckPlan is a function that will check the planned action of every resource change against the rules. All violations are
+// returned together, each with the attribute paths that changed.
+func CheckPlan(planStruct *terraform.PlanStruct, rules []Rule) error {
+	addresses := make([]string, 0, len(planStruct.ResourceChangesMap))
+	for address := range planStruct.ResourceChangesMap {
+		addresses = append(addresses, address)
+	}
+
+	sort.Strings(addresses)
+
+	var errs []error
+	for _, address := range addresses {
+		resourceChange := planStruct.ResourceChangesMap[address]
+		if resourceChange.Change == nil {
+			continue
+		}
+
+		action := ActionName(resourceChange.Change.Actions)
+
+		for _, rule := range rules {
+			matched, err := path.Match(rule.Pattern, address)
+			if err != nil {
+				return fmt.Errorf("invalid pattern %q: %w", rule.Pattern, err)
+			}
+
+			if !matched || contains(rule.Actions, action) {
+				continue
+			}
+
+			errs = append(errs, fmt.Errorf("%s is planned to %s, expected %s\n%s", address, action, strings.Join(rule.Actions, " or "),
+				Diff(resourceChange.Change)))
+
+			break
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("unexpected planned changes:\n%w", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// ActionName is a function that will return a single name for the planned actions, so a delete and create pair is a replace.
+func ActionName(actions tfjson.Actions) string {
+	switch {
+	case actions.Replace():
+		return Replace
+	case len(actions) == 1:
+		return string(actions[0])
+	default:
+		names := make([]string, 0, len(actions))
+		for _, action := range actions {
+			names = append(names, string(action))
+		}
+
+		return strings.Join(names, ",")
+	}
+}
+
+// Diff is a function that will return the changed attribute paths of a resource change, one per line, in the style of terraform plan.
+// Sensitive values are masked and attributes forcing a replacement are marked.
+func Diff(change *tfjson.Change) string {
+	diffLines := []diffLine{}
+	diffValue("", change.Before, change.After, change.BeforeSensitive, change.AfterSensitive, change.AfterUnknown, &diffLines)
+
+	replacePaths := map[string]bool{}
+	for _, replacePath := range change.ReplacePaths {
+		replacePaths[formatPath(replacePath)] = true
+	}
+
+	sort.Slice(diffLines, func(i, j int) bool {
+		return diffLines[i].attribute < diffLines[j].attribute
+	})
+
+	lines := make([]string, 0, len(diffLines))
+	for _, line := range diffLines {
+		if replacePaths[line.attribute] {
+			line.text += " # forces replacement"
+		}
+
+		lines = append(lines, line.text)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// diffValue is a helper function that will walk the before and after values and add a line for every leaf that differs.
+func diffValue(attribute string, before, after, beforeSensitive, afterSensitive, afterUnknown any, lines *[]diffLine) {
+	beforeMap, beforeIsMap := before.(map[string]any)
+	afterMap, afterIsMap := after.(map[string]any)
+
+	if beforeIsMap || afterIsMap {
+		keys := map[string]bool{}
+		for key := range beforeMap {
+			keys[key] = true
+		}
+
+		for key := range afterMap {
+			keys[key] = true
+		}
+
+		if unknown, ok := afterUnknown.(map[string]any); ok {
+			for key := range unknown {
+				keys[key] = true
+			}
+		}
+
+		for key := range keys {
+			diffValue(join(attribute, key), beforeMap[key], afterMap[key], child(beforeSensitive, key), child(afterSensitive, key),
+				child(afterUnknown, key), lines)
+		}
+
+		return
+	}
+
+	beforeList, beforeIsList := before.([]any)
+	afterList, afterIsList := after.([]any)
+
+	if beforeIsList || afterIsList {
+		length := max(len(beforeList), len(afterList))
+		if unknown, ok := afterUnknown.([]any); ok {
+			length = max(length, len(unknown))
+		}
+
+		for i := 0; i < length; i++ {
+			key := fmt.Sprintf("[%d]", i)
+			diffValue(attribute+key, index(beforeList, i), index(afterList, i), child(beforeSensitive, i), child(afterSensitive, i),
+				child(afterUnknown, i), lines)
+		}
+
+		return
+	}
+
+	if afterUnknown == true {
+		*lines = append(*lines, diffLine{attribute, fmt.Sprintf("  ~ %s: %s => %s", attribute, format(before, beforeSensitive), knownAfterApply)})
+		return
+	}
+
+	if fmt.Sprint(before) == fmt.Sprint(after) {
+		return
+	}
+
+	switch {
+	case before == nil:
+		*lines = append(*lines, diffLine{attribute, fmt.Sprintf("  + %s: %s", attribute, format(after, afterSensitive))})
+	case after == nil:
+		*lines = append(*lines, diffLine{attribute, fmt.Sprintf("  - %s: %s", attribute, format(before, beforeSensitive))})
+	default:
+		*lines = append(*lines, diffLine{attribute, fmt.Sprintf("  ~ %s: %s => %s", attribute, format(before, beforeSensitive), format(after, afterSensitive))})
+	}
+}
+
+// child is a helper function that will return the nested value of a map key or list index, or the value itself when it applies
+// to the whole structure, such as a sensitive marker set on a block.
+func child(value any, key any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		if name, ok := key.(string); ok {
+			return typed[name]
+		}
+	case []any:
+		if i, ok := key.(int); ok {
+			return index(typed, i)
+		}
+	case bool:
+		return typed
+	}
+
+	return nil
+}
+
+// index is a helper function that will return the list element at i, or nil when the list is shorter.
+func index(list []any, i int) any {
+	if i < len(list) {
+		return list[i]
+	}
+
+	return nil
+}
+
+// join is a helper function that will join an attribute path and a key.
+func join(attribute, key string) string {
+	if attribute == "" {
+		return key
+	}
+
+	return attribute + "." + key
+}
+
+// format is a helper function that will format a value, masking it when sensitive.
+func format(value, sensitive any) string {
+	if sensitive == true {
+		return sensitiveValue
+	}
+
+	if text, ok := value.(string); ok {
+		return fmt.Sprintf("%q", text)
+	}
+
+	if value == nil {
+		return "null"
+	}
+
+	return fmt.Sprint(value)
+}
+
+// formatPath is a helper function that will format a replace path from the plan, such as ["rke_config", 0, "name"], the same way
+// as the attribute paths of the diff.
+func formatPath(replacePath any) string {
+	steps, ok := replacePath.([]any)
+	if !ok {
+		return fmt.Sprint(replacePath)
+	}
+
+	attribute := ""
+	for _, step := range steps {
+		switch typed := step.(type) {
+		case string:
+			attribute = join(attribute, typed)
+		case float64:
+			attribute += fmt.Sprintf("[%d]", int(typed))
+		default:
+			attribute = join(attribute, fmt.Sprint(typed))
+		}
+	}
+
+	return attribute
+}
+
+// contains is a helper function that will check if the action is one of the allowed actions.
+func contains(actions []string, action string) bool {
+	for _, allowed := range actions {
+		if allowed == action {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,103 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+const planJSON = `{
+  "format_version": "1.2",
+  "resource_changes": [
+    {
+      "address": "rancher2_cluster_v2.tfp",
+      "type": "rancher2_cluster_v2",
+      "name": "tfp",
+      "change": {
+        "actions": ["delete", "create"],
+        "before": {"name": "tfp", "kubernetes_version": "v1.31.9+rke2r1", "rke_config": [{"machine_pools": [{"name": "pool0"}]}]},
+        "after": {"name": "tfp-new", "kubernetes_version": "v1.32.5+rke2r1", "rke_config": [{"machine_pools": [{"name": "pool0"}]}]},
+        "after_unknown": {"id": true},
+        "before_sensitive": {},
+        "after_sensitive": {},
+        "replace_paths": [["name"]]
+      }
+    },
+    {
+      "address": "rancher2_cloud_credential.tfp",
+      "type": "rancher2_cloud_credential",
+      "name": "tfp",
+      "change": {
+        "actions": ["update"],
+        "before": {"amazonec2_credential_config": [{"secret_key": "old-secret"}]},
+        "after": {"amazonec2_credential_config": [{"secret_key": "new-secret"}]},
+        "after_unknown": {},
+        "before_sensitive": {"amazonec2_credential_config": [{"secret_key": true}]},
+        "after_sensitive": {"amazonec2_credential_config": [{"secret_key": true}]}
+      }
+    },
+    {
+      "address": "rancher2_machine_config_v2.tfp",
+      "type": "rancher2_machine_config_v2",
+      "name": "tfp",
+      "change": {
+        "actions": ["delete", "create"],
+        "before": {},
+        "after": {},
+        "after_unknown": {}
+      }
+    }
+  ]
+}`
+
+func TestCheckPlan(t *testing.T) {
+	planStruct, err := terraform.ParsePlanJSON(planJSON)
+	require.NoError(t, err)
+
+	err = CheckPlan(planStruct, UpdateOnly)
+	require.EqualError(t, err, `unexpected planned changes:
+rancher2_cluster_v2.tfp is planned to replace, expected no-op or update
+  ~ id: null => (known after apply)
+  ~ kubernetes_version: "v1.31.9+rke2r1" => "v1.32.5+rke2r1"
+  ~ name: "tfp" => "tfp-new" # forces replacement`)
+
+	err = CheckPlan(planStruct, []Rule{{Pattern: "rancher2_cloud_credential.*", Actions: []string{NoOp}}})
+	require.EqualError(t, err, `unexpected planned changes:
+rancher2_cloud_credential.tfp is planned to update, expected no-op
+  ~ amazonec2_credential_config[0].secret_key: (sensitive value) => (sensitive value)`)
+
+	err = CheckPlan(planStruct, []Rule{{Pattern: "rancher2_cloud_credential.*", Actions: []string{Update}}})
+	require.NoError(t, err)
+}
+
+func TestReportChanges(t *testing.T) {
+	planStruct, err := terraform.ParsePlanJSON(`{
+  "format_version": "1.2",
+  "resource_changes": [
+    {
+      "address": "rancher2_cluster_v2.tfp",
+      "change": {
+        "actions": ["update"],
+        "before": {"rke_config": [{"machine_pools": [{"name": "pool0"}, {"name": "pool1"}]}]},
+        "after": {"rke_config": [{"machine_pools": [{"name": "pool1"}, {"name": "pool0"}]}]},
+        "after_unknown": {}
+      }
+    },
+    {
+      "address": "rancher2_cloud_credential.tfp",
+      "change": {"actions": ["no-op"], "before": {}, "after": {}, "after_unknown": {}}
+    }
+  ],
+  "output_changes": {
+    "kubeconfig": {"actions": ["update"], "before": "old", "after": "new", "after_unknown": false}
+  }
+}`)
+	require.NoError(t, err)
+
+	require.Equal(t, `Non-empty plan after apply:
+rancher2_cluster_v2.tfp is planned to update
+  ~ rke_config[0].machine_pools[0].name: "pool0" => "pool1"
+  ~ rke_config[0].machine_pools[1].name: "pool1" => "pool0"
+output.kubeconfig is planned to update`, ReportChanges(planStruct))
+}
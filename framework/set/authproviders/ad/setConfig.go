@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -29,13 +30,13 @@ func SetAD(terraformConfig *config.TerraformConfig, newFile *hclwrite.File, root
 
 	adBlockBody.SetAttributeValue(port, cty.NumberIntVal(int64(terraformConfig.ADConfig.Port)))
 	adBlockBody.SetAttributeValue(servers, cty.ListVal([]cty.Value{cty.StringVal(terraformConfig.ADConfig.Servers[0])}))
-	adBlockBody.SetAttributeValue(serviceAccountPassword, cty.StringVal(terraformConfig.ADConfig.ServiceAccountPassword))
+	secrets.SetSensitiveAttribute(newFile, adBlockBody, serviceAccountPassword, secrets.ADServiceAccountPassword, terraformConfig.ADConfig.ServiceAccountPassword)
 	adBlockBody.SetAttributeValue(serviceAccountUsername, cty.StringVal(terraformConfig.ADConfig.ServiceAccountUsername))
 	adBlockBody.SetAttributeValue(userSearchBase, cty.StringVal(terraformConfig.ADConfig.UserSearchBase))
 	adBlockBody.SetAttributeValue(testUsername, cty.StringVal(terraformConfig.ADConfig.TestUsername))
-	adBlockBody.SetAttributeValue(testPassword, cty.StringVal(terraformConfig.ADConfig.TestPassword))
+	secrets.SetSensitiveAttribute(newFile, adBlockBody, testPassword, secrets.ADTestPassword, terraformConfig.ADConfig.TestPassword)
 
-	_, err := file.Write(newFile.Bytes())
+	err := secrets.WriteMainTF(file, newFile)
 	if err != nil {
 		logrus.Infof("Failed to write Active Directory configurations to main.tf file. Error: %v", err)
 		return err
@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/shepherd/clients/rancher"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -30,14 +31,14 @@ func SetAzureAD(rancherConfig *rancher.Config, terraformConfig *config.Terraform
 	azureADBlockBody := azureADBlock.Body()
 
 	azureADBlockBody.SetAttributeValue(applicationID, cty.StringVal(terraformConfig.AzureADConfig.ApplicationID))
-	azureADBlockBody.SetAttributeValue(applicationSecret, cty.StringVal(terraformConfig.AzureADConfig.ApplicationSecret))
+	secrets.SetSensitiveAttribute(newFile, azureADBlockBody, applicationSecret, secrets.AzureADApplicationSecret, terraformConfig.AzureADConfig.ApplicationSecret)
 	azureADBlockBody.SetAttributeValue(authEndpoint, cty.StringVal(terraformConfig.AzureADConfig.AuthEndpoint))
 	azureADBlockBody.SetAttributeValue(graphEndpoint, cty.StringVal(terraformConfig.AzureADConfig.GraphEndpoint))
 	azureADBlockBody.SetAttributeValue(rancherURL, cty.StringVal("https://"+rancherConfig.Host))
 	azureADBlockBody.SetAttributeValue(tenantID, cty.StringVal(terraformConfig.AzureADConfig.TenantID))
 	azureADBlockBody.SetAttributeValue(tokenEndpoint, cty.StringVal(terraformConfig.AzureADConfig.TokenEndpoint))
 
-	_, err := file.Write(newFile.Bytes())
+	err := secrets.WriteMainTF(file, newFile)
 	if err != nil {
 		logrus.Infof("Failed to write Azure AD configurations to main.tf file. Error: %v", err)
 		return err
@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -23,9 +24,9 @@ func SetGithub(terraformConfig *config.TerraformConfig, newFile *hclwrite.File,
 	githubBlockBody := githubBlock.Body()
 
 	githubBlockBody.SetAttributeValue(clientID, cty.StringVal(terraformConfig.GithubConfig.ClientID))
-	githubBlockBody.SetAttributeValue(clientSecret, cty.StringVal(terraformConfig.GithubConfig.ClientSecret))
+	secrets.SetSensitiveAttribute(newFile, githubBlockBody, clientSecret, secrets.GithubClientSecret, terraformConfig.GithubConfig.ClientSecret)
 
-	_, err := file.Write(newFile.Bytes())
+	err := secrets.WriteMainTF(file, newFile)
 	if err != nil {
 		logrus.Infof("Failed to write Github configurations to main.tf file. Error: %v", err)
 		return err
@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -30,12 +31,12 @@ func SetOpenLDAP(terraformConfig *config.TerraformConfig, newFile *hclwrite.File
 	openLDAPBlockBody.SetAttributeValue(port, cty.NumberIntVal(int64(terraformConfig.OpenLDAPConfig.Port)))
 	openLDAPBlockBody.SetAttributeValue(servers, cty.ListVal([]cty.Value{cty.StringVal(terraformConfig.OpenLDAPConfig.Servers[0])}))
 	openLDAPBlockBody.SetAttributeValue(serviceAccountDistinguisedName, cty.StringVal(terraformConfig.OpenLDAPConfig.ServiceAccountDistinguisedName))
-	openLDAPBlockBody.SetAttributeValue(serviceAccountPassword, cty.StringVal(terraformConfig.OpenLDAPConfig.ServiceAccountPassword))
+	secrets.SetSensitiveAttribute(newFile, openLDAPBlockBody, serviceAccountPassword, secrets.OpenLDAPServiceAccountPassword, terraformConfig.OpenLDAPConfig.ServiceAccountPassword)
 	openLDAPBlockBody.SetAttributeValue(userSearchBase, cty.StringVal(terraformConfig.OpenLDAPConfig.UserSearchBase))
 	openLDAPBlockBody.SetAttributeValue(testUsername, cty.StringVal(terraformConfig.OpenLDAPConfig.TestUsername))
-	openLDAPBlockBody.SetAttributeValue(testPassword, cty.StringVal(terraformConfig.OpenLDAPConfig.TestPassword))
+	secrets.SetSensitiveAttribute(newFile, openLDAPBlockBody, testPassword, secrets.OpenLDAPTestPassword, terraformConfig.OpenLDAPConfig.TestPassword)
 
-	_, err := file.Write(newFile.Bytes())
+	err := secrets.WriteMainTF(file, newFile)
 	if err != nil {
 		logrus.Infof("Failed to write OpenLDAP configurations to main.tf file. Error: %v", err)
 		return err
@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/shepherd/clients/rancher"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -39,7 +40,7 @@ func SetOkta(rancherConfig *rancher.Config, terraformConfig *config.TerraformCon
 	oktaBlockBody.SetAttributeValue(uidField, cty.StringVal(terraformConfig.OktaConfig.UIDField))
 	oktaBlockBody.SetAttributeValue(userNameField, cty.StringVal(terraformConfig.OktaConfig.UserNameField))
 
-	_, err := file.Write(newFile.Bytes())
+	err := secrets.WriteMainTF(file, newFile)
 	if err != nil {
 		logrus.Infof("Failed to write Okta configurations to main.tf file. Error: %v", err)
 		return err
@@ -0,0 +1,102 @@
+package backend
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+)
+
+const (
+	lockMethod   = "LOCK"
+	unlockMethod = "UNLOCK"
+)
+
+// HTTPStateServer is a minimal stand-in for a Terraform http backend. It keeps the state and lock of every path in memory, so
+// the http backend can be exercised locally without any cloud storage.
+type HTTPStateServer struct {
+	mutex  sync.Mutex
+	states map[string][]byte
+	locks  map[string][]byte
+}
+
+type lockInfo struct {
+	ID string `json:"ID"`
+}
+
+// NewHTTPStateServer is a function that will create an empty HTTPStateServer. Serve it with httptest.NewServer or
+// http.ListenAndServe and point the address, lockAddress and unlockAddress of the backend to the same URL.
+func NewHTTPStateServer() *HTTPStateServer {
+	return &HTTPStateServer{
+		states: map[string][]byte{},
+		locks:  map[string][]byte{},
+	}
+}
+
+// ServeHTTP is a function that will implement the Terraform http backend protocol for the state at the request path.
+func (s *HTTPStateServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	statePath := r.URL.Path
+
+	switch r.Method {
+	case http.MethodGet:
+		state, ok := s.states[statePath]
+		if !ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(state)
+	case http.MethodPost:
+		if !s.holdsLock(statePath, r.URL.Query().Get("ID")) {
+			w.WriteHeader(http.StatusConflict)
+			w.Write(s.locks[statePath])
+			return
+		}
+
+		s.states[statePath] = body
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		delete(s.states, statePath)
+		w.WriteHeader(http.StatusOK)
+	case lockMethod:
+		if existing, ok := s.locks[statePath]; ok {
+			w.WriteHeader(http.StatusLocked)
+			w.Write(existing)
+			return
+		}
+
+		s.locks[statePath] = body
+		w.WriteHeader(http.StatusOK)
+	case unlockMethod:
+		delete(s.locks, statePath)
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// holdsLock is a helper function that will check if the state is unlocked or locked by the given lock ID.
+func (s *HTTPStateServer) holdsLock(statePath, id string) bool {
+	existing, ok := s.locks[statePath]
+	if !ok {
+		return true
+	}
+
+	info := lockInfo{}
+	err := json.Unmarshal(existing, &info)
+	if err != nil {
+		return false
+	}
+
+	return info.ID == id
+}
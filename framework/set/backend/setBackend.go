@@ -0,0 +1,155 @@
+package backend
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	backend = "backend"
+
+	S3      = "s3"
+	GCS     = "gcs"
+	AzureRM = "azurerm"
+	HTTP    = "http"
+	Local   = "local"
+
+	address                   = "address"
+	bucket                    = "bucket"
+	containerName             = "container_name"
+	endpoints                 = "endpoints"
+	key                       = "key"
+	lockAddress               = "lock_address"
+	statePath                 = "path"
+	prefix                    = "prefix"
+	region                    = "region"
+	resourceGroupName         = "resource_group_name"
+	skipCertVerification      = "skip_cert_verification"
+	skipCredentialsValidation = "skip_credentials_validation"
+	skipMetadataAPICheck      = "skip_metadata_api_check"
+	skipRegionValidation      = "skip_region_validation"
+	skipRequestingAccountID   = "skip_requesting_account_id"
+	storageAccountName        = "storage_account_name"
+	unlockAddress             = "unlock_address"
+	usePathStyle              = "use_path_style"
+)
+
+// SetBackend is a function that will set the backend block inside of the terraform block of the main.tf file. When no backend is
+// configured, nothing is set and Terraform keeps the state in terraform.tfstate next to the main.tf file. Credentials are never
+// rendered, as backend blocks cannot reference variables; they are read by Terraform from the standard environment variables.
+// The state is kept under the workspace, which is the resource prefix of the main.tf file, so workspaces never share state.
+// The backend is expected to be checked with ValidateBackend beforehand.
+func SetBackend(tfBlockBody *hclwrite.Body, backendConfig *config.Backend, workspace string) {
+	if backendConfig == nil || backendConfig.Type == "" {
+		return
+	}
+
+	backendBlockBody := tfBlockBody.AppendNewBlock(backend, []string{backendConfig.Type}).Body()
+
+	switch backendConfig.Type {
+	case S3:
+		backendBlockBody.SetAttributeValue(bucket, cty.StringVal(backendConfig.Bucket))
+		backendBlockBody.SetAttributeValue(key, cty.StringVal(workspaceKey(backendConfig.Key, workspace)))
+		backendBlockBody.SetAttributeValue(region, cty.StringVal(backendConfig.Region))
+
+		// A custom endpoint is an S3 compatible server, such as MinIO, that does not implement the AWS account APIs.
+		if backendConfig.Endpoint != "" {
+			backendBlockBody.SetAttributeValue(endpoints, cty.ObjectVal(map[string]cty.Value{
+				S3: cty.StringVal(backendConfig.Endpoint),
+			}))
+			backendBlockBody.SetAttributeValue(usePathStyle, cty.BoolVal(true))
+			backendBlockBody.SetAttributeValue(skipCredentialsValidation, cty.BoolVal(true))
+			backendBlockBody.SetAttributeValue(skipMetadataAPICheck, cty.BoolVal(true))
+			backendBlockBody.SetAttributeValue(skipRegionValidation, cty.BoolVal(true))
+			backendBlockBody.SetAttributeValue(skipRequestingAccountID, cty.BoolVal(true))
+		}
+	case GCS:
+		backendBlockBody.SetAttributeValue(bucket, cty.StringVal(backendConfig.Bucket))
+
+		statePrefix := path.Join(backendConfig.Prefix, workspace)
+		if statePrefix != "" {
+			backendBlockBody.SetAttributeValue(prefix, cty.StringVal(statePrefix))
+		}
+	case AzureRM:
+		backendBlockBody.SetAttributeValue(resourceGroupName, cty.StringVal(backendConfig.ResourceGroupName))
+		backendBlockBody.SetAttributeValue(storageAccountName, cty.StringVal(backendConfig.StorageAccountName))
+		backendBlockBody.SetAttributeValue(containerName, cty.StringVal(backendConfig.ContainerName))
+		backendBlockBody.SetAttributeValue(key, cty.StringVal(workspaceKey(backendConfig.Key, workspace)))
+	case HTTP:
+		backendBlockBody.SetAttributeValue(address, cty.StringVal(workspaceAddress(backendConfig.Address, workspace)))
+
+		if backendConfig.LockAddress != "" {
+			backendBlockBody.SetAttributeValue(lockAddress, cty.StringVal(workspaceAddress(backendConfig.LockAddress, workspace)))
+		}
+
+		if backendConfig.UnlockAddress != "" {
+			backendBlockBody.SetAttributeValue(unlockAddress, cty.StringVal(workspaceAddress(backendConfig.UnlockAddress, workspace)))
+		}
+
+		if backendConfig.SkipCertVerification {
+			backendBlockBody.SetAttributeValue(skipCertVerification, cty.BoolVal(true))
+		}
+	case Local:
+		stateFile := filepath.Join(filepath.Dir(backendConfig.Path), workspace, filepath.Base(backendConfig.Path))
+		backendBlockBody.SetAttributeValue(statePath, cty.StringVal(stateFile))
+	}
+}
+
+// ValidateBackend is a function that will validate that the backend type is supported and that the settings it requires are set.
+// No backend at all is valid and keeps the local state.
+func ValidateBackend(backendConfig *config.Backend) error {
+	if backendConfig == nil || backendConfig.Type == "" {
+		return nil
+	}
+
+	required := map[string]string{}
+
+	switch backendConfig.Type {
+	case S3:
+		required = map[string]string{bucket: backendConfig.Bucket, key: backendConfig.Key, region: backendConfig.Region}
+	case GCS:
+		required = map[string]string{bucket: backendConfig.Bucket}
+	case AzureRM:
+		required = map[string]string{
+			resourceGroupName:  backendConfig.ResourceGroupName,
+			storageAccountName: backendConfig.StorageAccountName,
+			containerName:      backendConfig.ContainerName,
+			key:                backendConfig.Key,
+		}
+	case HTTP:
+		required = map[string]string{address: backendConfig.Address}
+	case Local:
+		required = map[string]string{statePath: backendConfig.Path}
+	default:
+		return fmt.Errorf("unsupported backend type %q, must be one of %s, %s, %s, %s or %s", backendConfig.Type, S3, GCS, AzureRM, HTTP, Local)
+	}
+
+	for _, name := range []string{bucket, key, region, resourceGroupName, storageAccountName, containerName, address, statePath} {
+		if value, ok := required[name]; ok && value == "" {
+			return fmt.Errorf("%s backend requires %s to be set", backendConfig.Type, name)
+		}
+	}
+
+	return nil
+}
+
+// workspaceKey is a helper function that will return the key of the state object nested under the workspace, such as
+// sanity/<workspace>/terraform.tfstate for the key sanity/terraform.tfstate.
+func workspaceKey(key, workspace string) string {
+	return path.Join(path.Dir(key), workspace, path.Base(key))
+}
+
+// workspaceAddress is a helper function that will return the address of the state nested under the workspace.
+func workspaceAddress(address, workspace string) string {
+	if workspace == "" {
+		return address
+	}
+
+	return strings.TrimSuffix(address, "/") + "/" + workspace
+}
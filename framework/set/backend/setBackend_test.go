@@ -0,0 +1,131 @@
+package backend
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetBackend(t *testing.T) {
+	tests := []struct {
+		name     string
+		backend  *config.Backend
+		expected string
+	}{
+		{"None", nil, "terraform {\n}\n"},
+		{"Local", &config.Backend{Type: Local, Path: "/tmp/tfp.tfstate"}, `backend "local" {
+    path = "/tmp/tfp/tfp.tfstate"
+  }`},
+		{"S3", &config.Backend{Type: S3, Bucket: "tfp", Key: "sanity/terraform.tfstate", Region: "us-east-2"}, `backend "s3" {
+    bucket = "tfp"
+    key    = "sanity/tfp/terraform.tfstate"
+    region = "us-east-2"
+  }`},
+		{"S3_Endpoint", &config.Backend{Type: S3, Bucket: "tfp", Key: "tfp.tfstate", Region: "us-east-1", Endpoint: "http://127.0.0.1:9000"},
+			`use_path_style              = true`},
+		{"GCS", &config.Backend{Type: GCS, Bucket: "tfp", Prefix: "sanity"}, `backend "gcs" {
+    bucket = "tfp"
+    prefix = "sanity/tfp"
+  }`},
+		{"AzureRM", &config.Backend{Type: AzureRM, ResourceGroupName: "tfp", StorageAccountName: "tfp", ContainerName: "tfstate",
+			Key: "tfp.tfstate"}, `backend "azurerm" {`},
+		{"HTTP", &config.Backend{Type: HTTP, Address: "http://127.0.0.1:8080/tfp", LockAddress: "http://127.0.0.1:8080/tfp",
+			UnlockAddress: "http://127.0.0.1:8080/tfp"}, `lock_address   = "http://127.0.0.1:8080/tfp/tfp"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, ValidateBackend(tt.backend))
+
+			newFile := hclwrite.NewEmptyFile()
+			tfBlockBody := newFile.Body().AppendNewBlock("terraform", nil).Body()
+
+			SetBackend(tfBlockBody, tt.backend, "tfp")
+
+			require.Contains(t, string(hclwrite.Format(newFile.Bytes())), tt.expected)
+		})
+	}
+}
+
+func TestSetBackendWorkspaces(t *testing.T) {
+	tests := []struct {
+		name     string
+		backend  *config.Backend
+		location string
+	}{
+		{"Local", &config.Backend{Type: Local, Path: "/tmp/terraform.tfstate"}, statePath},
+		{"S3", &config.Backend{Type: S3, Bucket: "tfp", Key: "terraform.tfstate", Region: "us-east-2"}, key},
+		{"GCS", &config.Backend{Type: GCS, Bucket: "tfp"}, prefix},
+		{"AzureRM", &config.Backend{Type: AzureRM, ResourceGroupName: "tfp", StorageAccountName: "tfp", ContainerName: "tfstate",
+			Key: "terraform.tfstate"}, key},
+		{"HTTP", &config.Backend{Type: HTTP, Address: "http://127.0.0.1:8080/", LockAddress: "http://127.0.0.1:8080/"}, lockAddress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locations := map[string]string{}
+			for _, workspace := range []string{"tfp-first", "tfp-second"} {
+				newFile := hclwrite.NewEmptyFile()
+				tfBlockBody := newFile.Body().AppendNewBlock("terraform", nil).Body()
+
+				SetBackend(tfBlockBody, tt.backend, workspace)
+
+				backendBlock := tfBlockBody.FirstMatchingBlock(backend, []string{tt.backend.Type})
+				require.NotNil(t, backendBlock)
+
+				attribute := backendBlock.Body().GetAttribute(tt.location)
+				require.NotNil(t, attribute)
+
+				locations[workspace] = string(attribute.Expr().BuildTokens(nil).Bytes())
+				require.Contains(t, locations[workspace], workspace)
+			}
+
+			require.NotEqual(t, locations["tfp-first"], locations["tfp-second"])
+		})
+	}
+}
+
+func TestValidateBackend(t *testing.T) {
+	err := ValidateBackend(&config.Backend{Type: "consul"})
+	require.ErrorContains(t, err, `unsupported backend type "consul"`)
+
+	err = ValidateBackend(&config.Backend{Type: S3, Bucket: "tfp", Region: "us-east-2"})
+	require.EqualError(t, err, "s3 backend requires key to be set")
+
+	err = ValidateBackend(&config.Backend{Type: Local})
+	require.EqualError(t, err, "local backend requires path to be set")
+}
+
+func TestHTTPStateServer(t *testing.T) {
+	server := httptest.NewServer(NewHTTPStateServer())
+	defer server.Close()
+
+	send := func(method, path, body string) *http.Response {
+		request, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+
+		response, err := server.Client().Do(request)
+		require.NoError(t, err)
+		response.Body.Close()
+
+		return response
+	}
+
+	require.Equal(t, http.StatusNoContent, send(http.MethodGet, "/sanity", "").StatusCode)
+
+	require.Equal(t, http.StatusOK, send(lockMethod, "/sanity", `{"ID":"first"}`).StatusCode)
+	require.Equal(t, http.StatusLocked, send(lockMethod, "/sanity", `{"ID":"second"}`).StatusCode)
+	require.Equal(t, http.StatusConflict, send(http.MethodPost, "/sanity?ID=second", `{"version":4}`).StatusCode)
+	require.Equal(t, http.StatusOK, send(http.MethodPost, "/sanity?ID=first", `{"version":4}`).StatusCode)
+	require.Equal(t, http.StatusOK, send(lockMethod, "/airgap", `{"ID":"second"}`).StatusCode)
+	require.Equal(t, http.StatusOK, send(unlockMethod, "/sanity", `{"ID":"first"}`).StatusCode)
+
+	require.Equal(t, http.StatusOK, send(http.MethodGet, "/sanity", "").StatusCode)
+	require.Equal(t, http.StatusOK, send(http.MethodDelete, "/sanity", "").StatusCode)
+	require.Equal(t, http.StatusNoContent, send(http.MethodGet, "/sanity", "").StatusCode)
+}
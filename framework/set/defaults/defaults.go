@@ -18,6 +18,7 @@ const (
 	Airgap       = "airgap"
 	Custom       = "custom"
 	Import       = "import"
+	Imported     = "imported"
 	Registry     = "registry"
 
 	Rancher2Source      = "rancher/rancher2"
@@ -42,6 +43,7 @@ const (
 	Configs                             = "configs"
 	Mirrors                             = "mirrors"
 	Services                            = "services"
+	RotateCertificates                  = "rotate_certificates"
 	EnableNetworkPolicy                 = "enable_network_policy"
 	DefaultClusterRoleForProjectMembers = "default_cluster_role_for_project_members"
 	RancherBaseline                     = "rancher-baseline"
@@ -216,10 +218,15 @@ const (
 	Max              = "max"
 
 	Aws     = "aws"
+	Azure   = "azure"
+	Azurerm = "azurerm"
+	Google  = "google"
 	Linode  = "linode"
 	Vsphere = "vsphere"
 
 	AwsSource     = "hashicorp/aws"
+	AzurermSource = "hashicorp/azurerm"
+	GoogleSource  = "hashicorp/google"
 	LinodeSource  = "linode/linode"
 	RKESource     = "rancher/rke"
 	VsphereSource = "vmware/vsphere"
@@ -288,4 +295,27 @@ const (
 	LinodeNodeBalancerNode   = "linode_nodebalancer_node"
 	LinodeDomain             = "linode_domain"
 	LinodeDomainRecord       = "linode_domain_record"
+
+	AzureDNSARecord                      = "azurerm_dns_a_record"
+	AzureLinuxVirtualMachine             = "azurerm_linux_virtual_machine"
+	AzureLoadBalancer                    = "azurerm_lb"
+	AzureLoadBalancerBackendPool         = "azurerm_lb_backend_address_pool"
+	AzureLoadBalancerPoolAssociation     = "azurerm_network_interface_backend_address_pool_association"
+	AzureLoadBalancerProbe               = "azurerm_lb_probe"
+	AzureLoadBalancerRule                = "azurerm_lb_rule"
+	AzureNetworkInterface                = "azurerm_network_interface"
+	AzureNetworkSecurityGroup            = "azurerm_network_security_group"
+	AzureNetworkSecurityGroupAssociation = "azurerm_network_interface_security_group_association"
+	AzurePublicIP                        = "azurerm_public_ip"
+	AzureResourceGroup                   = "azurerm_resource_group"
+	AzureSubnet                          = "azurerm_subnet"
+	PublicIPAddress                      = "public_ip_address"
+
+	GoogleComputeAddress        = "google_compute_address"
+	GoogleComputeForwardingRule = "google_compute_forwarding_rule"
+	GoogleComputeInstance       = "google_compute_instance"
+	GoogleComputeTargetPool     = "google_compute_target_pool"
+	GoogleDNSRecordSet          = "google_dns_record_set"
+	GoogleNatIP                 = "network_interface[0].access_config[0].nat_ip"
+	GoogleNetworkIP             = "network_interface[0].network_ip"
 )
@@ -8,10 +8,11 @@ import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 )
 
 // RegisterPrivateNodes is a function that will register the private nodes to the cluster
-func RegisterPrivateNodes(provisionerBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig, nodePrivateIP,
+func RegisterPrivateNodes(newFile *hclwrite.File, provisionerBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig, nodePrivateIP,
 	registrationCommand string) error {
 	privateKey, err := os.ReadFile(terraformConfig.PrivateKeyPath)
 	if err != nil {
@@ -24,7 +25,7 @@ func RegisterPrivateNodes(provisionerBlockBody *hclwrite.Body, terraformConfig *
 
 	provisionerBlockBody.SetAttributeRaw(defaults.Inline, hclwrite.Tokens{
 		{Type: hclsyntax.TokenOQuote, Bytes: []byte(`["`), SpacesBefore: 1},
-		{Type: hclsyntax.TokenStringLit, Bytes: []byte("/tmp/register-nodes.sh " + encodedPEMFile + " " +
+		{Type: hclsyntax.TokenStringLit, Bytes: []byte("/tmp/register-nodes.sh " + secrets.Interpolation(newFile, secrets.PrivateKeyBase64, encodedPEMFile) + " " +
 			terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
 			nodePrivateIP + " " + newCommand + " " + terraformConfig.PrivateRegistries.SystemDefaultRegistry + " || true")},
 		{Type: hclsyntax.TokenCQuote, Bytes: []byte(`"]`), SpacesBefore: 1},
@@ -34,7 +35,7 @@ func RegisterPrivateNodes(provisionerBlockBody *hclwrite.Body, terraformConfig *
 }
 
 // RegisterWindowsPrivateNodes is a function that will register the private  Windows nodes to the cluster
-func RegisterWindowsPrivateNodes(provisionerBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig, nodePrivateIP,
+func RegisterWindowsPrivateNodes(newFile *hclwrite.File, provisionerBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig, nodePrivateIP,
 	registrationCommand string) error {
 	windowsPrivateKey, err := os.ReadFile(terraformConfig.WindowsPrivateKeyPath)
 	if err != nil {
@@ -47,7 +48,7 @@ func RegisterWindowsPrivateNodes(provisionerBlockBody *hclwrite.Body, terraformC
 
 	provisionerBlockBody.SetAttributeRaw(defaults.Inline, hclwrite.Tokens{
 		{Type: hclsyntax.TokenOQuote, Bytes: []byte(`["`), SpacesBefore: 1},
-		{Type: hclsyntax.TokenStringLit, Bytes: []byte("/tmp/register-windows-nodes.sh " + encodedWindowsPEMFile + " " +
+		{Type: hclsyntax.TokenStringLit, Bytes: []byte("/tmp/register-windows-nodes.sh " + secrets.Interpolation(newFile, secrets.WindowsPrivateKeyBase64, encodedWindowsPEMFile) + " " +
 			terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " + terraformConfig.AWSConfig.WindowsAWSUser + " " +
 			nodePrivateIP + " " + newCommand + " " + terraformConfig.PrivateRegistries.SystemDefaultRegistry)},
 		{Type: hclsyntax.TokenCQuote, Bytes: []byte(`"]`), SpacesBefore: 1},
@@ -73,7 +73,7 @@ func SetAirgapRKE1(terraformConfig *config.TerraformConfig, terratestConfig *con
 			return nil, nil, err
 		}
 
-		err = airgap.RegisterPrivateNodes(provisionerBlockBody, terraformConfig, nodePrivateIPs[instance], registrationCommands[instance])
+		err = airgap.RegisterPrivateNodes(newFile, provisionerBlockBody, terraformConfig, nodePrivateIPs[instance], registrationCommands[instance])
 		if err != nil {
 			return nil, nil, err
 		}
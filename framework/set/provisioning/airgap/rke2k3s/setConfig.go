@@ -27,7 +27,7 @@ const (
 // SetAirgapRKE2K3s is a function that will set the airgap RKE2/K3s cluster configurations in the main.tf file.
 func SetAirgapRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, configMap []map[string]any,
 	newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File) (*hclwrite.File, *os.File, error) {
-	v2.SetRancher2ClusterV2(rootBody, terraformConfig, terratestConfig)
+	v2.SetRancher2ClusterV2(newFile, rootBody, terraformConfig, terratestConfig)
 	rootBody.AppendNewline()
 
 	aws.CreateAWSInstances(rootBody, terraformConfig, terratestConfig, bastion+"_"+terraformConfig.ResourcePrefix)
@@ -47,7 +47,7 @@ func SetAirgapRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig *
 	}
 
 	if strings.Contains(terraformConfig.Module, clustertypes.WINDOWS) {
-		aws.CreateAirgappedWindowsAWSInstances(rootBody, terraformConfig, airgapWindowsNode+"_"+terraformConfig.ResourcePrefix)
+		aws.CreateAirgappedWindowsAWSInstances(newFile, rootBody, terraformConfig, airgapWindowsNode+"_"+terraformConfig.ResourcePrefix)
 		rootBody.AppendNewline()
 	}
 
@@ -87,7 +87,7 @@ func SetAirgapRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig *
 			return nil, nil, err
 		}
 
-		err = airgap.RegisterPrivateNodes(provisionerBlockBody, terraformConfig, nodePrivateIPs[instance], registrationCommands[instance])
+		err = airgap.RegisterPrivateNodes(newFile, provisionerBlockBody, terraformConfig, nodePrivateIPs[instance], registrationCommands[instance])
 		if err != nil {
 			return nil, nil, err
 		}
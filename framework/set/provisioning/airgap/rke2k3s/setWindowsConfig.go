@@ -17,7 +17,7 @@ func SetAirgapRKE2Windows(terraformConfig *config.TerraformConfig, terratestConf
 
 	registrationCommands, nodePrivateIPs := getRKE2K3sRegistrationCommands(terraformConfig)
 
-	err = airgap.RegisterWindowsPrivateNodes(provisionerBlockBody, terraformConfig, nodePrivateIPs[airgapWindowsNode], registrationCommands[airgapWindowsNode])
+	err = airgap.RegisterWindowsPrivateNodes(newFile, provisionerBlockBody, terraformConfig, nodePrivateIPs[airgapWindowsNode], registrationCommands[airgapWindowsNode])
 	if err != nil {
 		return nil, nil, err
 	}
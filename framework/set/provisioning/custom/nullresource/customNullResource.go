@@ -21,6 +21,10 @@ func CustomNullResource(rootBody *hclwrite.Body, terraformConfig *config.Terrafo
 	var countExpression string
 	if strings.Contains(terraformConfig.Provider, defaults.Aws) {
 		countExpression = defaults.Length + `(` + defaults.AwsInstance + `.` + terraformConfig.ResourcePrefix + `)`
+	} else if strings.Contains(terraformConfig.Provider, defaults.Azure) {
+		countExpression = defaults.Length + `(` + defaults.AzureLinuxVirtualMachine + `.` + terraformConfig.ResourcePrefix + `)`
+	} else if strings.Contains(terraformConfig.Provider, defaults.Google) {
+		countExpression = defaults.Length + `(` + defaults.GoogleComputeInstance + `.` + terraformConfig.ResourcePrefix + `)`
 	} else if strings.Contains(terraformConfig.Provider, defaults.Vsphere) {
 		countExpression = defaults.Length + `(` + defaults.VsphereVirtualMachine + `.` + terraformConfig.ResourcePrefix + `)`
 	}
@@ -63,6 +67,12 @@ func CustomNullResource(rootBody *hclwrite.Body, terraformConfig *config.Terrafo
 	case defaults.Aws:
 		connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.AWSConfig.AWSUser))
 		hostExpression = fmt.Sprintf(`"${%s.%s[%s.%s].%s}"`, defaults.AwsInstance, terraformConfig.ResourcePrefix, defaults.Count, defaults.Index, defaults.PublicIp)
+	case defaults.Azure:
+		connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.AzureConfig.SSHUser))
+		hostExpression = fmt.Sprintf(`"${%s.%s[%s.%s].%s}"`, defaults.AzureLinuxVirtualMachine, terraformConfig.ResourcePrefix, defaults.Count, defaults.Index, defaults.PublicIPAddress)
+	case defaults.Google:
+		connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.GoogleConfig.Username))
+		hostExpression = fmt.Sprintf(`"${%s.%s[%s.%s].%s}"`, defaults.GoogleComputeInstance, terraformConfig.ResourcePrefix, defaults.Count, defaults.Index, defaults.GoogleNatIP)
 	case defaults.Vsphere:
 		connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.VsphereConfig.VsphereUser))
 		hostExpression = fmt.Sprintf(`"${%s.%s[%s.%s].%s}"`, defaults.VsphereVirtualMachine, terraformConfig.ResourcePrefix, defaults.Count, defaults.Index, defaults.DefaultIPAddress)
@@ -8,12 +8,13 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/modules"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/zclconf/go-cty/cty"
 )
 
 // CustomWindowsNullResource is a function that will set the Windows null_resource configurations in the main.tf file,
 // to register the nodes to the cluster
-func CustomWindowsNullResource(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, clusterName string) error {
+func CustomWindowsNullResource(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, clusterName string) error {
 	nullResourceBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.NullResource, defaults.RegisterNodes + "-" + clusterName + "-windows"})
 	nullResourceBlockBody := nullResourceBlock.Body()
 
@@ -30,9 +31,9 @@ func CustomWindowsNullResource(rootBody *hclwrite.Body, terraformConfig *config.
 	connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.AWSConfig.WindowsAWSUser))
 
 	if strings.Contains(terraformConfig.Module, modules.CustomEC2RKE2Windows2019) {
-		connectionBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(terraformConfig.AWSConfig.Windows2019Password))
+		secrets.SetSensitiveAttribute(newFile, connectionBlockBody, defaults.Password, secrets.Windows2019Password, terraformConfig.AWSConfig.Windows2019Password)
 	} else if strings.Contains(terraformConfig.Module, modules.CustomEC2RKE2Windows2022) {
-		connectionBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(terraformConfig.AWSConfig.Windows2022Password))
+		secrets.SetSensitiveAttribute(newFile, connectionBlockBody, defaults.Password, secrets.Windows2022Password, terraformConfig.AWSConfig.Windows2022Password)
 	}
 
 	connectionBlockBody.SetAttributeValue(defaults.Insecure, cty.BoolVal(true))
@@ -8,6 +8,7 @@ import (
 	"github.com/rancher/shepherd/clients/rancher"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -52,8 +53,8 @@ func SetCustomProviders(rancherConfig *rancher.Config, terraformConfig *config.T
 	awsProvBlockBody := awsProvBlock.Body()
 
 	awsProvBlockBody.SetAttributeValue(defaults.Region, cty.StringVal(terraformConfig.AWSConfig.Region))
-	awsProvBlockBody.SetAttributeValue(defaults.AccessKey, cty.StringVal(terraformConfig.AWSCredentials.AWSAccessKey))
-	awsProvBlockBody.SetAttributeValue(defaults.SecretKey, cty.StringVal(terraformConfig.AWSCredentials.AWSSecretKey))
+	secrets.SetSensitiveAttribute(newFile, awsProvBlockBody, defaults.AccessKey, secrets.AWSAccessKey, terraformConfig.AWSCredentials.AWSAccessKey)
+	secrets.SetSensitiveAttribute(newFile, awsProvBlockBody, defaults.SecretKey, secrets.AWSSecretKey, terraformConfig.AWSCredentials.AWSSecretKey)
 
 	rootBody.AppendNewline()
 
@@ -65,7 +66,7 @@ func SetCustomProviders(rancherConfig *rancher.Config, terraformConfig *config.T
 	rancher2ProvBlockBody := rancher2ProvBlock.Body()
 
 	rancher2ProvBlockBody.SetAttributeValue(defaults.ApiUrl, cty.StringVal(`https://`+rancherConfig.Host))
-	rancher2ProvBlockBody.SetAttributeValue(defaults.TokenKey, cty.StringVal(rancherConfig.AdminToken))
+	secrets.SetSensitiveAttribute(newFile, rancher2ProvBlockBody, defaults.TokenKey, secrets.RancherAdminToken, rancherConfig.AdminToken)
 	rancher2ProvBlockBody.SetAttributeValue(defaults.Insecure, cty.BoolVal(*rancherConfig.Insecure))
 
 	rootBody.AppendNewline()
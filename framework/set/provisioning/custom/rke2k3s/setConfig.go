@@ -12,6 +12,8 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/custom/nullresource"
 	"github.com/rancher/tfp-automation/framework/set/resources/providers/aws"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/azure"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/google"
 	"github.com/rancher/tfp-automation/framework/set/resources/providers/vsphere"
 )
 
@@ -21,6 +23,13 @@ func SetCustomRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig *
 	switch terraformConfig.Provider {
 	case defaults.Aws:
 		aws.CreateAWSInstances(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix)
+	case defaults.Azure:
+		azure.CreateAzureNetwork(rootBody, terraformConfig)
+		rootBody.AppendNewline()
+
+		azure.CreateAzureInstances(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix)
+	case defaults.Google:
+		google.CreateGoogleInstances(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix)
 	case defaults.Vsphere:
 		dataCenterExpression := fmt.Sprintf(defaults.Data + `.` + defaults.VsphereDatacenter + `.` + defaults.VsphereDatacenter + `.id`)
 		dataCenterValue := hclwrite.Tokens{
@@ -47,12 +56,12 @@ func SetCustomRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig *
 
 	if strings.Contains(terraformConfig.Module, clustertypes.WINDOWS) {
 		rootBody.AppendNewline()
-		aws.CreateWindowsAWSInstances(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix)
+		aws.CreateWindowsAWSInstances(newFile, rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix)
 	}
 
 	rootBody.AppendNewline()
 
-	SetRancher2ClusterV2(rootBody, terraformConfig, terratestConfig)
+	SetRancher2ClusterV2(newFile, rootBody, terraformConfig, terratestConfig)
 	rootBody.AppendNewline()
 
 	nullresource.CustomNullResource(rootBody, terraformConfig, terratestConfig)
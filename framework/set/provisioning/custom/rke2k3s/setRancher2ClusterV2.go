@@ -14,7 +14,8 @@ import (
 )
 
 // SetRancher2ClusterV2 is a function that will set the rancher2_cluster_v2 configurations in the main.tf file.
-func SetRancher2ClusterV2(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig) error {
+func SetRancher2ClusterV2(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig) error {
 	rancher2ClusterV2Block := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.ClusterV2, terraformConfig.ResourcePrefix})
 	rancher2ClusterV2BlockBody := rancher2ClusterV2Block.Body()
 
@@ -39,7 +40,7 @@ func SetRancher2ClusterV2(rootBody *hclwrite.Body, terraformConfig *config.Terra
 	if terraformConfig.PrivateRegistries != nil {
 		if terraformConfig.PrivateRegistries.Username != "" {
 			rootBody.AppendNewline()
-			v2.CreateRegistrySecret(terraformConfig, rootBody)
+			v2.CreateRegistrySecret(terraformConfig, newFile, rootBody)
 		}
 
 		v2.SetMachineSelectorConfig(rkeConfigBlockBody, terraformConfig)
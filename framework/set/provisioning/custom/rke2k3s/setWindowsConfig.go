@@ -11,7 +11,7 @@ import (
 // SetCustomRKE2Windows is a function that will set the custom RKE2 cluster configurations in the main.tf file.
 func SetCustomRKE2Windows(terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, configMap []map[string]any,
 	newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File) (*hclwrite.File, *os.File, error) {
-	nullresource.CustomWindowsNullResource(rootBody, terraformConfig, terraformConfig.ResourcePrefix)
+	nullresource.CustomWindowsNullResource(newFile, rootBody, terraformConfig, terraformConfig.ResourcePrefix)
 	rootBody.AppendNewline()
 
 	return newFile, file, nil
@@ -11,28 +11,16 @@ import (
 	format "github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	resources "github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/zclconf/go-cty/cty"
 )
 
 // SetAKS is a function that will set the AKS configurations in the main.tf file.
 func SetAKS(terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, newFile *hclwrite.File, rootBody *hclwrite.Body,
 	file *os.File) (*hclwrite.File, *os.File, error) {
-	cloudCredBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.CloudCredential, defaults.CloudCredential})
-	cloudCredBlockBody := cloudCredBlock.Body()
-
-	cloudCredBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix))
-
-	azCredConfigBlock := cloudCredBlockBody.AppendNewBlock(azure.AzureCredentialConfig, nil)
-	azCredConfigBlockBody := azCredConfigBlock.Body()
-
-	azCredConfigBlockBody.SetAttributeValue(azure.ClientID, cty.StringVal(terraformConfig.AzureCredentials.ClientID))
-	azCredConfigBlockBody.SetAttributeValue(azure.ClientSecret, cty.StringVal(terraformConfig.AzureCredentials.ClientSecret))
-	azCredConfigBlockBody.SetAttributeValue(azure.SubscriptionID, cty.StringVal(terraformConfig.AzureCredentials.SubscriptionID))
-	azCredConfigBlockBody.SetAttributeValue(azure.TenantID, cty.StringVal(terraformConfig.AzureCredentials.TenantID))
-
-	rootBody.AppendNewline()
+	setAKSCloudCredential(newFile, rootBody, terraformConfig)
 
-	clusterBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.Cluster, defaults.Cluster})
+	clusterBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.Cluster, terraformConfig.ResourcePrefix})
 	clusterBlockBody := clusterBlock.Body()
 
 	clusterBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix))
@@ -41,7 +29,7 @@ func SetAKS(terraformConfig *config.TerraformConfig, terratestConfig *config.Ter
 	aksConfigBlockBody := aksConfigBlock.Body()
 
 	cloudCredID := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(defaults.CloudCredential + "." + defaults.CloudCredential + ".id")},
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(defaults.CloudCredential + "." + terraformConfig.ResourcePrefix + ".id")},
 	}
 
 	aksConfigBlockBody.SetAttributeRaw(defaults.CloudCredentialID, cloudCredID)
@@ -57,6 +45,10 @@ func SetAKS(terraformConfig *config.TerraformConfig, terratestConfig *config.Ter
 	aksConfigBlockBody.SetAttributeValue(azure.NetworkDockerBridgeCIDR, cty.StringVal(terraformConfig.AzureConfig.NetworkDockerBridgeCIDR))
 	aksConfigBlockBody.SetAttributeValue(azure.NetworkServiceCIDR, cty.StringVal(terraformConfig.AzureConfig.NetworkServiceCIDR))
 
+	if terraformConfig.AzureConfig.PrivateCluster {
+		aksConfigBlockBody.SetAttributeValue(azure.PrivateCluster, cty.BoolVal(true))
+	}
+
 	availabilityZones := format.ListOfStrings(terraformConfig.AzureConfig.AvailabilityZones)
 
 	for count, pool := range terratestConfig.Nodepools {
@@ -71,16 +63,41 @@ func SetAKS(terraformConfig *config.TerraformConfig, terratestConfig *config.Ter
 		nodePoolsBlockBody := nodePoolsBlock.Body()
 
 		nodePoolsBlockBody.SetAttributeRaw(azure.AvailabilityZones, availabilityZones)
-		nodePoolsBlockBody.SetAttributeValue(azure.NodePoolMode, cty.StringVal(terraformConfig.AzureConfig.Mode))
-		nodePoolsBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.AzureConfig.Name))
+		nodePoolsBlockBody.SetAttributeValue(azure.NodePoolMode, cty.StringVal(AKSNodePoolMode(terraformConfig, pool)))
+		nodePoolsBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(NodePoolName(terraformConfig, pool, count)))
 		nodePoolsBlockBody.SetAttributeValue(azure.Count, cty.NumberIntVal(pool.Quantity))
 		nodePoolsBlockBody.SetAttributeValue(azure.OrchestratorVersion, cty.StringVal(terratestConfig.KubernetesVersion))
 		nodePoolsBlockBody.SetAttributeValue(azure.OSDiskSizeGB, cty.NumberIntVal(terraformConfig.AzureConfig.OSDiskSizeGB))
 		nodePoolsBlockBody.SetAttributeValue(azure.VMSize, cty.StringVal(terraformConfig.AzureConfig.VMSize))
 
-		taints := format.ListOfStrings(terraformConfig.AzureConfig.Taints)
-		nodePoolsBlockBody.SetAttributeRaw(azure.Taints, taints)
+		// The taints of the azureConfig apply to the node pools without taints of their own.
+		if len(pool.Taints) == 0 {
+			taints := format.ListOfStrings(terraformConfig.AzureConfig.Taints)
+			nodePoolsBlockBody.SetAttributeRaw(azure.Taints, taints)
+		}
+
+		setAKSNodePoolSettings(nodePoolsBlockBody, pool)
 	}
 
+	rootBody.AppendNewline()
+
 	return newFile, file, nil
 }
+
+// setAKSCloudCredential is a helper function that will set the Azure cloud credential of an AKS cluster in the main.tf file.
+func setAKSCloudCredential(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+	cloudCredBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.CloudCredential, terraformConfig.ResourcePrefix})
+	cloudCredBlockBody := cloudCredBlock.Body()
+
+	cloudCredBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix))
+
+	azCredConfigBlock := cloudCredBlockBody.AppendNewBlock(azure.AzureCredentialConfig, nil)
+	azCredConfigBlockBody := azCredConfigBlock.Body()
+
+	azCredConfigBlockBody.SetAttributeValue(azure.ClientID, cty.StringVal(terraformConfig.AzureCredentials.ClientID))
+	secrets.SetSensitiveAttribute(newFile, azCredConfigBlockBody, azure.ClientSecret, secrets.AzureClientSecret, terraformConfig.AzureCredentials.ClientSecret)
+	azCredConfigBlockBody.SetAttributeValue(azure.SubscriptionID, cty.StringVal(terraformConfig.AzureCredentials.SubscriptionID))
+	azCredConfigBlockBody.SetAttributeValue(azure.TenantID, cty.StringVal(terraformConfig.AzureCredentials.TenantID))
+
+	rootBody.AppendNewline()
+}
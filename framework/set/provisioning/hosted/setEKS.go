@@ -11,26 +11,16 @@ import (
 	format "github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	resources "github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/zclconf/go-cty/cty"
 )
 
 // SetEKS is a function that will set the EKS configurations in the main.tf file.
 func SetEKS(terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, newFile *hclwrite.File, rootBody *hclwrite.Body,
 	file *os.File) (*hclwrite.File, *os.File, error) {
-	cloudCredBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.CloudCredential, defaults.CloudCredential})
-	cloudCredBlockBody := cloudCredBlock.Body()
-
-	cloudCredBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix))
-
-	ec2CredConfigBlock := cloudCredBlockBody.AppendNewBlock(amazon.EC2CredentialConfig, nil)
-	ec2CredConfigBlockBody := ec2CredConfigBlock.Body()
-
-	ec2CredConfigBlockBody.SetAttributeValue(defaults.AccessKey, cty.StringVal(terraformConfig.AWSCredentials.AWSAccessKey))
-	ec2CredConfigBlockBody.SetAttributeValue(defaults.SecretKey, cty.StringVal(terraformConfig.AWSCredentials.AWSSecretKey))
+	setEKSCloudCredential(newFile, rootBody, terraformConfig)
 
-	rootBody.AppendNewline()
-
-	clusterBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.Cluster, defaults.Cluster})
+	clusterBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.Cluster, terraformConfig.ResourcePrefix})
 	clusterBlockBody := clusterBlock.Body()
 
 	clusterBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix))
@@ -39,7 +29,7 @@ func SetEKS(terraformConfig *config.TerraformConfig, terratestConfig *config.Ter
 	eksConfigBlockBody := eksConfigBlock.Body()
 
 	cloudCredID := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(defaults.CloudCredential + "." + defaults.CloudCredential + ".id")},
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(defaults.CloudCredential + "." + terraformConfig.ResourcePrefix + ".id")},
 	}
 
 	eksConfigBlockBody.SetAttributeRaw(defaults.CloudCredentialID, cloudCredID)
@@ -63,13 +53,37 @@ func SetEKS(terraformConfig *config.TerraformConfig, terratestConfig *config.Ter
 		nodePoolsBlock := eksConfigBlockBody.AppendNewBlock(amazon.NodeGroups, nil)
 		nodePoolsBlockBody := nodePoolsBlock.Body()
 
-		nodePoolsBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix+`-pool`+poolNum))
+		nodePoolsBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(NodePoolName(terraformConfig, pool, count)))
 		nodePoolsBlockBody.SetAttributeValue(amazon.DiskSize, cty.NumberIntVal(pool.DiskSize))
-		nodePoolsBlockBody.SetAttributeValue(amazon.InstanceType, cty.StringVal(pool.InstanceType))
+
+		if !pool.Spot {
+			nodePoolsBlockBody.SetAttributeValue(amazon.InstanceType, cty.StringVal(pool.InstanceType))
+		}
+
 		nodePoolsBlockBody.SetAttributeValue(amazon.DesiredSize, cty.NumberIntVal(pool.DesiredSize))
 		nodePoolsBlockBody.SetAttributeValue(amazon.MaxSize, cty.NumberIntVal(pool.MaxSize))
 		nodePoolsBlockBody.SetAttributeValue(amazon.MinSize, cty.NumberIntVal(pool.MinSize))
+
+		setEKSNodeGroupSettings(nodePoolsBlockBody, pool)
 	}
 
+	rootBody.AppendNewline()
+
 	return newFile, file, nil
 }
+
+// setEKSCloudCredential is a helper function that will set the AWS cloud credential of an EKS cluster in the main.tf file.
+func setEKSCloudCredential(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+	cloudCredBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.CloudCredential, terraformConfig.ResourcePrefix})
+	cloudCredBlockBody := cloudCredBlock.Body()
+
+	cloudCredBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix))
+
+	ec2CredConfigBlock := cloudCredBlockBody.AppendNewBlock(amazon.EC2CredentialConfig, nil)
+	ec2CredConfigBlockBody := ec2CredConfigBlock.Body()
+
+	secrets.SetSensitiveAttribute(newFile, ec2CredConfigBlockBody, defaults.AccessKey, secrets.AWSAccessKey, terraformConfig.AWSCredentials.AWSAccessKey)
+	secrets.SetSensitiveAttribute(newFile, ec2CredConfigBlockBody, defaults.SecretKey, secrets.AWSSecretKey, terraformConfig.AWSCredentials.AWSSecretKey)
+
+	rootBody.AppendNewline()
+}
@@ -10,23 +10,16 @@ import (
 	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/google"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	resources "github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/zclconf/go-cty/cty"
 )
 
 // SetGKE is a function that will set the GKE configurations in the main.tf file.
 func SetGKE(terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, newFile *hclwrite.File, rootBody *hclwrite.Body,
 	file *os.File) (*hclwrite.File, *os.File, error) {
-	cloudCredBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.CloudCredential, defaults.CloudCredential})
-	cloudCredBlockBody := cloudCredBlock.Body()
-
-	cloudCredBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix))
-
-	googleCredConfigBlock := cloudCredBlockBody.AppendNewBlock(google.GoogleCredentialConfig, nil)
-	googleCredConfigBlock.Body().SetAttributeValue(google.AuthEncodedJSON, cty.StringVal(terraformConfig.GoogleCredentials.AuthEncodedJSON))
-
-	rootBody.AppendNewline()
+	setGKECloudCredential(newFile, rootBody, terraformConfig)
 
-	clusterBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.Cluster, defaults.Cluster})
+	clusterBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.Cluster, terraformConfig.ResourcePrefix})
 	clusterBlockBody := clusterBlock.Body()
 
 	clusterBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix))
@@ -37,7 +30,7 @@ func SetGKE(terraformConfig *config.TerraformConfig, terratestConfig *config.Ter
 	gkeConfigBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix))
 
 	cloudCredSecret := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(defaults.CloudCredential + "." + defaults.CloudCredential + ".id")},
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(defaults.CloudCredential + "." + terraformConfig.ResourcePrefix + ".id")},
 	}
 
 	gkeConfigBlockBody.SetAttributeRaw(google.GoogleCredentialSecret, cloudCredSecret)
@@ -47,6 +40,10 @@ func SetGKE(terraformConfig *config.TerraformConfig, terratestConfig *config.Ter
 	gkeConfigBlockBody.SetAttributeValue(google.Network, cty.StringVal(terraformConfig.GoogleConfig.Network))
 	gkeConfigBlockBody.SetAttributeValue(google.Subnetwork, cty.StringVal(terraformConfig.GoogleConfig.Subnetwork))
 
+	if terraformConfig.GoogleConfig.EnablePrivateNodes || terraformConfig.GoogleConfig.EnablePrivateEndpoint {
+		setGKEPrivateCluster(gkeConfigBlockBody, terraformConfig)
+	}
+
 	for count, pool := range terratestConfig.Nodepools {
 		poolNum := strconv.Itoa(count)
 
@@ -60,9 +57,39 @@ func SetGKE(terraformConfig *config.TerraformConfig, terratestConfig *config.Ter
 
 		nodePoolsBlockBody.SetAttributeValue(google.InitialNodeCount, cty.NumberIntVal(pool.Quantity))
 		nodePoolsBlockBody.SetAttributeValue(google.MaxPodsConstraint, cty.NumberIntVal(pool.MaxPodsConstraint))
-		nodePoolsBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix+`-pool`+poolNum))
+		nodePoolsBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(NodePoolName(terraformConfig, pool, count)))
 		nodePoolsBlockBody.SetAttributeValue(google.Version, cty.StringVal(terratestConfig.KubernetesVersion))
+
+		setGKENodePoolSettings(nodePoolsBlockBody, pool)
 	}
 
+	rootBody.AppendNewline()
+
 	return newFile, file, nil
 }
+
+// setGKEPrivateCluster is a helper function that will set the private nodes and endpoint of a GKE cluster in the main.tf file. Private
+// clusters are VPC-native, so they use IP aliases as well.
+func setGKEPrivateCluster(gkeConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+	ipAllocationPolicyBlockBody := gkeConfigBlockBody.AppendNewBlock(google.IPAllocationPolicy, nil).Body()
+	ipAllocationPolicyBlockBody.SetAttributeValue(google.UseIPAliases, cty.BoolVal(true))
+
+	privateClusterBlockBody := gkeConfigBlockBody.AppendNewBlock(google.PrivateClusterConfig, nil).Body()
+
+	privateClusterBlockBody.SetAttributeValue(google.EnablePrivateEndpoint, cty.BoolVal(terraformConfig.GoogleConfig.EnablePrivateEndpoint))
+	privateClusterBlockBody.SetAttributeValue(google.EnablePrivateNodes, cty.BoolVal(terraformConfig.GoogleConfig.EnablePrivateNodes))
+	privateClusterBlockBody.SetAttributeValue(google.MasterIPv4CIDRBlock, cty.StringVal(terraformConfig.GoogleConfig.MasterIPv4CIDRBlock))
+}
+
+// setGKECloudCredential is a helper function that will set the Google cloud credential of a GKE cluster in the main.tf file.
+func setGKECloudCredential(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+	cloudCredBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.CloudCredential, terraformConfig.ResourcePrefix})
+	cloudCredBlockBody := cloudCredBlock.Body()
+
+	cloudCredBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix))
+
+	googleCredConfigBlock := cloudCredBlockBody.AppendNewBlock(google.GoogleCredentialConfig, nil)
+	secrets.SetSensitiveAttribute(newFile, googleCredConfigBlock.Body(), google.AuthEncodedJSON, secrets.GoogleAuthEncodedJSON, terraformConfig.GoogleCredentials.AuthEncodedJSON)
+
+	rootBody.AppendNewline()
+}
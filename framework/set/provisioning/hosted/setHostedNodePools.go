@@ -0,0 +1,174 @@
+package hosted
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/amazon"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/azure"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/google"
+	format "github.com/rancher/tfp-automation/framework/format"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const defaultTaintEffect = "NoSchedule"
+
+var gkeTaintEffects = map[string]string{
+	"NoSchedule":       "NO_SCHEDULE",
+	"PreferNoSchedule": "PREFER_NO_SCHEDULE",
+	"NoExecute":        "NO_EXECUTE",
+}
+
+// NodePoolName is a function that will return the name of a node pool of a hosted cluster. Unnamed node pools are named after the
+// resource prefix, except for AKS, which names the first node pool after the azureConfig and suffixes the others with their number.
+func NodePoolName(terraformConfig *config.TerraformConfig, pool config.Nodepool, poolNum int) string {
+	if pool.Name != "" {
+		return pool.Name
+	}
+
+	if strings.HasPrefix(terraformConfig.Module, clustertypes.AKS) {
+		if poolNum == 0 {
+			return terraformConfig.AzureConfig.Name
+		}
+
+		return terraformConfig.AzureConfig.Name + strconv.Itoa(poolNum)
+	}
+
+	return terraformConfig.ResourcePrefix + `-pool` + strconv.Itoa(poolNum)
+}
+
+// AKSNodePoolMode is a function that will return the mode of an AKS node pool, which defaults to the mode of the azureConfig.
+func AKSNodePoolMode(terraformConfig *config.TerraformConfig, pool config.Nodepool) string {
+	if pool.Mode != "" {
+		return pool.Mode
+	}
+
+	return terraformConfig.AzureConfig.Mode
+}
+
+// AKSTaint is a function that will return a taint in the key=value:Effect format of AKS node pools.
+func AKSTaint(taint config.Taint) string {
+	effect := taint.Effect
+	if effect == "" {
+		effect = defaultTaintEffect
+	}
+
+	if taint.Value == "" {
+		return taint.Key + ":" + effect
+	}
+
+	return taint.Key + "=" + taint.Value + ":" + effect
+}
+
+// GKETaintEffect is a function that will return the GKE form of a Kubernetes taint effect, such as NO_SCHEDULE for NoSchedule.
+func GKETaintEffect(effect string) string {
+	if effect == "" {
+		effect = defaultTaintEffect
+	}
+
+	if gkeEffect, ok := gkeTaintEffects[effect]; ok {
+		return gkeEffect
+	}
+
+	return effect
+}
+
+// setEKSNodeGroupSettings is a helper function that will set the labels, spot instances and launch template of an EKS node group
+// in the main.tf file. Spot node groups request spot instances of the instance type of the node pool.
+func setEKSNodeGroupSettings(nodeGroupBlockBody *hclwrite.Body, pool config.Nodepool) {
+	if len(pool.Labels) > 0 {
+		nodeGroupBlockBody.SetAttributeValue(defaults.Labels, labelsValue(pool.Labels))
+	}
+
+	if pool.Spot {
+		nodeGroupBlockBody.SetAttributeValue(amazon.RequestSpotInstances, cty.BoolVal(true))
+		nodeGroupBlockBody.SetAttributeRaw(amazon.SpotInstanceTypes, format.ListOfStrings([]string{pool.InstanceType}))
+	}
+
+	if pool.LaunchTemplate != nil {
+		launchTemplateBlockBody := nodeGroupBlockBody.AppendNewBlock(amazon.LaunchTemplate, nil).Body()
+
+		launchTemplateBlockBody.SetAttributeValue(amazon.LaunchTemplateID, cty.StringVal(pool.LaunchTemplate.ID))
+
+		if pool.LaunchTemplate.Name != "" {
+			launchTemplateBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(pool.LaunchTemplate.Name))
+		}
+
+		launchTemplateBlockBody.SetAttributeValue(defaults.Version, cty.NumberIntVal(pool.LaunchTemplate.Version))
+	}
+}
+
+// setAKSNodePoolSettings is a helper function that will set the autoscaling, labels and taints of an AKS node pool in the main.tf file.
+func setAKSNodePoolSettings(nodePoolBlockBody *hclwrite.Body, pool config.Nodepool) {
+	if pool.Autoscaling {
+		nodePoolBlockBody.SetAttributeValue(azure.EnableAutoScaling, cty.BoolVal(true))
+		nodePoolBlockBody.SetAttributeValue(azure.MinCount, cty.NumberIntVal(pool.MinSize))
+		nodePoolBlockBody.SetAttributeValue(azure.MaxCount, cty.NumberIntVal(pool.MaxSize))
+	}
+
+	if len(pool.Labels) > 0 {
+		nodePoolBlockBody.SetAttributeValue(defaults.Labels, labelsValue(pool.Labels))
+	}
+
+	if len(pool.Taints) > 0 {
+		var taints []string
+		for _, taint := range pool.Taints {
+			taints = append(taints, AKSTaint(taint))
+		}
+
+		nodePoolBlockBody.SetAttributeRaw(azure.Taints, format.ListOfStrings(taints))
+	}
+}
+
+// setGKENodePoolSettings is a helper function that will set the autoscaling, node config and management of a GKE node pool in the
+// main.tf file. The node config is only set for labels, taints and preemptible nodes, so GKE defaults the rest of it.
+func setGKENodePoolSettings(nodePoolBlockBody *hclwrite.Body, pool config.Nodepool) {
+	if pool.Autoscaling {
+		autoscalingBlockBody := nodePoolBlockBody.AppendNewBlock(google.Autoscaling, nil).Body()
+
+		autoscalingBlockBody.SetAttributeValue(defaults.Enabled, cty.BoolVal(true))
+		autoscalingBlockBody.SetAttributeValue(google.MinNodeCount, cty.NumberIntVal(pool.MinSize))
+		autoscalingBlockBody.SetAttributeValue(google.MaxNodeCount, cty.NumberIntVal(pool.MaxSize))
+	}
+
+	if len(pool.Labels) > 0 || len(pool.Taints) > 0 || pool.Spot {
+		nodeConfigBlockBody := nodePoolBlockBody.AppendNewBlock(google.NodeConfig, nil).Body()
+
+		if len(pool.Labels) > 0 {
+			nodeConfigBlockBody.SetAttributeValue(defaults.Labels, labelsValue(pool.Labels))
+		}
+
+		if pool.Spot {
+			nodeConfigBlockBody.SetAttributeValue(google.Preemptible, cty.BoolVal(true))
+		}
+
+		for _, taint := range pool.Taints {
+			taintBlockBody := nodeConfigBlockBody.AppendNewBlock(google.Taints, nil).Body()
+
+			taintBlockBody.SetAttributeValue(google.TaintKey, cty.StringVal(taint.Key))
+			taintBlockBody.SetAttributeValue(defaults.Value, cty.StringVal(taint.Value))
+			taintBlockBody.SetAttributeValue(google.TaintEffect, cty.StringVal(GKETaintEffect(taint.Effect)))
+		}
+	}
+
+	if pool.Management != nil {
+		managementBlockBody := nodePoolBlockBody.AppendNewBlock(google.Management, nil).Body()
+
+		managementBlockBody.SetAttributeValue(google.AutoRepair, cty.BoolVal(pool.Management.AutoRepair))
+		managementBlockBody.SetAttributeValue(google.AutoUpgrade, cty.BoolVal(pool.Management.AutoUpgrade))
+	}
+}
+
+// labelsValue is a helper function that will return the labels of a node pool as a map value.
+func labelsValue(labels map[string]string) cty.Value {
+	values := map[string]cty.Value{}
+	for labelKey, labelValue := range labels {
+		values[labelKey] = cty.StringVal(labelValue)
+	}
+
+	return cty.MapVal(values)
+}
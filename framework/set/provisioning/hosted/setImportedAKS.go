@@ -0,0 +1,76 @@
+package hosted
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/azure"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	resources "github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// SetImportedAKS is a function that will set the configurations of an existing AKS cluster that is imported into Rancher in the main.tf
+// file. The node pools are matched to the existing ones by name, so Rancher adopts them instead of creating new ones.
+func SetImportedAKS(terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, newFile *hclwrite.File, rootBody *hclwrite.Body,
+	file *os.File) (*hclwrite.File, *os.File, error) {
+	setAKSCloudCredential(newFile, rootBody, terraformConfig)
+
+	clusterBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.Cluster, terraformConfig.ResourcePrefix})
+	clusterBlockBody := clusterBlock.Body()
+
+	clusterBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix))
+
+	aksConfigBlock := clusterBlockBody.AppendNewBlock(azure.AKSConfig, nil)
+	aksConfigBlockBody := aksConfigBlock.Body()
+
+	cloudCredID := hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(defaults.CloudCredential + "." + terraformConfig.ResourcePrefix + ".id")},
+	}
+
+	aksConfigBlockBody.SetAttributeRaw(defaults.CloudCredentialID, cloudCredID)
+	aksConfigBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.HostedClusterName))
+	aksConfigBlockBody.SetAttributeValue(azure.ResourceGroup, cty.StringVal(terraformConfig.AzureConfig.ResourceGroup))
+	aksConfigBlockBody.SetAttributeValue(azure.ResourceLocation, cty.StringVal(terraformConfig.AzureConfig.ResourceLocation))
+	aksConfigBlockBody.SetAttributeValue(defaults.Imported, cty.BoolVal(true))
+
+	if terratestConfig.KubernetesVersion != "" {
+		aksConfigBlockBody.SetAttributeValue(defaults.KubernetesVersion, cty.StringVal(terratestConfig.KubernetesVersion))
+	}
+
+	for count, pool := range terratestConfig.Nodepools {
+		poolNum := strconv.Itoa(count)
+
+		_, err := resources.SetResourceNodepoolValidation(terraformConfig, pool, poolNum)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		nodePoolsBlock := aksConfigBlockBody.AppendNewBlock(azure.NodePools, nil)
+		nodePoolsBlockBody := nodePoolsBlock.Body()
+
+		nodePoolsBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(pool.Name))
+		nodePoolsBlockBody.SetAttributeValue(azure.Count, cty.NumberIntVal(pool.Quantity))
+
+		if mode := AKSNodePoolMode(terraformConfig, pool); mode != "" {
+			nodePoolsBlockBody.SetAttributeValue(azure.NodePoolMode, cty.StringVal(mode))
+		}
+
+		if terraformConfig.AzureConfig.VMSize != "" {
+			nodePoolsBlockBody.SetAttributeValue(azure.VMSize, cty.StringVal(terraformConfig.AzureConfig.VMSize))
+		}
+
+		if terraformConfig.AzureConfig.OSDiskSizeGB != 0 {
+			nodePoolsBlockBody.SetAttributeValue(azure.OSDiskSizeGB, cty.NumberIntVal(terraformConfig.AzureConfig.OSDiskSizeGB))
+		}
+
+		setAKSNodePoolSettings(nodePoolsBlockBody, pool)
+	}
+
+	rootBody.AppendNewline()
+
+	return newFile, file, nil
+}
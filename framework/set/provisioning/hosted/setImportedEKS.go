@@ -0,0 +1,83 @@
+package hosted
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/amazon"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	resources "github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// SetImportedEKS is a function that will set the configurations of an existing EKS cluster that is imported into Rancher in the main.tf
+// file. The node groups are matched to the existing ones by name, so Rancher adopts them instead of creating new ones.
+func SetImportedEKS(terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, newFile *hclwrite.File, rootBody *hclwrite.Body,
+	file *os.File) (*hclwrite.File, *os.File, error) {
+	setEKSCloudCredential(newFile, rootBody, terraformConfig)
+
+	clusterBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.Cluster, terraformConfig.ResourcePrefix})
+	clusterBlockBody := clusterBlock.Body()
+
+	clusterBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix))
+
+	eksConfigBlock := clusterBlockBody.AppendNewBlock(amazon.EKSConfig, nil)
+	eksConfigBlockBody := eksConfigBlock.Body()
+
+	cloudCredID := hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(defaults.CloudCredential + "." + terraformConfig.ResourcePrefix + ".id")},
+	}
+
+	eksConfigBlockBody.SetAttributeRaw(defaults.CloudCredentialID, cloudCredID)
+	eksConfigBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.HostedClusterName))
+	eksConfigBlockBody.SetAttributeValue(defaults.Region, cty.StringVal(terraformConfig.AWSConfig.Region))
+	eksConfigBlockBody.SetAttributeValue(defaults.Imported, cty.BoolVal(true))
+
+	if terratestConfig.KubernetesVersion != "" {
+		eksConfigBlockBody.SetAttributeValue(defaults.KubernetesVersion, cty.StringVal(terratestConfig.KubernetesVersion))
+	}
+
+	for count, pool := range terratestConfig.Nodepools {
+		poolNum := strconv.Itoa(count)
+
+		_, err := resources.SetResourceNodepoolValidation(terraformConfig, pool, poolNum)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// The minimum and maximum size default to the desired size, so the node group is not resized by the import.
+		maxSize, minSize := pool.MaxSize, pool.MinSize
+		if maxSize == 0 {
+			maxSize = pool.DesiredSize
+		}
+
+		if minSize == 0 {
+			minSize = pool.DesiredSize
+		}
+
+		nodePoolsBlock := eksConfigBlockBody.AppendNewBlock(amazon.NodeGroups, nil)
+		nodePoolsBlockBody := nodePoolsBlock.Body()
+
+		nodePoolsBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(pool.Name))
+		nodePoolsBlockBody.SetAttributeValue(amazon.DesiredSize, cty.NumberIntVal(pool.DesiredSize))
+		nodePoolsBlockBody.SetAttributeValue(amazon.MaxSize, cty.NumberIntVal(maxSize))
+		nodePoolsBlockBody.SetAttributeValue(amazon.MinSize, cty.NumberIntVal(minSize))
+
+		if pool.InstanceType != "" && !pool.Spot {
+			nodePoolsBlockBody.SetAttributeValue(amazon.InstanceType, cty.StringVal(pool.InstanceType))
+		}
+
+		if pool.DiskSize != 0 {
+			nodePoolsBlockBody.SetAttributeValue(amazon.DiskSize, cty.NumberIntVal(pool.DiskSize))
+		}
+
+		setEKSNodeGroupSettings(nodePoolsBlockBody, pool)
+	}
+
+	rootBody.AppendNewline()
+
+	return newFile, file, nil
+}
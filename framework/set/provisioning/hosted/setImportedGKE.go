@@ -0,0 +1,71 @@
+package hosted
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/google"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	resources "github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// SetImportedGKE is a function that will set the configurations of an existing GKE cluster that is imported into Rancher in the main.tf
+// file. The node pools are matched to the existing ones by name, so Rancher adopts them instead of creating new ones. GKE node pools
+// require a version, so the Kubernetes version must match the one of the existing cluster.
+func SetImportedGKE(terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, newFile *hclwrite.File, rootBody *hclwrite.Body,
+	file *os.File) (*hclwrite.File, *os.File, error) {
+	setGKECloudCredential(newFile, rootBody, terraformConfig)
+
+	clusterBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.Cluster, terraformConfig.ResourcePrefix})
+	clusterBlockBody := clusterBlock.Body()
+
+	clusterBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix))
+
+	gkeConfigBlock := clusterBlockBody.AppendNewBlock(google.GKEConfig, nil)
+	gkeConfigBlockBody := gkeConfigBlock.Body()
+
+	cloudCredSecret := hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(defaults.CloudCredential + "." + terraformConfig.ResourcePrefix + ".id")},
+	}
+
+	gkeConfigBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.HostedClusterName))
+	gkeConfigBlockBody.SetAttributeRaw(google.GoogleCredentialSecret, cloudCredSecret)
+	gkeConfigBlockBody.SetAttributeValue(defaults.Region, cty.StringVal(terraformConfig.GoogleConfig.Region))
+	gkeConfigBlockBody.SetAttributeValue(google.ProjectID, cty.StringVal(terraformConfig.GoogleConfig.ProjectID))
+	gkeConfigBlockBody.SetAttributeValue(defaults.Imported, cty.BoolVal(true))
+
+	if terratestConfig.KubernetesVersion != "" {
+		gkeConfigBlockBody.SetAttributeValue(defaults.KubernetesVersion, cty.StringVal(terratestConfig.KubernetesVersion))
+	}
+
+	for count, pool := range terratestConfig.Nodepools {
+		poolNum := strconv.Itoa(count)
+
+		_, err := resources.SetResourceNodepoolValidation(terraformConfig, pool, poolNum)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		nodePoolsBlock := gkeConfigBlockBody.AppendNewBlock(google.NodePools, nil)
+		nodePoolsBlockBody := nodePoolsBlock.Body()
+
+		nodePoolsBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(pool.Name))
+		nodePoolsBlockBody.SetAttributeValue(google.InitialNodeCount, cty.NumberIntVal(pool.Quantity))
+
+		if pool.MaxPodsConstraint != 0 {
+			nodePoolsBlockBody.SetAttributeValue(google.MaxPodsConstraint, cty.NumberIntVal(pool.MaxPodsConstraint))
+		}
+
+		nodePoolsBlockBody.SetAttributeValue(google.Version, cty.StringVal(terratestConfig.KubernetesVersion))
+
+		setGKENodePoolSettings(nodePoolsBlockBody, pool)
+	}
+
+	rootBody.AppendNewline()
+
+	return newFile, file, nil
+}
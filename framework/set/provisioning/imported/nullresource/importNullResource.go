@@ -38,6 +38,10 @@ func CreateImportedNullResource(rootBody *hclwrite.Body, terraformConfig *config
 	switch terraformConfig.Provider {
 	case defaults.Aws:
 		connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.AWSConfig.AWSUser))
+	case defaults.Azure:
+		connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.AzureConfig.SSHUser))
+	case defaults.Google:
+		connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.GoogleConfig.Username))
 	case defaults.Vsphere:
 		connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.VsphereConfig.VsphereUser))
 	}
@@ -57,6 +61,10 @@ func CreateImportedNullResource(rootBody *hclwrite.Body, terraformConfig *config
 	switch terraformConfig.Provider {
 	case defaults.Aws:
 		dependsOnServer = `[` + defaults.AwsInstance + `.` + serverOneName + `, ` + defaults.AwsInstance + `.` + serverTwoName + `, ` + defaults.AwsInstance + `.` + serverThreeName + `]`
+	case defaults.Azure:
+		dependsOnServer = `[` + defaults.AzureLinuxVirtualMachine + `.` + serverOneName + `, ` + defaults.AzureLinuxVirtualMachine + `.` + serverTwoName + `, ` + defaults.AzureLinuxVirtualMachine + `.` + serverThreeName + `]`
+	case defaults.Google:
+		dependsOnServer = `[` + defaults.GoogleComputeInstance + `.` + serverOneName + `, ` + defaults.GoogleComputeInstance + `.` + serverTwoName + `, ` + defaults.GoogleComputeInstance + `.` + serverThreeName + `]`
 	case defaults.Vsphere:
 		dependsOnServer = `[` + defaults.VsphereVirtualMachine + `.` + serverOneName + `, ` + defaults.VsphereVirtualMachine + `.` + serverTwoName + `, ` + defaults.VsphereVirtualMachine + `.` + serverThreeName + `]`
 	}
@@ -8,11 +8,12 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/modules"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/zclconf/go-cty/cty"
 )
 
 // CreateImportedWindowsNullResource is a helper function that will create the null_resource for the Windows node.
-func CreateImportedWindowsNullResource(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
+func CreateImportedWindowsNullResource(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
 	publicDNS, resourceName string) (*hclwrite.Body, *hclwrite.Body) {
 	nullResourceBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.NullResource, resourceName})
 	nullResourceBlockBody := nullResourceBlock.Body()
@@ -41,9 +42,9 @@ func CreateImportedWindowsNullResource(rootBody *hclwrite.Body, terraformConfig
 	connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.AWSConfig.WindowsAWSUser))
 
 	if strings.Contains(terraformConfig.Module, modules.ImportEC2RKE2Windows2019) {
-		connectionBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(terraformConfig.AWSConfig.Windows2019Password))
+		secrets.SetSensitiveAttribute(newFile, connectionBlockBody, defaults.Password, secrets.Windows2019Password, terraformConfig.AWSConfig.Windows2019Password)
 	} else if strings.Contains(terraformConfig.Module, modules.ImportEC2RKE2Windows2022) {
-		connectionBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(terraformConfig.AWSConfig.Windows2022Password))
+		secrets.SetSensitiveAttribute(newFile, connectionBlockBody, defaults.Password, secrets.Windows2022Password, terraformConfig.AWSConfig.Windows2022Password)
 	}
 
 	connectionBlockBody.SetAttributeValue(defaults.Insecure, cty.BoolVal(true))
@@ -48,7 +48,7 @@ func SetImportedRKE1(terraformConfig *config.TerraformConfig, terratestConfig *c
 
 	kubeConfig := fmt.Sprintf("${%s.%s.kube_config_yaml}", defaults.RKECluster, terraformConfig.ResourcePrefix)
 
-	err := imported.ImportNodes(rootBody, terraformConfig, terratestConfig, nodeOnePublicDNS, kubeConfig, importCommand[serverOneName])
+	err := imported.ImportNodes(newFile, rootBody, terraformConfig, terratestConfig, nodeOnePublicDNS, kubeConfig, importCommand[serverOneName])
 	if err != nil {
 		return nil, nil, err
 	}
@@ -8,6 +8,8 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/rancher/tfp-automation/framework/set/resources/providers/aws"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/azure"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/google"
 	"github.com/rancher/tfp-automation/framework/set/resources/providers/vsphere"
 )
 
@@ -43,6 +45,11 @@ func getProviderIPAddresses(terraformConfig *config.TerraformConfig, terratestCo
 		rootBody.AppendNewline()
 	}
 
+	if terraformConfig.Provider == defaults.Azure {
+		azure.CreateAzureNetwork(rootBody, terraformConfig)
+		rootBody.AppendNewline()
+	}
+
 	for _, instance := range instances {
 		switch terraformConfig.Provider {
 		case defaults.Aws:
@@ -53,6 +60,22 @@ func getProviderIPAddresses(terraformConfig *config.TerraformConfig, terratestCo
 			nodeOnePublicIP = fmt.Sprintf("${%s.%s.public_ip}", defaults.AwsInstance, serverOneName)
 			nodeTwoPublicIP = fmt.Sprintf("${%s.%s.public_ip}", defaults.AwsInstance, serverTwoName)
 			nodeThreePublicIP = fmt.Sprintf("${%s.%s.public_ip}", defaults.AwsInstance, serverThreeName)
+		case defaults.Azure:
+			azure.CreateAzureInstances(rootBody, terraformConfig, terratestConfig, instance)
+			rootBody.AppendNewline()
+
+			nodeOnePrivateIP = fmt.Sprintf("${%s.%s.private_ip_address}", defaults.AzureLinuxVirtualMachine, serverOneName)
+			nodeOnePublicIP = fmt.Sprintf("${%s.%s.public_ip_address}", defaults.AzureLinuxVirtualMachine, serverOneName)
+			nodeTwoPublicIP = fmt.Sprintf("${%s.%s.public_ip_address}", defaults.AzureLinuxVirtualMachine, serverTwoName)
+			nodeThreePublicIP = fmt.Sprintf("${%s.%s.public_ip_address}", defaults.AzureLinuxVirtualMachine, serverThreeName)
+		case defaults.Google:
+			google.CreateGoogleInstances(rootBody, terraformConfig, terratestConfig, instance)
+			rootBody.AppendNewline()
+
+			nodeOnePrivateIP = fmt.Sprintf("${%s.%s.%s}", defaults.GoogleComputeInstance, serverOneName, defaults.GoogleNetworkIP)
+			nodeOnePublicIP = fmt.Sprintf("${%s.%s.%s}", defaults.GoogleComputeInstance, serverOneName, defaults.GoogleNatIP)
+			nodeTwoPublicIP = fmt.Sprintf("${%s.%s.%s}", defaults.GoogleComputeInstance, serverTwoName, defaults.GoogleNatIP)
+			nodeThreePublicIP = fmt.Sprintf("${%s.%s.%s}", defaults.GoogleComputeInstance, serverThreeName, defaults.GoogleNatIP)
 		case defaults.Vsphere:
 			vsphere.CreateVsphereVirtualMachine(rootBody, terraformConfig, terratestConfig, instance)
 			rootBody.AppendNewline()
@@ -48,11 +48,11 @@ func SetImportedRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig
 	rootBody.AppendNewline()
 
 	if strings.Contains(terraformConfig.Module, clustertypes.WINDOWS) {
-		aws.CreateWindowsAWSInstances(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix)
+		aws.CreateWindowsAWSInstances(newFile, rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix)
 		rootBody.AppendNewline()
 
 		windowsNodePublicDNS := fmt.Sprintf("${%s.%s.public_dns}", defaults.AwsInstance, windowsNodeName)
-		resources.AddWindowsNodeToImportedCluster(rootBody, terraformConfig, terratestConfig, nodeOnePrivateIP, windowsNodePublicDNS, token)
+		resources.AddWindowsNodeToImportedCluster(newFile, rootBody, terraformConfig, terratestConfig, nodeOnePrivateIP, windowsNodePublicDNS, token)
 
 		// Add the sleep command to wait for the Windows node to be ready
 		rootBody.AppendNewline()
@@ -64,7 +64,7 @@ func SetImportedRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig
 
 	importCommand := imported.GetImportCommand(terraformConfig.ResourcePrefix)
 
-	err := imported.ImportNodes(rootBody, terraformConfig, terratestConfig, nodeOnePublicIP, "", importCommand[serverOneName])
+	err := imported.ImportNodes(newFile, rootBody, terraformConfig, terratestConfig, nodeOnePublicIP, "", importCommand[serverOneName])
 	if err != nil {
 		return nil, nil, err
 	}
@@ -6,7 +6,7 @@ import (
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/defaults/providers"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	v2 "github.com/rancher/tfp-automation/framework/set/provisioning/nodedriver/rke2k3s"
 	aws "github.com/rancher/tfp-automation/framework/set/provisioning/providers/aws"
@@ -22,6 +22,7 @@ const (
 	clusterSync     = "rancher2_cluster_sync"
 	nodeTemplate    = "rancher2_node_template"
 	rancherNodePool = "rancher2_node_pool"
+	nodeTaints      = "node_taints"
 
 	backupConfig            = "backup_config"
 	intervalHours           = "interval_hours"
@@ -31,6 +32,7 @@ const (
 	snapshot                = "snapshot"
 	s3BackupConfig          = "s3_backup_config"
 	bucketName              = "bucket_name"
+	caCertificates          = "ca_certificates"
 	privateRegistryURL      = "url"
 	privateRegistryUsername = "user"
 	privateRegistryPassword = "password"
@@ -46,7 +48,7 @@ const (
 
 // SetRKE1 is a function that will set the RKE1 configurations in the main.tf file.
 func SetRKE1(terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, newFile *hclwrite.File, rootBody *hclwrite.Body,
-	file *os.File, rbacRole config.Role) (*hclwrite.File, *os.File, error) {
+	file *os.File, rbacRole config.Role, provider string) (*hclwrite.File, *os.File, error) {
 	nodeTemplateBlock := rootBody.AppendNewBlock(defaults.Resource, []string{nodeTemplate, terraformConfig.ResourcePrefix})
 	nodeTemplateBlockBody := nodeTemplateBlock.Body()
 
@@ -58,18 +60,18 @@ func SetRKE1(terraformConfig *config.TerraformConfig, terratestConfig *config.Te
 		}))
 	}
 
-	switch terraformConfig.Module {
-	case modules.EC2RKE1:
-		aws.SetAWSRKE1Provider(nodeTemplateBlockBody, terraformConfig)
-	case modules.AzureRKE1:
-		azure.SetAzureRKE1Provider(nodeTemplateBlockBody, terraformConfig)
-	case modules.LinodeRKE1:
-		linode.SetLinodeRKE1Provider(nodeTemplateBlockBody, terraformConfig)
-	case modules.HarvesterRKE1:
-		harvester.SetHarvesterCredentialProvider(rootBody, terraformConfig)
+	switch provider {
+	case providers.AWS:
+		aws.SetAWSRKE1Provider(newFile, nodeTemplateBlockBody, terraformConfig)
+	case providers.Azure:
+		azure.SetAzureRKE1Provider(newFile, nodeTemplateBlockBody, terraformConfig)
+	case providers.Linode:
+		linode.SetLinodeRKE1Provider(newFile, nodeTemplateBlockBody, terraformConfig)
+	case providers.Harvester:
+		harvester.SetHarvesterCredentialProvider(newFile, rootBody, terraformConfig)
 		harvester.SetHarvesterRKE1Provider(nodeTemplateBlockBody, terraformConfig)
-	case modules.VsphereRKE1:
-		vsphere.SetVsphereRKE1Provider(nodeTemplateBlockBody, terraformConfig)
+	case providers.Vsphere:
+		vsphere.SetVsphereRKE1Provider(newFile, nodeTemplateBlockBody, terraformConfig)
 	}
 
 	rootBody.AppendNewline()
@@ -102,8 +104,8 @@ func SetRKE1(terraformConfig *config.TerraformConfig, terratestConfig *config.Te
 
 	rootBody.AppendNewline()
 
-	if terraformConfig.PrivateRegistries != nil && strings.Contains(terraformConfig.Module, modules.EC2) {
-		err = setRKE1PrivateRegistryConfig(rkeConfigBlockBody, terraformConfig)
+	if terraformConfig.PrivateRegistries != nil && provider == providers.AWS {
+		err = setRKE1PrivateRegistryConfig(newFile, rkeConfigBlockBody, terraformConfig)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -112,7 +114,7 @@ func SetRKE1(terraformConfig *config.TerraformConfig, terratestConfig *config.Te
 	}
 
 	if terraformConfig.ETCDRKE1 != nil {
-		err = setEtcdConfig(rkeConfigBlockBody, terraformConfig)
+		err = setEtcdConfig(newFile, rkeConfigBlockBody, terraformConfig)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -120,6 +122,13 @@ func SetRKE1(terraformConfig *config.TerraformConfig, terratestConfig *config.Te
 		rootBody.AppendNewline()
 	}
 
+	if terratestConfig.RotateCertificates != nil {
+		err = setRotateCertificates(rkeConfigBlockBody, terratestConfig.RotateCertificates)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	clusterSyncNodePoolIDs := ""
 
 	for count, pool := range terratestConfig.Nodepools {
@@ -5,11 +5,12 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/modules"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/zclconf/go-cty/cty"
 )
 
 // setEtcdConfig is a function that will set the etcd configurations in the main.tf file.
-func setEtcdConfig(rkeConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) error {
+func setEtcdConfig(newFile *hclwrite.File, rkeConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) error {
 	servicesBlock := rkeConfigBlockBody.AppendNewBlock(defaults.Services, nil)
 	servicesBlockBody := servicesBlock.Body()
 
@@ -28,12 +29,12 @@ func setEtcdConfig(rkeConfigBlockBody *hclwrite.Body, terraformConfig *config.Te
 		s3ConfigBlock := backupConfigBlockBody.AppendNewBlock(s3BackupConfig, nil)
 		s3ConfigBlockBody := s3ConfigBlock.Body()
 
-		s3ConfigBlockBody.SetAttributeValue(defaults.AccessKey, cty.StringVal(terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig.AccessKey))
+		secrets.SetSensitiveAttribute(newFile, s3ConfigBlockBody, defaults.AccessKey, secrets.EtcdS3AccessKey, terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig.AccessKey)
 		s3ConfigBlockBody.SetAttributeValue(bucketName, cty.StringVal(terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig.BucketName))
 		s3ConfigBlockBody.SetAttributeValue(defaults.Endpoint, cty.StringVal(terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig.Endpoint))
 		s3ConfigBlockBody.SetAttributeValue(defaults.Folder, cty.StringVal(terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig.Folder))
 		s3ConfigBlockBody.SetAttributeValue(defaults.Region, cty.StringVal(terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig.Region))
-		s3ConfigBlockBody.SetAttributeValue(defaults.SecretKey, cty.StringVal(terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig.SecretKey))
+		secrets.SetSensitiveAttribute(newFile, s3ConfigBlockBody, defaults.SecretKey, secrets.EtcdS3SecretKey, terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig.SecretKey)
 	}
 
 	etcdBlockBody.SetAttributeValue(retention, cty.StringVal(terraformConfig.ETCDRKE1.Retention))
@@ -47,6 +47,8 @@ func setNodePool(nodePools []config.Nodepool, count int, pool config.Nodepool, r
 	nodePoolBlockBody.SetAttributeValue(defaults.Etcd, cty.BoolVal(pool.Etcd))
 	nodePoolBlockBody.SetAttributeValue(worker, cty.BoolVal(pool.Worker))
 
+	resources.SetNodepoolSettings(nodePoolBlockBody, pool, nodeTaints)
+
 	rootBody.AppendNewline()
 
 	if count != len(nodePools) {
@@ -0,0 +1,28 @@
+package rke1
+
+import (
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// setRotateCertificates is a function that will set the rotate_certificates block in the main.tf file for a RKE1 cluster. RKE1
+// clusters have no generation, so Rancher rotates the certificates when the block is added or changed.
+func setRotateCertificates(rkeConfigBlockBody *hclwrite.Body, rotateCertificates *config.RotateCertificates) error {
+	rotateCertificatesBlock := rkeConfigBlockBody.AppendNewBlock(defaults.RotateCertificates, nil)
+	rotateCertificatesBlockBody := rotateCertificatesBlock.Body()
+
+	rotateCertificatesBlockBody.SetAttributeValue(caCertificates, cty.BoolVal(rotateCertificates.CACertificates))
+
+	if len(rotateCertificates.Services) > 0 {
+		services := []cty.Value{}
+		for _, service := range rotateCertificates.Services {
+			services = append(services, cty.StringVal(service))
+		}
+
+		rotateCertificatesBlockBody.SetAttributeValue(defaults.Services, cty.ListVal(services))
+	}
+
+	return nil
+}
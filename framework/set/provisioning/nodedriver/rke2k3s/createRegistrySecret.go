@@ -5,6 +5,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -18,7 +19,7 @@ const (
 )
 
 // CreateRegistrySecret is a function that will set the airgap RKE2/K3s cluster configurations in the main.tf file.
-func CreateRegistrySecret(terraformConfig *config.TerraformConfig, rootBody *hclwrite.Body) {
+func CreateRegistrySecret(terraformConfig *config.TerraformConfig, newFile *hclwrite.File, rootBody *hclwrite.Body) {
 	secretBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.SecretV2, terraformConfig.ResourcePrefix})
 	secretBlockBody := secretBlock.Body()
 
@@ -39,6 +40,6 @@ func CreateRegistrySecret(terraformConfig *config.TerraformConfig, rootBody *hcl
 	dataBlock := secretBlockBody.AppendNewBlock(defaults.Data+" =", nil)
 	configBlockBody := dataBlock.Body()
 
-	configBlockBody.SetAttributeValue(password, cty.StringVal(terraformConfig.PrivateRegistries.Password))
+	secrets.SetSensitiveAttribute(newFile, configBlockBody, password, secrets.RegistryPassword, terraformConfig.PrivateRegistries.Password)
 	configBlockBody.SetAttributeValue(username, cty.StringVal(terraformConfig.PrivateRegistries.Username))
 }
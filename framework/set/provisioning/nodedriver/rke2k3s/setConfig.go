@@ -6,10 +6,11 @@ import (
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/defaults/providers"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	aws "github.com/rancher/tfp-automation/framework/set/provisioning/providers/aws"
 	azure "github.com/rancher/tfp-automation/framework/set/provisioning/providers/azure"
+	google "github.com/rancher/tfp-automation/framework/set/provisioning/providers/google"
 	harvester "github.com/rancher/tfp-automation/framework/set/provisioning/providers/harvester"
 	linode "github.com/rancher/tfp-automation/framework/set/provisioning/providers/linode"
 	vsphere "github.com/rancher/tfp-automation/framework/set/provisioning/providers/vsphere"
@@ -26,10 +27,26 @@ const (
 	etcdRole                  = "etcd_role"
 	workerRole                = "worker_role"
 
+	taints                      = "taints"
+	nodeStartupTimeoutSeconds   = "node_startup_timeout_seconds"
+	unhealthyNodeTimeoutSeconds = "unhealthy_node_timeout_seconds"
+	maxUnhealthy                = "max_unhealthy"
+
 	upgradeStrategy         = "upgrade_strategy"
 	controlPlaneConcurrency = "control_plane_concurrency"
 	workerConcurrency       = "worker_concurrency"
 
+	controlPlaneDrainOptions        = "control_plane_drain_options"
+	workerDrainOptions              = "worker_drain_options"
+	enabled                         = "enabled"
+	force                           = "force"
+	ignoreDaemonSets                = "ignore_daemon_sets"
+	deleteEmptyDirData              = "delete_empty_dir_data"
+	disableEviction                 = "disable_eviction"
+	gracePeriod                     = "grace_period"
+	timeout                         = "timeout"
+	skipWaitForDeleteTimeoutSeconds = "skip_wait_for_delete_timeout_seconds"
+
 	disableSnapshots     = "disable_snapshots"
 	snapshotScheduleCron = "snapshot_schedule_cron"
 	snapshotRetention    = "snapshot_retention"
@@ -38,6 +55,12 @@ const (
 	endpointCA           = "endpoint_ca"
 	skipSSLVerify        = "skip_ssl_verify"
 
+	s3CredentialSuffix = "-s3"
+	s3CredentialConfig = "s3_credential_config"
+	defaultBucket      = "default_bucket"
+	defaultEndpoint    = "default_endpoint"
+	defaultRegion      = "default_region"
+
 	hostname              = "hostname"
 	authConfigSecretName  = "auth_config_secret_name"
 	tlsSecretName         = "tls_secret_name"
@@ -56,22 +79,29 @@ const (
 
 // SetRKE2K3s is a function that will set the RKE2/K3S configurations in the main.tf file.
 func SetRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, newFile *hclwrite.File, rootBody *hclwrite.Body,
-	file *os.File, rbacRole config.Role) (*hclwrite.File, *os.File, error) {
-	switch terraformConfig.Module {
-	case modules.EC2RKE2, modules.EC2K3s:
-		aws.SetAWSRKE2K3SProvider(rootBody, terraformConfig)
-	case modules.AzureRKE2, modules.AzureK3s:
-		azure.SetAzureRKE2K3SProvider(rootBody, terraformConfig)
-	case modules.HarvesterRKE2, modules.HarvesterK3s:
-		harvester.SetHarvesterCredentialProvider(rootBody, terraformConfig)
-	case modules.LinodeRKE2, modules.LinodeK3s:
-		linode.SetLinodeRKE2K3SProvider(rootBody, terraformConfig)
-	case modules.VsphereRKE2, modules.VsphereK3s:
-		vsphere.SetVsphereRKE2K3SProvider(rootBody, terraformConfig)
+	file *os.File, rbacRole config.Role, provider string) (*hclwrite.File, *os.File, error) {
+	switch provider {
+	case providers.AWS:
+		aws.SetAWSRKE2K3SProvider(newFile, rootBody, terraformConfig)
+	case providers.Azure:
+		azure.SetAzureRKE2K3SProvider(newFile, rootBody, terraformConfig)
+	case providers.Google:
+		google.SetGoogleRKE2K3SProvider(newFile, rootBody, terraformConfig)
+	case providers.Harvester:
+		harvester.SetHarvesterCredentialProvider(newFile, rootBody, terraformConfig)
+	case providers.Linode:
+		linode.SetLinodeRKE2K3SProvider(newFile, rootBody, terraformConfig)
+	case providers.Vsphere:
+		vsphere.SetVsphereRKE2K3SProvider(newFile, rootBody, terraformConfig)
 	}
 
 	rootBody.AppendNewline()
 
+	if terratestConfig.SnapshotInput.S3 != nil {
+		setS3CloudCredential(newFile, rootBody, terraformConfig, terratestConfig.SnapshotInput.S3)
+		rootBody.AppendNewline()
+	}
+
 	if strings.Contains(terratestConfig.PSACT, defaults.RancherBaseline) {
 		rootBody, err := resources.SetBaselinePSACT(newFile, rootBody, terraformConfig.ResourcePrefix)
 		if err != nil {
@@ -81,22 +111,41 @@ func SetRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig *config
 		rootBody.AppendNewline()
 	}
 
-	machineConfigBlockBody, err := setMachineConfig(rootBody, terraformConfig, terratestConfig.PSACT)
-	if err != nil {
-		return nil, nil, err
-	}
+	machineConfigs := machineConfigNames(terraformConfig, terratestConfig.Nodepools)
+	renderedConfigs := map[string]bool{}
+
+	for count, name := range machineConfigs {
+		if renderedConfigs[name] {
+			continue
+		}
+
+		renderedConfigs[name] = true
+
+		if count > 0 {
+			rootBody.AppendNewline()
+		}
 
-	switch terraformConfig.Module {
-	case modules.EC2RKE2, modules.EC2K3s:
-		aws.SetAWSRKE2K3SMachineConfig(machineConfigBlockBody, terraformConfig)
-	case modules.AzureRKE2, modules.AzureK3s:
-		azure.SetAzureRKE2K3SMachineConfig(machineConfigBlockBody, terraformConfig)
-	case modules.HarvesterRKE2, modules.HarvesterK3s:
-		harvester.SetHarvesterRKE2K3SMachineConfig(machineConfigBlockBody, terraformConfig)
-	case modules.LinodeRKE2, modules.LinodeK3s:
-		linode.SetLinodeRKE2K3SMachineConfig(machineConfigBlockBody, terraformConfig)
-	case modules.VsphereRKE2, modules.VsphereK3s:
-		vsphere.SetVsphereRKE2K3SMachineConfig(machineConfigBlockBody, terraformConfig)
+		machineConfigBlockBody, err := setMachineConfig(rootBody, terraformConfig, terratestConfig.PSACT, name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		poolConfig := poolMachineConfig(terraformConfig, terratestConfig.Nodepools[count].MachineConfig)
+
+		switch provider {
+		case providers.AWS:
+			aws.SetAWSRKE2K3SMachineConfig(machineConfigBlockBody, poolConfig)
+		case providers.Azure:
+			azure.SetAzureRKE2K3SMachineConfig(machineConfigBlockBody, poolConfig)
+		case providers.Google:
+			google.SetGoogleRKE2K3SMachineConfig(machineConfigBlockBody, poolConfig)
+		case providers.Harvester:
+			harvester.SetHarvesterRKE2K3SMachineConfig(machineConfigBlockBody, poolConfig)
+		case providers.Linode:
+			linode.SetLinodeRKE2K3SMachineConfig(newFile, machineConfigBlockBody, poolConfig)
+		case providers.Vsphere:
+			vsphere.SetVsphereRKE2K3SMachineConfig(newFile, machineConfigBlockBody, poolConfig)
+		}
 	}
 
 	rootBody.AppendNewline()
@@ -119,16 +168,16 @@ func SetRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig *config
 	}
 
 	for count, pool := range terratestConfig.Nodepools {
-		err = setMachinePool(terraformConfig, count, pool, rkeConfigBlockBody)
+		err = setMachinePool(terraformConfig, count, pool, machineConfigs[count], rkeConfigBlockBody)
 		if err != nil {
 			return nil, nil, err
 		}
 	}
 
-	if terraformConfig.PrivateRegistries != nil && strings.Contains(terraformConfig.Module, modules.EC2) {
+	if terraformConfig.PrivateRegistries != nil && provider == providers.AWS {
 		if terraformConfig.PrivateRegistries.Username != "" {
 			rootBody.AppendNewline()
-			CreateRegistrySecret(terraformConfig, rootBody)
+			CreateRegistrySecret(terraformConfig, newFile, rootBody)
 		}
 
 		if terraformConfig.PrivateRegistries.SystemDefaultRegistry != "" {
@@ -144,8 +193,15 @@ func SetRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig *config
 		}
 	}
 
-	if terraformConfig.ETCD != nil {
-		err = setEtcdConfig(rkeConfigBlockBody, terraformConfig)
+	if terraformConfig.UpgradeStrategy != nil {
+		err = setUpgradeStrategy(rkeConfigBlockBody, terraformConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if terraformConfig.ETCD != nil || terratestConfig.SnapshotInput.S3 != nil {
+		err = setEtcdConfig(rkeConfigBlockBody, terraformConfig, terratestConfig.SnapshotInput.S3)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -165,6 +221,13 @@ func SetRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig *config
 		}
 	}
 
+	if terratestConfig.RotateCertificates != nil {
+		err = SetRotateRKE2K3SCertificates(rkeConfigBlockBody, terratestConfig.RotateCertificates)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	rootBody.AppendNewline()
 
 	return newFile, file, nil
@@ -8,19 +8,39 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/modules"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/zclconf/go-cty/cty"
 )
 
-// setEtcdConfig is a function that will set the etcd configurations in the main.tf file.
-func setEtcdConfig(rkeConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) error {
+// setEtcdConfig is a function that will set the etcd configurations in the main.tf file. The S3 snapshot config of the snapshot
+// input takes precedence over the S3 config of the etcd config, which is only supported by EC2 modules.
+func setEtcdConfig(rkeConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig, snapshotS3 *config.SnapshotS3) error {
 	snapshotBlock := rkeConfigBlockBody.AppendNewBlock(defaults.Etcd, nil)
 	snapshotBlockBody := snapshotBlock.Body()
 
-	snapshotBlockBody.SetAttributeValue(disableSnapshots, cty.BoolVal(terraformConfig.ETCD.DisableSnapshots))
-	snapshotBlockBody.SetAttributeValue(snapshotScheduleCron, cty.StringVal(terraformConfig.ETCD.SnapshotScheduleCron))
-	snapshotBlockBody.SetAttributeValue(snapshotRetention, cty.NumberIntVal(int64(terraformConfig.ETCD.SnapshotRetention)))
+	if terraformConfig.ETCD != nil {
+		snapshotBlockBody.SetAttributeValue(disableSnapshots, cty.BoolVal(terraformConfig.ETCD.DisableSnapshots))
+		snapshotBlockBody.SetAttributeValue(snapshotScheduleCron, cty.StringVal(terraformConfig.ETCD.SnapshotScheduleCron))
+		snapshotBlockBody.SetAttributeValue(snapshotRetention, cty.NumberIntVal(int64(terraformConfig.ETCD.SnapshotRetention)))
+	}
+
+	switch {
+	case snapshotS3 != nil:
+		s3ConfigBlock := snapshotBlockBody.AppendNewBlock(s3Config, nil)
+		s3ConfigBlockBody := s3ConfigBlock.Body()
 
-	if strings.Contains(terraformConfig.Module, modules.EC2) && terraformConfig.ETCD.S3 != nil {
+		cloudCredSecretName := hclwrite.Tokens{
+			{Type: hclsyntax.TokenIdent, Bytes: []byte(defaults.CloudCredential + "." + terraformConfig.ResourcePrefix + s3CredentialSuffix + ".id")},
+		}
+
+		s3ConfigBlockBody.SetAttributeValue(bucket, cty.StringVal(snapshotS3.Bucket))
+		s3ConfigBlockBody.SetAttributeValue(defaults.Endpoint, cty.StringVal(snapshotS3.Endpoint))
+		s3ConfigBlockBody.SetAttributeRaw(cloudCredentialName, cloudCredSecretName)
+		s3ConfigBlockBody.SetAttributeValue(endpointCA, cty.StringVal(snapshotS3.EndpointCA))
+		s3ConfigBlockBody.SetAttributeValue(defaults.Folder, cty.StringVal(snapshotS3.Folder))
+		s3ConfigBlockBody.SetAttributeValue(defaults.Region, cty.StringVal(snapshotS3.Region))
+		s3ConfigBlockBody.SetAttributeValue(skipSSLVerify, cty.BoolVal(snapshotS3.SkipSSLVerify))
+	case strings.Contains(terraformConfig.Module, modules.EC2) && terraformConfig.ETCD != nil && terraformConfig.ETCD.S3 != nil:
 		s3ConfigBlock := snapshotBlockBody.AppendNewBlock(s3Config, nil)
 		s3ConfigBlockBody := s3ConfigBlock.Body()
 
@@ -39,3 +59,23 @@ func setEtcdConfig(rkeConfigBlockBody *hclwrite.Body, terraformConfig *config.Te
 
 	return nil
 }
+
+// setS3CloudCredential is a function that will set the cloud credential of the S3 snapshot config in the main.tf file.
+func setS3CloudCredential(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, snapshotS3 *config.SnapshotS3) {
+	cloudCredBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.CloudCredential, terraformConfig.ResourcePrefix + s3CredentialSuffix})
+	cloudCredBlockBody := cloudCredBlock.Body()
+
+	cloudCredBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix+s3CredentialSuffix))
+
+	s3CredBlock := cloudCredBlockBody.AppendNewBlock(s3CredentialConfig, nil)
+	s3CredBlockBody := s3CredBlock.Body()
+
+	secrets.SetSensitiveAttribute(newFile, s3CredBlockBody, defaults.AccessKey, secrets.EtcdS3AccessKey, snapshotS3.AccessKey)
+	secrets.SetSensitiveAttribute(newFile, s3CredBlockBody, defaults.SecretKey, secrets.EtcdS3SecretKey, snapshotS3.SecretKey)
+	s3CredBlockBody.SetAttributeValue(defaultBucket, cty.StringVal(snapshotS3.Bucket))
+	s3CredBlockBody.SetAttributeValue(defaultEndpoint, cty.StringVal(snapshotS3.Endpoint))
+
+	if snapshotS3.Region != "" {
+		s3CredBlockBody.SetAttributeValue(defaultRegion, cty.StringVal(snapshotS3.Region))
+	}
+}
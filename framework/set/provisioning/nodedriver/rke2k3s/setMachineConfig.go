@@ -1,6 +1,8 @@
 package rke2k3s
 
 import (
+	"strconv"
+
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
@@ -9,8 +11,8 @@ import (
 )
 
 // setMachineConfig is a function that will set the machine configurations in the main.tf file.
-func setMachineConfig(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, psact string) (*hclwrite.Body, error) {
-	machineConfigBlock := rootBody.AppendNewBlock(defaults.Resource, []string{machineConfigV2, terraformConfig.ResourcePrefix})
+func setMachineConfig(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, psact, name string) (*hclwrite.Body, error) {
+	machineConfigBlock := rootBody.AppendNewBlock(defaults.Resource, []string{machineConfigV2, name})
 	machineConfigBlockBody := machineConfigBlock.Body()
 
 	if psact == defaults.RancherBaseline {
@@ -22,7 +24,76 @@ func setMachineConfig(rootBody *hclwrite.Body, terraformConfig *config.Terraform
 		machineConfigBlockBody.SetAttributeRaw(defaults.DependsOn, dependsOnTemp)
 	}
 
-	machineConfigBlockBody.SetAttributeValue(defaults.GenerateName, cty.StringVal(terraformConfig.ResourcePrefix))
+	machineConfigBlockBody.SetAttributeValue(defaults.GenerateName, cty.StringVal(name))
 
 	return machineConfigBlockBody, nil
 }
+
+// machineConfigNames is a function that will return the name of the machine config used by each node pool. Pools without a
+// machineConfig share the machine config named after the resource prefix, while every distinct machineConfig gets its own machine
+// config, named after the resource prefix and the first pool that uses it.
+func machineConfigNames(terraformConfig *config.TerraformConfig, nodepools []config.Nodepool) []string {
+	names := make([]string, len(nodepools))
+	shapes := map[config.MachineConfig]string{}
+
+	for count, pool := range nodepools {
+		if pool.MachineConfig == nil {
+			names[count] = terraformConfig.ResourcePrefix
+			continue
+		}
+
+		name, ok := shapes[*pool.MachineConfig]
+		if !ok {
+			name = terraformConfig.ResourcePrefix + "-" + strconv.Itoa(count)
+			shapes[*pool.MachineConfig] = name
+		}
+
+		names[count] = name
+	}
+
+	return names
+}
+
+// poolMachineConfig is a function that will return a copy of the terraform config with the machineConfig of a node pool applied
+// to the node provider configs, so that the provider machine config functions render the pool's machines.
+func poolMachineConfig(terraformConfig *config.TerraformConfig, machineConfig *config.MachineConfig) *config.TerraformConfig {
+	if machineConfig == nil {
+		return terraformConfig
+	}
+
+	poolConfig := *terraformConfig
+
+	if machineConfig.AMI != "" {
+		poolConfig.AWSConfig.AMI = machineConfig.AMI
+	}
+
+	if machineConfig.InstanceType != "" {
+		poolConfig.AWSConfig.AWSInstanceType = machineConfig.InstanceType
+		poolConfig.AzureConfig.Size = machineConfig.InstanceType
+		poolConfig.GoogleConfig.MachineType = machineConfig.InstanceType
+		poolConfig.LinodeConfig.Type = machineConfig.InstanceType
+	}
+
+	if machineConfig.RootSize != 0 {
+		poolConfig.AWSConfig.AWSRootSize = machineConfig.RootSize
+	}
+
+	if machineConfig.DiskSize != "" {
+		poolConfig.AzureConfig.DiskSize = machineConfig.DiskSize
+		poolConfig.GoogleConfig.DiskSize = machineConfig.DiskSize
+		poolConfig.HarvesterConfig.DiskSize = machineConfig.DiskSize
+		poolConfig.VsphereConfig.DiskSize = machineConfig.DiskSize
+	}
+
+	if machineConfig.CPUCount != "" {
+		poolConfig.HarvesterConfig.CPUCount = machineConfig.CPUCount
+		poolConfig.VsphereConfig.CPUCount = machineConfig.CPUCount
+	}
+
+	if machineConfig.MemorySize != "" {
+		poolConfig.HarvesterConfig.MemorySize = machineConfig.MemorySize
+		poolConfig.VsphereConfig.MemorySize = machineConfig.MemorySize
+	}
+
+	return &poolConfig
+}
@@ -11,7 +11,7 @@ import (
 	"github.com/zclconf/go-cty/cty"
 )
 
-func setMachinePool(terraformConfig *config.TerraformConfig, count int, pool config.Nodepool, rkeConfigBlockBody *hclwrite.Body) error {
+func setMachinePool(terraformConfig *config.TerraformConfig, count int, pool config.Nodepool, machineConfigName string, rkeConfigBlockBody *hclwrite.Body) error {
 	poolNum := strconv.Itoa(count)
 
 	_, err := resources.SetResourceNodepoolValidation(terraformConfig, pool, poolNum)
@@ -34,17 +34,31 @@ func setMachinePool(terraformConfig *config.TerraformConfig, count int, pool con
 	machinePoolsBlockBody.SetAttributeValue(workerRole, cty.BoolVal(pool.Worker))
 	machinePoolsBlockBody.SetAttributeValue(defaults.Quantity, cty.NumberIntVal(pool.Quantity))
 
+	resources.SetNodepoolSettings(machinePoolsBlockBody, pool, taints)
+
+	if pool.NodeStartupTimeoutSeconds != 0 {
+		machinePoolsBlockBody.SetAttributeValue(nodeStartupTimeoutSeconds, cty.NumberIntVal(pool.NodeStartupTimeoutSeconds))
+	}
+
+	if pool.UnhealthyNodeTimeoutSeconds != 0 {
+		machinePoolsBlockBody.SetAttributeValue(unhealthyNodeTimeoutSeconds, cty.NumberIntVal(pool.UnhealthyNodeTimeoutSeconds))
+	}
+
+	if pool.MaxUnhealthy != "" {
+		machinePoolsBlockBody.SetAttributeValue(maxUnhealthy, cty.StringVal(pool.MaxUnhealthy))
+	}
+
 	machineConfigBlock := machinePoolsBlockBody.AppendNewBlock(defaults.MachineConfig, nil)
 	machineConfigBlockBody := machineConfigBlock.Body()
 
 	kind := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(machineConfigV2 + "." + terraformConfig.ResourcePrefix + ".kind")},
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(machineConfigV2 + "." + machineConfigName + ".kind")},
 	}
 
 	machineConfigBlockBody.SetAttributeRaw(defaults.ResourceKind, kind)
 
 	name := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(machineConfigV2 + "." + terraformConfig.ResourcePrefix + ".name")},
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(machineConfigV2 + "." + machineConfigName + ".name")},
 	}
 
 	machineConfigBlockBody.SetAttributeRaw(defaults.ResourceName, name)
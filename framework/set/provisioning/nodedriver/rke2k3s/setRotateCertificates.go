@@ -0,0 +1,33 @@
+package rke2k3s
+
+import (
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// SetRotateRKE2K3SCertificates is a function that will set the rotate_certificates block in the main.tf file for a RKE2/K3S cluster.
+// Rancher rotates the certificates whenever the generation is bumped.
+func SetRotateRKE2K3SCertificates(rkeConfigBlockBody *hclwrite.Body, rotateCertificates *config.RotateCertificates) error {
+	rotateCertificatesBlock := rkeConfigBlockBody.AppendNewBlock(defaults.RotateCertificates, nil)
+	rotateCertificatesBlockBody := rotateCertificatesBlock.Body()
+
+	generation := rotateCertificates.Generation
+	if generation == 0 {
+		generation = 1
+	}
+
+	rotateCertificatesBlockBody.SetAttributeValue(Generation, cty.NumberIntVal(generation))
+
+	if len(rotateCertificates.Services) > 0 {
+		services := []cty.Value{}
+		for _, service := range rotateCertificates.Services {
+			services = append(services, cty.StringVal(service))
+		}
+
+		rotateCertificatesBlockBody.SetAttributeValue(defaults.Services, cty.ListVal(services))
+	}
+
+	return nil
+}
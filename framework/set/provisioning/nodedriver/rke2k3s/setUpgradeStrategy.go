@@ -0,0 +1,60 @@
+package rke2k3s
+
+import (
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// setUpgradeStrategy is a function that will set the upgrade strategy configurations in the main.tf file.
+func setUpgradeStrategy(rkeConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) error {
+	upgradeStrategyBlock := rkeConfigBlockBody.AppendNewBlock(upgradeStrategy, nil)
+	upgradeStrategyBlockBody := upgradeStrategyBlock.Body()
+
+	strategy := terraformConfig.UpgradeStrategy
+
+	if strategy.ControlPlaneConcurrency != "" {
+		upgradeStrategyBlockBody.SetAttributeValue(controlPlaneConcurrency, cty.StringVal(strategy.ControlPlaneConcurrency))
+	}
+
+	if strategy.WorkerConcurrency != "" {
+		upgradeStrategyBlockBody.SetAttributeValue(workerConcurrency, cty.StringVal(strategy.WorkerConcurrency))
+	}
+
+	if strategy.ControlPlaneDrainOptions != nil {
+		setDrainOptions(upgradeStrategyBlockBody, controlPlaneDrainOptions, strategy.ControlPlaneDrainOptions)
+	}
+
+	if strategy.WorkerDrainOptions != nil {
+		setDrainOptions(upgradeStrategyBlockBody, workerDrainOptions, strategy.WorkerDrainOptions)
+	}
+
+	return nil
+}
+
+// setDrainOptions is a helper function that will set the drain options of the control plane or worker nodes in the main.tf file.
+func setDrainOptions(upgradeStrategyBlockBody *hclwrite.Body, blockName string, options *config.DrainOptions) {
+	drainOptionsBlock := upgradeStrategyBlockBody.AppendNewBlock(blockName, nil)
+	drainOptionsBlockBody := drainOptionsBlock.Body()
+
+	drainOptionsBlockBody.SetAttributeValue(enabled, cty.BoolVal(options.Enabled))
+	drainOptionsBlockBody.SetAttributeValue(force, cty.BoolVal(options.Force))
+	drainOptionsBlockBody.SetAttributeValue(deleteEmptyDirData, cty.BoolVal(options.DeleteEmptyDirData))
+	drainOptionsBlockBody.SetAttributeValue(disableEviction, cty.BoolVal(options.DisableEviction))
+
+	if options.IgnoreDaemonSets != nil {
+		drainOptionsBlockBody.SetAttributeValue(ignoreDaemonSets, cty.BoolVal(*options.IgnoreDaemonSets))
+	}
+
+	if options.GracePeriod != 0 {
+		drainOptionsBlockBody.SetAttributeValue(gracePeriod, cty.NumberIntVal(options.GracePeriod))
+	}
+
+	if options.Timeout != 0 {
+		drainOptionsBlockBody.SetAttributeValue(timeout, cty.NumberIntVal(options.Timeout))
+	}
+
+	if options.SkipWaitForDeleteTimeoutSeconds != 0 {
+		drainOptionsBlockBody.SetAttributeValue(skipWaitForDeleteTimeoutSeconds, cty.NumberIntVal(options.SkipWaitForDeleteTimeoutSeconds))
+	}
+}
@@ -6,17 +6,18 @@ import (
 	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/amazon"
 	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/zclconf/go-cty/cty"
 )
 
 // SetAWSRKE1Provider is a helper function that will set the AWS RKE1
 // Terraform configurations in the main.tf file.
-func SetAWSRKE1Provider(nodeTemplateBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+func SetAWSRKE1Provider(newFile *hclwrite.File, nodeTemplateBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
 	awsConfigBlock := nodeTemplateBlockBody.AppendNewBlock(amazon.EC2Config, nil)
 	awsConfigBlockBody := awsConfigBlock.Body()
 
-	awsConfigBlockBody.SetAttributeValue(defaults.AccessKey, cty.StringVal(terraformConfig.AWSCredentials.AWSAccessKey))
-	awsConfigBlockBody.SetAttributeValue(defaults.SecretKey, cty.StringVal(terraformConfig.AWSCredentials.AWSSecretKey))
+	secrets.SetSensitiveAttribute(newFile, awsConfigBlockBody, defaults.AccessKey, secrets.AWSAccessKey, terraformConfig.AWSCredentials.AWSAccessKey)
+	secrets.SetSensitiveAttribute(newFile, awsConfigBlockBody, defaults.SecretKey, secrets.AWSSecretKey, terraformConfig.AWSCredentials.AWSSecretKey)
 	awsConfigBlockBody.SetAttributeValue(defaults.Region, cty.StringVal(terraformConfig.AWSConfig.Region))
 
 	awsConfigBlockBody.SetAttributeValue(amazon.AMI, cty.StringVal(terraformConfig.AWSConfig.AMI))
@@ -35,7 +36,7 @@ func SetAWSRKE1Provider(nodeTemplateBlockBody *hclwrite.Body, terraformConfig *c
 
 // SetAWSRKE2K3SProvider is a helper function that will set the AWS RKE2/K3S
 // Terraform provider details in the main.tf file.
-func SetAWSRKE2K3SProvider(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+func SetAWSRKE2K3SProvider(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
 	cloudCredBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.CloudCredential, terraformConfig.ResourcePrefix})
 	cloudCredBlockBody := cloudCredBlock.Body()
 
@@ -44,6 +45,6 @@ func SetAWSRKE2K3SProvider(rootBody *hclwrite.Body, terraformConfig *config.Terr
 	awsCredBlock := cloudCredBlockBody.AppendNewBlock(amazon.EC2CredentialConfig, nil)
 	awsCredBlockBody := awsCredBlock.Body()
 
-	awsCredBlockBody.SetAttributeValue(defaults.AccessKey, cty.StringVal(terraformConfig.AWSCredentials.AWSAccessKey))
-	awsCredBlockBody.SetAttributeValue(defaults.SecretKey, cty.StringVal(terraformConfig.AWSCredentials.AWSSecretKey))
+	secrets.SetSensitiveAttribute(newFile, awsCredBlockBody, defaults.AccessKey, secrets.AWSAccessKey, terraformConfig.AWSCredentials.AWSAccessKey)
+	secrets.SetSensitiveAttribute(newFile, awsCredBlockBody, defaults.SecretKey, secrets.AWSSecretKey, terraformConfig.AWSCredentials.AWSSecretKey)
 }
@@ -5,12 +5,13 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/azure"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/zclconf/go-cty/cty"
 )
 
 // SetAzureRKE1Provider is a helper function that will set the Azure RKE1
 // Terraform configurations in the main.tf file.
-func SetAzureRKE1Provider(nodeTemplateBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+func SetAzureRKE1Provider(newFile *hclwrite.File, nodeTemplateBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
 	azureConfigBlock := nodeTemplateBlockBody.AppendNewBlock(azure.AzureConfig, nil)
 	azureConfigBlockBody := azureConfigBlock.Body()
 
@@ -21,7 +22,7 @@ func SetAzureRKE1Provider(nodeTemplateBlockBody *hclwrite.Body, terraformConfig
 
 	azureConfigBlockBody.SetAttributeValue(azure.AvailabilitySet, cty.StringVal(terraformConfig.AzureConfig.AvailabilitySet))
 	azureConfigBlockBody.SetAttributeValue(azure.ClientID, cty.StringVal(terraformConfig.AzureCredentials.ClientID))
-	azureConfigBlockBody.SetAttributeValue(azure.ClientSecret, cty.StringVal(terraformConfig.AzureCredentials.ClientSecret))
+	secrets.SetSensitiveAttribute(newFile, azureConfigBlockBody, azure.ClientSecret, secrets.AzureClientSecret, terraformConfig.AzureCredentials.ClientSecret)
 	azureConfigBlockBody.SetAttributeValue(azure.SubscriptionID, cty.StringVal(terraformConfig.AzureCredentials.SubscriptionID))
 	azureConfigBlockBody.SetAttributeValue(azure.Environment, cty.StringVal(terraformConfig.AzureCredentials.Environment))
 	azureConfigBlockBody.SetAttributeValue(azure.CustomData, cty.StringVal(terraformConfig.AzureConfig.CustomData))
@@ -43,7 +44,7 @@ func SetAzureRKE1Provider(nodeTemplateBlockBody *hclwrite.Body, terraformConfig
 }
 
 // SetAzureRKE2K3SProvider is a helper function that will set the Azure RKE2/K3S Terraform provider details in the main.tf file.
-func SetAzureRKE2K3SProvider(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+func SetAzureRKE2K3SProvider(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
 	cloudCredBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.CloudCredential, terraformConfig.ResourcePrefix})
 	cloudCredBlockBody := cloudCredBlock.Body()
 
@@ -53,7 +54,7 @@ func SetAzureRKE2K3SProvider(rootBody *hclwrite.Body, terraformConfig *config.Te
 	azureCredBlockBody := azureCredBlock.Body()
 
 	azureCredBlockBody.SetAttributeValue(azure.ClientID, cty.StringVal(terraformConfig.AzureCredentials.ClientID))
-	azureCredBlockBody.SetAttributeValue(azure.ClientSecret, cty.StringVal(terraformConfig.AzureCredentials.ClientSecret))
+	secrets.SetSensitiveAttribute(newFile, azureCredBlockBody, azure.ClientSecret, secrets.AzureClientSecret, terraformConfig.AzureCredentials.ClientSecret)
 	azureCredBlockBody.SetAttributeValue(azure.SubscriptionID, cty.StringVal(terraformConfig.AzureCredentials.SubscriptionID))
 	azureCredBlockBody.SetAttributeValue(azure.Environment, cty.StringVal(terraformConfig.AzureCredentials.Environment))
 	azureCredBlockBody.SetAttributeValue(azure.TenantID, cty.StringVal(terraformConfig.AzureCredentials.TenantID))
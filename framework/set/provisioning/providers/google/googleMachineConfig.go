@@ -0,0 +1,54 @@
+package google
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/google"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// SetGoogleRKE2K3SMachineConfig is a helper function that will set the Google RKE2/K3S
+// Terraform machine configurations in the main.tf file.
+func SetGoogleRKE2K3SMachineConfig(machineConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+	googleConfigBlock := machineConfigBlockBody.AppendNewBlock(google.GoogleConfig, nil)
+	googleConfigBlockBody := googleConfigBlock.Body()
+
+	googleConfigBlockBody.SetAttributeValue(google.Project, cty.StringVal(terraformConfig.GoogleConfig.ProjectID))
+	googleConfigBlockBody.SetAttributeValue(google.Zone, cty.StringVal(terraformConfig.GoogleConfig.Zone))
+	googleConfigBlockBody.SetAttributeValue(google.MachineType, cty.StringVal(terraformConfig.GoogleConfig.MachineType))
+	googleConfigBlockBody.SetAttributeValue(google.MachineImage, cty.StringVal(terraformConfig.GoogleConfig.MachineImage))
+
+	if terraformConfig.GoogleConfig.DiskSize != "" {
+		googleConfigBlockBody.SetAttributeValue(google.DiskSize, cty.StringVal(terraformConfig.GoogleConfig.DiskSize))
+	}
+
+	if terraformConfig.GoogleConfig.DiskType != "" {
+		googleConfigBlockBody.SetAttributeValue(google.DiskType, cty.StringVal(terraformConfig.GoogleConfig.DiskType))
+	}
+
+	googleConfigBlockBody.SetAttributeValue(google.Network, cty.StringVal(terraformConfig.GoogleConfig.Network))
+
+	if terraformConfig.GoogleConfig.Subnetwork != "" {
+		googleConfigBlockBody.SetAttributeValue(google.SubNetwork, cty.StringVal(terraformConfig.GoogleConfig.Subnetwork))
+	}
+
+	if terraformConfig.GoogleConfig.Username != "" {
+		googleConfigBlockBody.SetAttributeValue(google.Username, cty.StringVal(terraformConfig.GoogleConfig.Username))
+	}
+
+	// The node driver takes the network tags as a single comma separated value.
+	if len(terraformConfig.GoogleConfig.Tags) > 0 {
+		googleConfigBlockBody.SetAttributeValue(google.Tags, cty.StringVal(strings.Join(terraformConfig.GoogleConfig.Tags, ",")))
+	}
+
+	if len(terraformConfig.GoogleConfig.OpenPort) > 0 {
+		openPorts := make([]cty.Value, len(terraformConfig.GoogleConfig.OpenPort))
+		for i, port := range terraformConfig.GoogleConfig.OpenPort {
+			openPorts[i] = cty.StringVal(port)
+		}
+
+		googleConfigBlockBody.SetAttributeValue(google.OpenPort, cty.ListVal(openPorts))
+	}
+}
@@ -0,0 +1,24 @@
+package google
+
+import (
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/google"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// SetGoogleRKE2K3SProvider is a helper function that will set the Google RKE2/K3S
+// Terraform provider details in the main.tf file.
+func SetGoogleRKE2K3SProvider(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+	cloudCredBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.CloudCredential, terraformConfig.ResourcePrefix})
+	cloudCredBlockBody := cloudCredBlock.Body()
+
+	cloudCredBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix))
+
+	googleCredBlock := cloudCredBlockBody.AppendNewBlock(google.GoogleCredentialConfig, nil)
+	googleCredBlockBody := googleCredBlock.Body()
+
+	secrets.SetSensitiveAttribute(newFile, googleCredBlockBody, google.AuthEncodedJSON, secrets.GoogleAuthEncodedJSON, terraformConfig.GoogleCredentials.AuthEncodedJSON)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/harvester"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -37,7 +38,7 @@ func SetHarvesterRKE1Provider(nodeTemplateBlockBody *hclwrite.Body, terraformCon
 }
 
 // SetHarvesterCredentialProvider is a helper function that will set the Harvester cloud provider in main.tf
-func SetHarvesterCredentialProvider(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+func SetHarvesterCredentialProvider(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
 	cloudCredBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.CloudCredential, terraformConfig.ResourcePrefix})
 	cloudCredBlockBody := cloudCredBlock.Body()
 
@@ -48,7 +49,7 @@ func SetHarvesterCredentialProvider(rootBody *hclwrite.Body, terraformConfig *co
 
 	harvesterCredBlockBody.SetAttributeValue(harvester.ClusterID, cty.StringVal(terraformConfig.HarvesterCredentials.ClusterID))
 	harvesterCredBlockBody.SetAttributeValue(harvester.ClusterType, cty.StringVal(terraformConfig.HarvesterCredentials.ClusterType))
-	harvesterCredBlockBody.SetAttributeValue(harvester.KubeconfigContent, cty.StringVal(terraformConfig.HarvesterCredentials.KubeconfigContent))
+	secrets.SetSensitiveAttribute(newFile, harvesterCredBlockBody, harvester.KubeconfigContent, secrets.HarvesterKubeconfig, terraformConfig.HarvesterCredentials.KubeconfigContent)
 }
 
 func constructNetworkInfo(networkNames []string) hclwrite.Tokens {
@@ -4,12 +4,13 @@ import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/vsphere"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/zclconf/go-cty/cty"
 )
 
 // SetVsphereRKE2K3SMachineConfig is a helper function that will set the Vsphere RKE2/K3S
 // Terraform machine configurations in the main.tf file.
-func SetVsphereRKE2K3SMachineConfig(machineConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+func SetVsphereRKE2K3SMachineConfig(newFile *hclwrite.File, machineConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
 	vsphereConfigBlock := machineConfigBlockBody.AppendNewBlock(vsphere.VsphereConfig, nil)
 	vsphereConfigBlockBody := vsphereConfigBlock.Body()
 
@@ -40,7 +41,7 @@ func SetVsphereRKE2K3SMachineConfig(machineConfigBlockBody *hclwrite.Body, terra
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.MemorySize, cty.StringVal(terraformConfig.VsphereConfig.MemorySize))
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.Network, cty.ListVal(networks))
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.Pool, cty.StringVal(terraformConfig.VsphereConfig.Pool))
-	vsphereConfigBlockBody.SetAttributeValue(vsphere.SSHPassword, cty.StringVal(terraformConfig.VsphereConfig.SSHPassword))
+	secrets.SetSensitiveAttribute(newFile, vsphereConfigBlockBody, vsphere.SSHPassword, secrets.VsphereSSHPassword, terraformConfig.VsphereConfig.SSHPassword)
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.SSHPort, cty.StringVal(terraformConfig.VsphereConfig.SSHPort))
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.SSHUser, cty.StringVal(terraformConfig.VsphereConfig.SSHUser))
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.SSHUserGroup, cty.StringVal(terraformConfig.VsphereConfig.SSHUserGroup))
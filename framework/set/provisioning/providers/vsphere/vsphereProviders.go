@@ -5,12 +5,13 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/vsphere"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/zclconf/go-cty/cty"
 )
 
 // SetVsphereRKE1Provider is a helper function that will set the Vsphere RKE1
 // Terraform provider details in the main.tf file.
-func SetVsphereRKE1Provider(nodeTemplateBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+func SetVsphereRKE1Provider(newFile *hclwrite.File, nodeTemplateBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
 	vsphereConfigBlock := nodeTemplateBlockBody.AppendNewBlock(vsphere.VsphereConfig, nil)
 	vsphereConfigBlockBody := vsphereConfigBlock.Body()
 
@@ -40,9 +41,9 @@ func SetVsphereRKE1Provider(nodeTemplateBlockBody *hclwrite.Body, terraformConfi
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.HostSystem, cty.StringVal(terraformConfig.VsphereConfig.HostSystem))
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.MemorySize, cty.StringVal(terraformConfig.VsphereConfig.MemorySize))
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.Network, cty.ListVal(networks))
-	vsphereConfigBlockBody.SetAttributeValue(vsphere.Password, cty.StringVal(terraformConfig.VsphereCredentials.Password))
+	secrets.SetSensitiveAttribute(newFile, vsphereConfigBlockBody, vsphere.Password, secrets.VspherePassword, terraformConfig.VsphereCredentials.Password)
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.Pool, cty.StringVal(terraformConfig.VsphereConfig.Pool))
-	vsphereConfigBlockBody.SetAttributeValue(vsphere.SSHPassword, cty.StringVal(terraformConfig.VsphereConfig.SSHPassword))
+	secrets.SetSensitiveAttribute(newFile, vsphereConfigBlockBody, vsphere.SSHPassword, secrets.VsphereSSHPassword, terraformConfig.VsphereConfig.SSHPassword)
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.SSHPort, cty.StringVal(terraformConfig.VsphereConfig.SSHPort))
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.SSHUser, cty.StringVal(terraformConfig.VsphereConfig.SSHUser))
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.SSHUserGroup, cty.StringVal(terraformConfig.VsphereConfig.SSHUserGroup))
@@ -52,7 +53,7 @@ func SetVsphereRKE1Provider(nodeTemplateBlockBody *hclwrite.Body, terraformConfi
 }
 
 // SetVsphereRKE2K3SProvider is a helper function that will set the Vsphere RKE2/K3S Terraform provider details in the main.tf file.
-func SetVsphereRKE2K3SProvider(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+func SetVsphereRKE2K3SProvider(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
 	cloudCredBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.CloudCredential, terraformConfig.ResourcePrefix})
 	cloudCredBlockBody := cloudCredBlock.Body()
 
@@ -61,7 +62,7 @@ func SetVsphereRKE2K3SProvider(rootBody *hclwrite.Body, terraformConfig *config.
 	vsphereCredBlock := cloudCredBlockBody.AppendNewBlock(vsphere.VsphereCredentialConfig, nil)
 	vsphereCredBlockBody := vsphereCredBlock.Body()
 
-	vsphereCredBlockBody.SetAttributeValue(vsphere.Password, cty.StringVal(terraformConfig.VsphereCredentials.Password))
+	secrets.SetSensitiveAttribute(newFile, vsphereCredBlockBody, vsphere.Password, secrets.VspherePassword, terraformConfig.VsphereCredentials.Password)
 	vsphereCredBlockBody.SetAttributeValue(vsphere.Username, cty.StringVal(terraformConfig.VsphereCredentials.Username))
 	vsphereCredBlockBody.SetAttributeValue(vsphere.Vcenter, cty.StringVal(terraformConfig.VsphereCredentials.Vcenter))
 	vsphereCredBlockBody.SetAttributeValue(vsphere.VcenterPort, cty.StringVal(terraformConfig.VsphereCredentials.VcenterPort))
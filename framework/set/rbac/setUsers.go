@@ -7,6 +7,7 @@ import (
 	namegen "github.com/rancher/shepherd/pkg/namegenerator"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -41,7 +42,7 @@ func setUsers(newFile *hclwrite.File, rootBody *hclwrite.Body, rbacRole config.R
 
 	userBlockBody.SetAttributeValue(name, cty.StringVal(testuser))
 	userBlockBody.SetAttributeValue(username, cty.StringVal(testuser))
-	userBlockBody.SetAttributeValue(testPassword, cty.StringVal(testpassword))
+	secrets.SetSensitiveAttribute(newFile, userBlockBody, testPassword, secrets.TestUserPassword, testpassword)
 	userBlockBody.SetAttributeValue(defaults.Enabled, cty.BoolVal(true))
 
 	rootBody.AppendNewline()
@@ -0,0 +1,180 @@
+package set
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/defaults/providers"
+)
+
+type Distro string
+type Mode string
+type OS string
+
+const (
+	RKE1   Distro = "rke1"
+	RKE2   Distro = "rke2"
+	K3S    Distro = "k3s"
+	Hosted Distro = "hosted"
+
+	NodeDriver   Mode = "nodedriver"
+	Custom       Mode = "custom"
+	Import       Mode = "import"
+	Airgap       Mode = "airgap"
+	HostedMode   Mode = "hosted"
+	HostedImport Mode = "hosted_import"
+
+	Linux       OS = "linux"
+	Windows2019 OS = "windows_2019"
+	Windows2022 OS = "windows_2022"
+)
+
+// ModuleGenerator sets the resources of a module in the main.tf file.
+type ModuleGenerator func(client *rancher.Client, module Module, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
+	rbacRole config.Role, newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File, configMap []map[string]any,
+	isWindows bool) (*hclwrite.File, *os.File, error)
+
+// ModuleVerifier verifies that a provisioned cluster of a module has the expected number of nodes.
+type ModuleVerifier func(client *rancher.Client, clusterID string, nodeCount int64) error
+
+// Module describes a supported module and how it is generated and verified.
+type Module struct {
+	Name     string
+	Provider string
+	Distro   Distro
+	Mode     Mode
+	OS       OS
+	Generate ModuleGenerator
+	Verify   ModuleVerifier
+}
+
+var registry = map[string]Module{}
+
+func init() {
+	RegisterModule(Module{Name: modules.AKS, Provider: providers.Azure, Distro: Hosted, Mode: HostedMode, OS: Linux, Generate: HostedClusters, Verify: verifyAKSNodeCount})
+	RegisterModule(Module{Name: modules.EKS, Provider: providers.AWS, Distro: Hosted, Mode: HostedMode, OS: Linux, Generate: HostedClusters, Verify: verifyEKSNodeCount})
+	RegisterModule(Module{Name: modules.GKE, Provider: providers.Google, Distro: Hosted, Mode: HostedMode, OS: Linux, Generate: HostedClusters, Verify: verifyGKENodeCount})
+	RegisterModule(Module{Name: modules.AKSImport, Provider: providers.Azure, Distro: Hosted, Mode: HostedImport, OS: Linux, Generate: HostedClusters, Verify: verifyAKSNodeCount})
+	RegisterModule(Module{Name: modules.EKSImport, Provider: providers.AWS, Distro: Hosted, Mode: HostedImport, OS: Linux, Generate: HostedClusters, Verify: verifyEKSNodeCount})
+	RegisterModule(Module{Name: modules.GKEImport, Provider: providers.Google, Distro: Hosted, Mode: HostedImport, OS: Linux, Generate: HostedClusters, Verify: verifyGKENodeCount})
+
+	for _, module := range []struct {
+		name     string
+		provider string
+		distro   Distro
+	}{
+		{modules.AzureRKE1, providers.Azure, RKE1},
+		{modules.AzureRKE2, providers.Azure, RKE2},
+		{modules.AzureK3s, providers.Azure, K3S},
+		{modules.EC2RKE1, providers.AWS, RKE1},
+		{modules.EC2RKE2, providers.AWS, RKE2},
+		{modules.EC2K3s, providers.AWS, K3S},
+		{modules.GCERKE2, providers.Google, RKE2},
+		{modules.GCEK3s, providers.Google, K3S},
+		{modules.HarvesterRKE1, providers.Harvester, RKE1},
+		{modules.HarvesterRKE2, providers.Harvester, RKE2},
+		{modules.HarvesterK3s, providers.Harvester, K3S},
+		{modules.LinodeRKE1, providers.Linode, RKE1},
+		{modules.LinodeRKE2, providers.Linode, RKE2},
+		{modules.LinodeK3s, providers.Linode, K3S},
+		{modules.VsphereRKE1, providers.Vsphere, RKE1},
+		{modules.VsphereRKE2, providers.Vsphere, RKE2},
+		{modules.VsphereK3s, providers.Vsphere, K3S},
+	} {
+		RegisterModule(Module{Name: module.name, Provider: module.provider, Distro: module.distro, Mode: NodeDriver, OS: Linux,
+			Generate: NodeDriverClusters, Verify: verifyNodeCount})
+	}
+
+	for _, module := range []struct {
+		name     string
+		provider string
+		distro   Distro
+		mode     Mode
+		os       OS
+	}{
+		{modules.CustomAzureRKE2, providers.Azure, RKE2, Custom, Linux},
+		{modules.CustomAzureK3s, providers.Azure, K3S, Custom, Linux},
+		{modules.CustomEC2RKE1, providers.AWS, RKE1, Custom, Linux},
+		{modules.CustomEC2RKE2, providers.AWS, RKE2, Custom, Linux},
+		{modules.CustomEC2RKE2Windows2019, providers.AWS, RKE2, Custom, Windows2019},
+		{modules.CustomEC2RKE2Windows2022, providers.AWS, RKE2, Custom, Windows2022},
+		{modules.CustomEC2K3s, providers.AWS, K3S, Custom, Linux},
+		{modules.CustomGCERKE2, providers.Google, RKE2, Custom, Linux},
+		{modules.CustomGCEK3s, providers.Google, K3S, Custom, Linux},
+		{modules.CustomVsphereRKE1, providers.Vsphere, RKE1, Custom, Linux},
+		{modules.CustomVsphereRKE2, providers.Vsphere, RKE2, Custom, Linux},
+		{modules.CustomVsphereK3s, providers.Vsphere, K3S, Custom, Linux},
+		{modules.ImportAzureRKE2, providers.Azure, RKE2, Import, Linux},
+		{modules.ImportAzureK3s, providers.Azure, K3S, Import, Linux},
+		{modules.ImportEC2RKE1, providers.AWS, RKE1, Import, Linux},
+		{modules.ImportEC2RKE2, providers.AWS, RKE2, Import, Linux},
+		{modules.ImportEC2RKE2Windows2019, providers.AWS, RKE2, Import, Windows2019},
+		{modules.ImportEC2RKE2Windows2022, providers.AWS, RKE2, Import, Windows2022},
+		{modules.ImportEC2K3s, providers.AWS, K3S, Import, Linux},
+		{modules.ImportGCERKE2, providers.Google, RKE2, Import, Linux},
+		{modules.ImportGCEK3s, providers.Google, K3S, Import, Linux},
+		{modules.ImportVsphereRKE1, providers.Vsphere, RKE1, Import, Linux},
+		{modules.ImportVsphereRKE2, providers.Vsphere, RKE2, Import, Linux},
+		{modules.ImportVsphereK3s, providers.Vsphere, K3S, Import, Linux},
+		{modules.AirgapRKE1, providers.AWS, RKE1, Airgap, Linux},
+		{modules.AirgapRKE2, providers.AWS, RKE2, Airgap, Linux},
+		{modules.AirgapRKE2Windows2019, providers.AWS, RKE2, Airgap, Windows2019},
+		{modules.AirgapRKE2Windows2022, providers.AWS, RKE2, Airgap, Windows2022},
+		{modules.AirgapK3S, providers.AWS, K3S, Airgap, Linux},
+	} {
+		var generate ModuleGenerator
+		switch module.mode {
+		case Custom:
+			generate = CustomClusters
+		case Import:
+			generate = ImportedClusters
+		case Airgap:
+			generate = AirgapClusters
+		}
+
+		RegisterModule(Module{Name: module.name, Provider: module.provider, Distro: module.distro, Mode: module.mode, OS: module.os,
+			Generate: generate, Verify: verifyNodeCount})
+	}
+}
+
+// RegisterModule adds a module to the registry of supported modules.
+func RegisterModule(module Module) {
+	if _, ok := registry[module.Name]; ok {
+		panic(fmt.Sprintf("Module %s is already registered", module.Name))
+	}
+
+	registry[module.Name] = module
+}
+
+// LookupModule returns the registered module for the given name.
+func LookupModule(name string) (Module, error) {
+	module, ok := registry[name]
+	if !ok {
+		return Module{}, fmt.Errorf("Unsupported module: %q. Supported modules are: %s", name, strings.Join(SupportedModules(), ", "))
+	}
+
+	return module, nil
+}
+
+// SupportedModules returns the sorted names of all registered modules.
+func SupportedModules() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// IsWindows returns whether the module provisions Windows nodes.
+func (m Module) IsWindows() bool {
+	return m.OS == Windows2019 || m.OS == Windows2022
+}
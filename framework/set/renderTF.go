@@ -0,0 +1,65 @@
+package set
+
+import (
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/backend"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
+)
+
+// RenderTF is a function that will render the main.tf file contents based on the module type without needing a Rancher client
+// or writing to the filesystem. The Rancher admin token is used for the provider alias and RBAC configurations are skipped.
+// Sensitive values are declared as variables and returned separately so they can be written to secrets.auto.tfvars.json. When
+// isWindows is set, the Windows nodes of custom and airgap modules are rendered after the Linux cluster, so the complete module
+// is returned.
+func RenderTF(rancherConfig *rancher.Config, configMap []map[string]any, isWindows bool) ([]byte, map[string]string, error) {
+	newFile := hclwrite.NewEmptyFile()
+	rootBody := newFile.Body()
+
+	defer secrets.Reset(newFile)
+
+	customModule := false
+	windowsPass := false
+	for _, cattleConfig := range configMap {
+		_, terraformConfig, terratestConfig, _ := config.LoadTFPConfigs(cattleConfig)
+
+		module, err := LookupModule(terraformConfig.Module)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		err = backend.ValidateBackend(terratestConfig.Backend)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if module.Mode != NodeDriver && module.Mode != HostedMode && module.Mode != HostedImport {
+			customModule = true
+		}
+
+		if isWindows && (module.Mode == Custom || module.Mode == Airgap) {
+			windowsPass = true
+		}
+	}
+
+	newFile, rootBody = rancher2.SetRenderProvidersTF(rancherConfig, newFile, rootBody, configMap, customModule)
+
+	_, customClusterNames, err := setClusters(nil, "", configMap, newFile, rootBody, nil, false, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The Windows nodes are added on top of the Linux pass, the same as the persisted clusters of the Windows provisioning tests.
+	if windowsPass {
+		_, _, err = setClusters(nil, "", configMap, newFile, rootBody, nil, true, customClusterNames)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	values := secrets.DeclareVariables(newFile)
+
+	return newFile.Bytes(), values, nil
+}
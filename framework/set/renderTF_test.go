@@ -0,0 +1,427 @@
+package set
+
+import (
+	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	shepherdConfig "github.com/rancher/shepherd/pkg/config"
+	"github.com/rancher/shepherd/pkg/config/operations"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/defaults/providers"
+	"github.com/rancher/tfp-automation/framework/set/backend"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	goldenDir   = "testdata/golden"
+	testdataDir = "testdata"
+)
+
+var (
+	update = flag.Bool("update", false, "update the golden files")
+
+	// randomSuffix matches names generated by namegen.AppendRandomString so they can be compared across runs.
+	randomSuffix = regexp.MustCompile(`(auto-[a-z0-9-]+)-[a-z]{5}`)
+
+	// pathSegment matches the keys and list indexes of a dotted override path, such as terratest.nodepools[2].labels.
+	pathSegment = regexp.MustCompile(`([^.\[\]]+)|\[(\d+)\]`)
+)
+
+// renderCase is a golden render of a module. The overrides are applied to the testdata cattle config, keyed by the dotted path of
+// the value, such as terratest.nodepools[2].labels. An index one past the end of a list appends a new item.
+type renderCase struct {
+	name      string
+	module    string
+	golden    string
+	overrides map[string]any
+}
+
+func TestRenderTF(t *testing.T) {
+	setRenderEnv(t)
+
+	// The etcd and control plane pools share the default machine config, while both worker pools use the same bigger one.
+	machineConfig := map[string]any{
+		"instanceType": "m5.2xlarge",
+		"rootSize":     200,
+		"cpuCount":     "8",
+		"memorySize":   "16384",
+		"diskSize":     "80000",
+	}
+
+	machinePools := map[string]any{
+		"terratest.nodepools[2].machineConfig": machineConfig,
+		"terratest.nodepools[3]":               map[string]any{"quantity": 2, "worker": true, "machineConfig": machineConfig},
+	}
+
+	labels := map[string]any{"terratest.nodepools[2].labels": map[string]any{"tfp.rancher.io/pool": "workers"}}
+	taints := map[string]any{"terratest.nodepools[2].taints": []any{map[string]any{"key": "dedicated", "value": "workers", "effect": "NoSchedule"}}}
+	nodepoolSettings := merge(labels, taints, map[string]any{"terratest.nodepools[2].drainBeforeDelete": true})
+
+	tests := []renderCase{
+		{"AKS", modules.AKS, "", nil},
+		{"EKS", modules.EKS, "", nil},
+		{"GKE", modules.GKE, "", nil},
+		{"Azure_RKE1", modules.AzureRKE1, "", nil},
+		{"Azure_RKE2", modules.AzureRKE2, "", nil},
+		{"Azure_K3S", modules.AzureK3s, "", nil},
+		{"EC2_RKE1", modules.EC2RKE1, "", nil},
+		{"EC2_RKE2", modules.EC2RKE2, "", nil},
+		{"EC2_K3S", modules.EC2K3s, "", nil},
+		{"GCE_RKE2", modules.GCERKE2, "", nil},
+		{"GCE_K3S", modules.GCEK3s, "", nil},
+		{"Harvester_RKE1", modules.HarvesterRKE1, "", nil},
+		{"Harvester_RKE2", modules.HarvesterRKE2, "", nil},
+		{"Harvester_K3S", modules.HarvesterK3s, "", nil},
+		{"Linode_RKE1", modules.LinodeRKE1, "", nil},
+		{"Linode_RKE2", modules.LinodeRKE2, "", nil},
+		{"Linode_K3S", modules.LinodeK3s, "", nil},
+		{"Vsphere_RKE1", modules.VsphereRKE1, "", nil},
+		{"Vsphere_RKE2", modules.VsphereRKE2, "", nil},
+		{"Vsphere_K3S", modules.VsphereK3s, "", nil},
+		{"Custom_Azure_RKE2", modules.CustomAzureRKE2, "", withProvider(providers.Azure)},
+		{"Custom_Azure_K3S", modules.CustomAzureK3s, "", withProvider(providers.Azure)},
+		{"Custom_EC2_RKE1", modules.CustomEC2RKE1, "", withProvider(providers.AWS)},
+		{"Custom_EC2_RKE2", modules.CustomEC2RKE2, "", withProvider(providers.AWS)},
+		{"Custom_EC2_RKE2_Windows_2019", modules.CustomEC2RKE2Windows2019, "", withProvider(providers.AWS)},
+		{"Custom_EC2_RKE2_Windows_2022", modules.CustomEC2RKE2Windows2022, "", withProvider(providers.AWS)},
+		{"Custom_EC2_K3S", modules.CustomEC2K3s, "", withProvider(providers.AWS)},
+		{"Custom_GCE_RKE2", modules.CustomGCERKE2, "", withProvider(providers.Google)},
+		{"Custom_GCE_K3S", modules.CustomGCEK3s, "", withProvider(providers.Google)},
+		{"Custom_Vsphere_RKE1", modules.CustomVsphereRKE1, "", withProvider(providers.Vsphere)},
+		{"Custom_Vsphere_RKE2", modules.CustomVsphereRKE2, "", withProvider(providers.Vsphere)},
+		{"Custom_Vsphere_K3S", modules.CustomVsphereK3s, "", withProvider(providers.Vsphere)},
+		{"Import_Azure_RKE2", modules.ImportAzureRKE2, "", withProvider(providers.Azure)},
+		{"Import_Azure_K3S", modules.ImportAzureK3s, "", withProvider(providers.Azure)},
+		{"Import_EC2_RKE1", modules.ImportEC2RKE1, "", withProvider(providers.AWS)},
+		{"Import_EC2_RKE2", modules.ImportEC2RKE2, "", withProvider(providers.AWS)},
+		{"Import_EC2_RKE2_Windows_2019", modules.ImportEC2RKE2Windows2019, "", withProvider(providers.AWS)},
+		{"Import_EC2_RKE2_Windows_2022", modules.ImportEC2RKE2Windows2022, "", withProvider(providers.AWS)},
+		{"Import_EC2_K3S", modules.ImportEC2K3s, "", withProvider(providers.AWS)},
+		{"Import_GCE_RKE2", modules.ImportGCERKE2, "", withProvider(providers.Google)},
+		{"Import_GCE_K3S", modules.ImportGCEK3s, "", withProvider(providers.Google)},
+		{"Import_Vsphere_RKE1", modules.ImportVsphereRKE1, "", withProvider(providers.Vsphere)},
+		{"Import_Vsphere_RKE2", modules.ImportVsphereRKE2, "", withProvider(providers.Vsphere)},
+		{"Import_Vsphere_K3S", modules.ImportVsphereK3s, "", withProvider(providers.Vsphere)},
+		{"Airgap_RKE1", modules.AirgapRKE1, "", withProvider(providers.AWS)},
+		{"Airgap_RKE2", modules.AirgapRKE2, "", withProvider(providers.AWS)},
+		{"Airgap_RKE2_Windows_2019", modules.AirgapRKE2Windows2019, "", withProvider(providers.AWS)},
+		{"Airgap_RKE2_Windows_2022", modules.AirgapRKE2Windows2022, "", withProvider(providers.AWS)},
+		{"Airgap_K3S", modules.AirgapK3S, "", withProvider(providers.AWS)},
+		{"EC2_RKE2_Machine_Pools", modules.EC2RKE2, "_machine_pools", machinePools},
+		{"Vsphere_K3S_Machine_Pools", modules.VsphereK3s, "_machine_pools", machinePools},
+		{"EC2_RKE1_Nodepool_Settings", modules.EC2RKE1, "_nodepool_settings", nodepoolSettings},
+		{"EC2_RKE2_Nodepool_Settings", modules.EC2RKE2, "_nodepool_settings", merge(nodepoolSettings, map[string]any{
+			"terratest.nodepools[2].nodeStartupTimeoutSeconds":   600,
+			"terratest.nodepools[2].unhealthyNodeTimeoutSeconds": 300,
+			"terratest.nodepools[2].maxUnhealthy":                "50%",
+		})},
+		{"EC2_K3S_Upgrade_Strategy", modules.EC2K3s, "_upgrade_strategy", map[string]any{
+			"terraform.upgradeStrategy": map[string]any{
+				"controlPlaneConcurrency": "1",
+				"workerConcurrency":       "50%",
+				"controlPlaneDrainOptions": map[string]any{
+					"enabled": false,
+				},
+				"workerDrainOptions": map[string]any{
+					"enabled":            true,
+					"deleteEmptyDirData": true,
+					"ignoreDaemonSets":   true,
+					"gracePeriod":        30,
+					"timeout":            600,
+				},
+			},
+		}},
+		{"Linode_RKE2_Snapshot_S3", modules.LinodeRKE2, "_snapshot_s3", map[string]any{
+			"terraform.etcd": map[string]any{
+				"snapshotScheduleCron": "*/10 * * * *",
+				"snapshotRetention":    3,
+			},
+			"terratest.snapshotInput.s3": map[string]any{
+				"accessKey":  "minio-access-key",
+				"secretKey":  "minio-secret-key",
+				"bucket":     "etcd-snapshots",
+				"endpoint":   "10.0.0.10:30900",
+				"endpointCA": "-----BEGIN CERTIFICATE-----",
+				"folder":     "tfp",
+				"region":     "us-east-1",
+			},
+		}},
+		{"EC2_RKE1_Rotate_Certificates", modules.EC2RKE1, "_rotate_certificates", map[string]any{
+			"terratest.rotateCertificates": map[string]any{"caCertificates": true, "services": []any{"kube-apiserver", "kubelet"}},
+		}},
+		{"EC2_RKE2_Rotate_Certificates", modules.EC2RKE2, "_rotate_certificates", map[string]any{
+			"terratest.rotateCertificates": map[string]any{"generation": 2, "services": []any{"api-server", "kubelet"}},
+		}},
+		// The Kubernetes version of an existing AKS or EKS cluster is kept when no version is set, while GKE node pools require one.
+		{"AKS_Import", modules.AKSImport, "", hostedImport("")},
+		{"EKS_Import", modules.EKSImport, "", hostedImport("")},
+		{"GKE_Import", modules.GKEImport, "", hostedImport("1.32.4-gke.1415000")},
+		{"AKS_Nodepool_Settings", modules.AKS, "_nodepool_settings", merge(labels, taints, map[string]any{
+			"terratest.nodepools[2].name":          "workers",
+			"terratest.nodepools[2].mode":          "User",
+			"terratest.nodepools[2].autoscaling":   true,
+			"terratest.nodepools[2].minSize":       1,
+			"terratest.nodepools[2].maxSize":       3,
+			"terraform.azureConfig.privateCluster": true,
+		})},
+		// EKS node groups have no taints in the provider, so they are only set on the AKS and GKE node pools.
+		{"EKS_Nodepool_Settings", modules.EKS, "_nodepool_settings", merge(labels, map[string]any{
+			"terratest.nodepools[2].name":           "workers",
+			"terratest.nodepools[2].spot":           true,
+			"terratest.nodepools[2].launchTemplate": map[string]any{"id": "lt-0123456789", "version": 2},
+			"terraform.awsConfig.privateAccess":     true,
+			"terraform.awsConfig.publicAccess":      false,
+		})},
+		{"GKE_Nodepool_Settings", modules.GKE, "_nodepool_settings", merge(labels, taints, map[string]any{
+			"terratest.nodepools[2].name":                  "workers",
+			"terratest.nodepools[2].spot":                  true,
+			"terratest.nodepools[2].autoscaling":           true,
+			"terratest.nodepools[2].minSize":               1,
+			"terratest.nodepools[2].maxSize":               3,
+			"terratest.nodepools[2].management":            map[string]any{"autoRepair": true, "autoUpgrade": false},
+			"terraform.googleConfig.enablePrivateNodes":    true,
+			"terraform.googleConfig.enablePrivateEndpoint": true,
+			"terraform.googleConfig.masterIpv4CidrBlock":   "172.16.0.0/28",
+		})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cattleConfig := shepherdConfig.LoadConfigFromFile(filepath.Join(testdataDir, "cattle-config.yaml"))
+
+			_, err := operations.ReplaceValue([]string{config.TerraformConfigurationFileKey, "module"}, tt.module, cattleConfig)
+			require.NoError(t, err)
+
+			paths := make([]string, 0, len(tt.overrides))
+			for path := range tt.overrides {
+				paths = append(paths, path)
+			}
+
+			sort.Strings(paths)
+
+			for _, path := range paths {
+				setOverride(cattleConfig, path, tt.overrides[path])
+			}
+
+			renderGolden(t, cattleConfig, filepath.Join(goldenDir, tt.module+tt.golden+".tf"))
+		})
+	}
+}
+
+func TestRenderTFMixedHosted(t *testing.T) {
+	setRenderEnv(t)
+
+	tests := []struct {
+		resourcePrefix string
+		module         string
+	}{
+		{"tfp-eks", modules.EKS},
+		{"tfp-aks", modules.AKS},
+		{"tfp-gke", modules.GKE},
+		{"tfp-rke1", modules.EC2RKE1},
+	}
+
+	var configMap []map[string]any
+	for _, tt := range tests {
+		cattleConfig := shepherdConfig.LoadConfigFromFile(filepath.Join(testdataDir, "cattle-config.yaml"))
+
+		_, err := operations.ReplaceValue([]string{config.TerraformConfigurationFileKey, "module"}, tt.module, cattleConfig)
+		require.NoError(t, err)
+
+		_, err = operations.ReplaceValue([]string{config.TerraformConfigurationFileKey, "resourcePrefix"}, tt.resourcePrefix, cattleConfig)
+		require.NoError(t, err)
+
+		configMap = append(configMap, cattleConfig)
+	}
+
+	module := renderGoldenConfigs(t, configMap, filepath.Join(goldenDir, "mixed_hosted.tf"))
+
+	parsed, diags := hclwrite.ParseConfig(module, "main.tf", hcl.InitialPos)
+	require.False(t, diags.HasErrors(), diags.Error())
+
+	addresses := map[string]bool{}
+	for _, block := range parsed.Body().Blocks() {
+		if block.Type() != "resource" {
+			continue
+		}
+
+		address := strings.Join(block.Labels(), ".")
+		require.False(t, addresses[address], "resource %s is rendered more than once", address)
+
+		addresses[address] = true
+	}
+}
+
+func TestRenderTFRemoteState(t *testing.T) {
+	setRenderEnv(t)
+
+	server := httptest.NewServer(backend.NewHTTPStateServer())
+	defer server.Close()
+
+	// The state is kept under the resource prefix of the run that created the resources.
+	state := `{"version":4,"serial":1,"lineage":"cleanup","resources":[]}`
+	response, err := http.Post(server.URL+"/tfstate/tfp", "application/json", strings.NewReader(state))
+	require.NoError(t, err)
+	response.Body.Close()
+
+	cattleConfig := shepherdConfig.LoadConfigFromFile(filepath.Join(testdataDir, "cattle-config.yaml"))
+
+	_, err = operations.ReplaceValue([]string{config.TerraformConfigurationFileKey, "module"}, modules.EC2RKE2, cattleConfig)
+	require.NoError(t, err)
+
+	setOverride(cattleConfig, "terratest.backend", map[string]any{"type": backend.HTTP, "address": server.URL + "/tfstate"})
+
+	rancherConfig, _, _, _ := config.LoadTFPConfigs(cattleConfig)
+
+	module, _, err := RenderTF(rancherConfig, []map[string]any{cattleConfig}, false)
+	require.NoError(t, err)
+
+	parsed, diags := hclwrite.ParseConfig(module, "main.tf", hcl.InitialPos)
+	require.False(t, diags.HasErrors(), diags.Error())
+
+	terraformBlock := parsed.Body().FirstMatchingBlock("terraform", nil)
+	require.NotNil(t, terraformBlock)
+
+	backendBlock := terraformBlock.Body().FirstMatchingBlock("backend", []string{backend.HTTP})
+	require.NotNil(t, backendBlock)
+
+	address := strings.Trim(string(backendBlock.Body().GetAttribute("address").Expr().BuildTokens(nil).Bytes()), `" `)
+
+	response, err = http.Get(address)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.Equal(t, state, string(body))
+}
+
+// withProvider is a helper function that will return the overrides of the infrastructure provider of a module.
+func withProvider(provider string) map[string]any {
+	return map[string]any{"terraform.provider": provider}
+}
+
+// hostedImport is a helper function that will return the overrides of an existing hosted cluster with a single node pool.
+func hostedImport(kubernetesVersion string) map[string]any {
+	return map[string]any{
+		"terraform.hostedClusterName": "existing-cluster",
+		"terratest.kubernetesVersion": kubernetesVersion,
+		"terratest.nodepools":         []any{map[string]any{"name": "existing-pool", "quantity": 3, "desiredSize": 3}},
+	}
+}
+
+// merge is a helper function that will return the overrides of all the given overrides, where later ones take precedence.
+func merge(overrides ...map[string]any) map[string]any {
+	merged := map[string]any{}
+	for _, override := range overrides {
+		for path, value := range override {
+			merged[path] = value
+		}
+	}
+
+	return merged
+}
+
+// setOverride is a helper function that will set the value at the dotted path of the cattle config, creating missing maps and
+// appending to lists when the index is one past the end.
+func setOverride(cattleConfig map[string]any, path string, value any) {
+	var keys []any
+	for _, match := range pathSegment.FindAllStringSubmatch(path, -1) {
+		if match[2] != "" {
+			index, _ := strconv.Atoi(match[2])
+			keys = append(keys, index)
+			continue
+		}
+
+		keys = append(keys, match[1])
+	}
+
+	setValue(cattleConfig, keys, value)
+}
+
+// setValue is a helper function that will set the value under the keys of the container and return the updated container.
+func setValue(container any, keys []any, value any) any {
+	if len(keys) == 0 {
+		return value
+	}
+
+	switch key := keys[0].(type) {
+	case int:
+		items, _ := container.([]any)
+		if key == len(items) {
+			items = append(items, nil)
+		}
+
+		items[key] = setValue(items[key], keys[1:], value)
+
+		return items
+	default:
+		fields, _ := container.(map[string]any)
+		if fields == nil {
+			fields = map[string]any{}
+		}
+
+		fields[key.(string)] = setValue(fields[key.(string)], keys[1:], value)
+
+		return fields
+	}
+}
+
+func setRenderEnv(t *testing.T) {
+	repoRoot, err := filepath.Abs(filepath.Join("..", ".."))
+	require.NoError(t, err)
+
+	t.Setenv("GOPATH", repoRoot)
+	t.Setenv("RANCHER2_PROVIDER_VERSION", "8.0.0")
+	t.Setenv("CLOUD_PROVIDER_VERSION", "5.95.0")
+	t.Setenv("LOCALS_PROVIDER_VERSION", "2.5.2")
+	t.Setenv("RKE_PROVIDER_VERSION", "1.7.7")
+}
+
+func renderGolden(t *testing.T, cattleConfig map[string]any, goldenFile string) {
+	renderGoldenConfigs(t, []map[string]any{cattleConfig}, goldenFile)
+}
+
+func renderGoldenConfigs(t *testing.T, configMap []map[string]any, goldenFile string) []byte {
+	rancherConfig, terraformConfig, _, _ := config.LoadTFPConfigs(configMap[0])
+
+	// Windows modules render the Windows custom and airgap clusters, the same as the Windows provisioning tests.
+	tfModule, err := LookupModule(terraformConfig.Module)
+	require.NoError(t, err)
+
+	module, values, err := RenderTF(rancherConfig, configMap, tfModule.IsWindows())
+	require.NoError(t, err)
+
+	for name, value := range values {
+		if value == "" {
+			continue
+		}
+
+		require.NotContains(t, string(module), value, "sensitive value of %s is rendered in plain text", name)
+	}
+
+	module = randomSuffix.ReplaceAll(module, []byte("${1}-xxxxx"))
+
+	if *update {
+		require.NoError(t, os.WriteFile(goldenFile, module, 0644))
+	}
+
+	expected, err := os.ReadFile(goldenFile)
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(module))
+
+	return module
+}
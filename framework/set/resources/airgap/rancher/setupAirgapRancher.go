@@ -10,6 +10,7 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -30,7 +31,7 @@ func CreateAirgapRancher(file *os.File, newFile *hclwrite.File, rootBody *hclwri
 		return nil, err
 	}
 
-	_, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, rke2BastionPublicDNS, installRancher)
+	_, provisionerBlockBody := rke2.SSHNullResource(newFile, rootBody, terraformConfig, rke2BastionPublicDNS, installRancher)
 
 	command := "/tmp/setup.sh " + terraformConfig.Standalone.RancherChartRepository + " " +
 		terraformConfig.Standalone.Repo + " " + terraformConfig.Standalone.CertManagerVersion + " " +
@@ -51,7 +52,7 @@ func CreateAirgapRancher(file *os.File, newFile *hclwrite.File, rootBody *hclwri
 		cty.StringVal(command),
 	}))
 
-	_, err = file.Write(newFile.Bytes())
+	err = secrets.WriteMainTF(file, newFile)
 	if err != nil {
 		logrus.Infof("Failed to append configurations to main.tf file. Error: %v", err)
 		return nil, err
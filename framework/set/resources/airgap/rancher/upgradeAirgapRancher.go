@@ -10,6 +10,7 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -30,7 +31,7 @@ func UpgradeAirgapRancher(file *os.File, newFile *hclwrite.File, rootBody *hclwr
 		return nil, err
 	}
 
-	_, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, bastionNode, upgradeRancher)
+	_, provisionerBlockBody := rke2.SSHNullResource(newFile, rootBody, terraformConfig, bastionNode, upgradeRancher)
 
 	command := "bash -c '/tmp/upgrade.sh " + terraformConfig.Standalone.UpgradedRancherChartRepository + " " +
 		terraformConfig.Standalone.UpgradedRancherRepo + " " + terraformConfig.Standalone.CertType + " " +
@@ -50,7 +51,7 @@ func UpgradeAirgapRancher(file *os.File, newFile *hclwrite.File, rootBody *hclwr
 		cty.StringVal(command),
 	}))
 
-	_, err = file.Write(newFile.Bytes())
+	err = secrets.WriteMainTF(file, newFile)
 	if err != nil {
 		logrus.Infof("Failed to append configurations to main.tf file. Error: %v", err)
 		return nil, err
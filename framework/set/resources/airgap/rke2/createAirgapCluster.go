@@ -13,6 +13,7 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -57,21 +58,21 @@ func CreateAirgapRKE2Cluster(file *os.File, newFile *hclwrite.File, rootBody *hc
 
 	encodedPEMFile := base64.StdEncoding.EncodeToString([]byte(privateKey))
 
-	_, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, rke2BastionPublicDNS, rke2Bastion)
+	_, provisionerBlockBody := rke2.SSHNullResource(newFile, rootBody, terraformConfig, rke2BastionPublicDNS, rke2Bastion)
 
-	provisionerBlockBody.SetAttributeValue(defaults.Inline, cty.ListVal([]cty.Value{
-		cty.StringVal("echo '" + string(bastionScriptContent) + "' > /tmp/bastion.sh"),
-		cty.StringVal("chmod +x /tmp/bastion.sh"),
-		cty.StringVal("bash -c '/tmp/bastion.sh " + terraformConfig.Standalone.RKE2Version + " " + rke2ServerOnePrivateIP + " " +
-			rke2ServerTwoPrivateIP + " " + rke2ServerThreePrivateIP + " " + terraformConfig.Standalone.OSUser + " " + encodedPEMFile + "'"),
+	provisionerBlockBody.SetAttributeRaw(defaults.Inline, hclwrite.TokensForTuple([]hclwrite.Tokens{
+		hclwrite.TokensForValue(cty.StringVal("echo '" + string(bastionScriptContent) + "' > /tmp/bastion.sh")),
+		hclwrite.TokensForValue(cty.StringVal("chmod +x /tmp/bastion.sh")),
+		secrets.TemplateTokens(newFile, "bash -c '/tmp/bastion.sh "+terraformConfig.Standalone.RKE2Version+" "+rke2ServerOnePrivateIP+" "+
+			rke2ServerTwoPrivateIP+" "+rke2ServerThreePrivateIP+" "+terraformConfig.Standalone.OSUser+" ", secrets.PrivateKeyBase64, encodedPEMFile, "'"),
 	}))
 
 	rke2Token := namegen.AppendRandomString(token)
 
-	createAirgappedRKE2Server(rootBody, terraformConfig, rke2BastionPublicDNS, rke2ServerOnePrivateIP, rke2Token, registryPublicDNS, serverOneScriptContent)
-	addAirgappedRKE2ServerNodes(rootBody, terraformConfig, rke2BastionPublicDNS, rke2ServerOnePrivateIP, rke2ServerTwoPrivateIP, rke2ServerThreePrivateIP, rke2Token, registryPublicDNS, newServersScriptContent)
+	createAirgappedRKE2Server(newFile, rootBody, terraformConfig, rke2BastionPublicDNS, rke2ServerOnePrivateIP, rke2Token, registryPublicDNS, serverOneScriptContent)
+	addAirgappedRKE2ServerNodes(newFile, rootBody, terraformConfig, rke2BastionPublicDNS, rke2ServerOnePrivateIP, rke2ServerTwoPrivateIP, rke2ServerThreePrivateIP, rke2Token, registryPublicDNS, newServersScriptContent)
 
-	_, err = file.Write(newFile.Bytes())
+	err = secrets.WriteMainTF(file, newFile)
 	if err != nil {
 		logrus.Infof("Failed to append configurations to main.tf file. Error: %v", err)
 		return nil, err
@@ -81,9 +82,9 @@ func CreateAirgapRKE2Cluster(file *os.File, newFile *hclwrite.File, rootBody *hc
 }
 
 // createAirgappedRKE2Server is a helper function that will create the RKE2 server.
-func createAirgappedRKE2Server(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, rke2BastionPublicDNS, rke2ServerOnePrivateIP,
+func createAirgappedRKE2Server(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, rke2BastionPublicDNS, rke2ServerOnePrivateIP,
 	rke2Token, registryPublicDNS string, script []byte) {
-	nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, rke2BastionPublicDNS, rke2ServerOne)
+	nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(newFile, rootBody, terraformConfig, rke2BastionPublicDNS, rke2ServerOne)
 
 	command := "bash -c '/tmp/init-server.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
 		rke2ServerOnePrivateIP + " " + rke2Token + " " + registryPublicDNS + " " + terraformConfig.Standalone.RegistryUsername + " " +
@@ -110,14 +111,14 @@ func createAirgappedRKE2Server(rootBody *hclwrite.Body, terraformConfig *config.
 }
 
 // addAirgappedRKE2ServerNodes is a helper function that will add additional RKE2 server nodes to the initial RKE2 airgapped server.
-func addAirgappedRKE2ServerNodes(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, rke2BastionPublicDNS, rke2ServerOnePrivateIP, rke2ServerTwoPrivateIP,
+func addAirgappedRKE2ServerNodes(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, rke2BastionPublicDNS, rke2ServerOnePrivateIP, rke2ServerTwoPrivateIP,
 	rke2ServerThreePrivateIP, rke2Token, registryPublicDNS string, script []byte) {
 	instances := []string{rke2ServerTwoPrivateIP, rke2ServerThreePrivateIP}
 	hosts := []string{rke2ServerTwo, rke2ServerThree}
 
 	for i, instance := range instances {
 		host := hosts[i]
-		nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, rke2BastionPublicDNS, host)
+		nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(newFile, rootBody, terraformConfig, rke2BastionPublicDNS, host)
 
 		command := "bash -c '/tmp/add-servers.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
 			rke2ServerOnePrivateIP + " " + instance + " " + rke2Token + " " + registryPublicDNS + " " + terraformConfig.Standalone.RegistryUsername + " " +
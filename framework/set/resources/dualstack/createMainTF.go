@@ -9,6 +9,7 @@ import (
 	shepherdConfig "github.com/rancher/shepherd/clients/rancher"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/set/backend"
 	"github.com/rancher/tfp-automation/framework/set/resources/dualstack/rke2"
 	tunnel "github.com/rancher/tfp-automation/framework/set/resources/providers"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity"
@@ -41,7 +42,13 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 	tfBlock := rootBody.AppendNewBlock(terraformConst, nil)
 	tfBlockBody := tfBlock.Body()
 
-	var err error
+	err := backend.ValidateBackend(terratestConfig.Backend)
+	if err != nil {
+		return "", err
+	}
+
+	backend.SetBackend(tfBlockBody, terratestConfig.Backend, terraformConfig.ResourcePrefix)
+
 	var nodeBalancerHostname string
 
 	instances := []string{serverOne, serverTwo, serverThree}
@@ -16,7 +16,7 @@ import (
 )
 
 // AddWindowsNodeToImportedCluster is a helper function that will add an additional Windows node to the initial server.
-func AddWindowsNodeToImportedCluster(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
+func AddWindowsNodeToImportedCluster(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
 	serverOnePrivateIP, windowsNodePublicDNS, token string) error {
 	userDir, _ := rancher2.SetKeyPath(keypath.RancherKeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
 
@@ -27,18 +27,18 @@ func AddWindowsNodeToImportedCluster(rootBody *hclwrite.Body, terraformConfig *c
 		return err
 	}
 
-	addImportedWindowsNode(rootBody, terraformConfig, terratestConfig, serverOnePrivateIP, windowsNodePublicDNS, token, serverOneScriptContent)
+	addImportedWindowsNode(newFile, rootBody, terraformConfig, terratestConfig, serverOnePrivateIP, windowsNodePublicDNS, token, serverOneScriptContent)
 
 	return nil
 }
 
 // addImportedWindowsNode is a helper function that will add an additional Windows node to the initial server.
-func addImportedWindowsNode(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
+func addImportedWindowsNode(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
 	serverOnePrivateIP, windowsNodePublicDNS,
 	token string, script []byte) {
 	copyScriptName := terraformConfig.ResourcePrefix + copyScript + windowsServer
 
-	nullResourceBlockBody, provisionerBlockBody := nullresource.CreateImportedWindowsNullResource(rootBody, terraformConfig, terratestConfig, windowsNodePublicDNS, copyScriptName)
+	nullResourceBlockBody, provisionerBlockBody := nullresource.CreateImportedWindowsNullResource(newFile, rootBody, terraformConfig, terratestConfig, windowsNodePublicDNS, copyScriptName)
 	rootBody.AppendNewline()
 
 	dependsOnServer := `[` + defaults.AwsInstance + `.` + terraformConfig.ResourcePrefix + `-windows` + `]`
@@ -71,7 +71,7 @@ func addImportedWindowsNode(rootBody *hclwrite.Body, terraformConfig *config.Ter
 	}
 
 	provisionerBlockBody.SetAttributeValue(defaults.Inline, cty.ListVal(inlineCommands))
-	nullResourceBlockBody, provisionerBlockBody = nullresource.CreateImportedWindowsNullResource(rootBody, terraformConfig, terratestConfig, windowsNodePublicDNS, addWindowsNode)
+	nullResourceBlockBody, provisionerBlockBody = nullresource.CreateImportedWindowsNullResource(newFile, rootBody, terraformConfig, terratestConfig, windowsNodePublicDNS, addWindowsNode)
 
 	version := terraformConfig.Standalone.RKE2Version
 	version += "+rke2r1"
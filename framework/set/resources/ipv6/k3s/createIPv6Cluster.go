@@ -13,6 +13,7 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -57,22 +58,22 @@ func CreateIPv6K3SCluster(file *os.File, newFile *hclwrite.File, rootBody *hclwr
 
 	encodedPEMFile := base64.StdEncoding.EncodeToString([]byte(privateKey))
 
-	_, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, k3sBastionPublicIP, k3sBastion)
+	_, provisionerBlockBody := rke2.SSHNullResource(newFile, rootBody, terraformConfig, k3sBastionPublicIP, k3sBastion)
 
-	provisionerBlockBody.SetAttributeValue(defaults.Inline, cty.ListVal([]cty.Value{
-		cty.StringVal("echo '" + string(bastionScriptContent) + "' > /tmp/bastion.sh"),
-		cty.StringVal("chmod +x /tmp/bastion.sh"),
-		cty.StringVal("bash -c '/tmp/bastion.sh " + terraformConfig.Standalone.K3SVersion + " " + k3sServerOnePrivateIP + " " +
-			k3sServerTwoPrivateIP + " " + k3sServerThreePrivateIP + " " + terraformConfig.Standalone.OSUser + " " + encodedPEMFile + "'"),
+	provisionerBlockBody.SetAttributeRaw(defaults.Inline, hclwrite.TokensForTuple([]hclwrite.Tokens{
+		hclwrite.TokensForValue(cty.StringVal("echo '" + string(bastionScriptContent) + "' > /tmp/bastion.sh")),
+		hclwrite.TokensForValue(cty.StringVal("chmod +x /tmp/bastion.sh")),
+		secrets.TemplateTokens(newFile, "bash -c '/tmp/bastion.sh "+terraformConfig.Standalone.K3SVersion+" "+k3sServerOnePrivateIP+" "+
+			k3sServerTwoPrivateIP+" "+k3sServerThreePrivateIP+" "+terraformConfig.Standalone.OSUser+" ", secrets.PrivateKeyBase64, encodedPEMFile, "'"),
 	}))
 
 	k3sToken := namegen.AppendRandomString(token)
 
-	createIPv6K3SServer(rootBody, terraformConfig, k3sBastionPublicIP, k3sServerOnePublicIP, k3sServerOnePrivateIP, k3sToken, serverOneScriptContent)
-	addIPv6K3SServerNodes(rootBody, terraformConfig, k3sBastionPublicIP, k3sServerOnePublicIP, k3sServerTwoPrivateIP, k3sServerThreePrivateIP, k3sToken,
+	createIPv6K3SServer(newFile, rootBody, terraformConfig, k3sBastionPublicIP, k3sServerOnePublicIP, k3sServerOnePrivateIP, k3sToken, serverOneScriptContent)
+	addIPv6K3SServerNodes(newFile, rootBody, terraformConfig, k3sBastionPublicIP, k3sServerOnePublicIP, k3sServerTwoPrivateIP, k3sServerThreePrivateIP, k3sToken,
 		newServersScriptContent)
 
-	_, err = file.Write(newFile.Bytes())
+	err = secrets.WriteMainTF(file, newFile)
 	if err != nil {
 		logrus.Infof("Failed to append configurations to main.tf file. Error: %v", err)
 		return nil, err
@@ -82,9 +83,9 @@ func CreateIPv6K3SCluster(file *os.File, newFile *hclwrite.File, rootBody *hclwr
 }
 
 // createIPv6K3SServer is a helper function that will create the K3S server.
-func createIPv6K3SServer(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, k3sBastionPublicIP, k3sServerOnePublicIP,
+func createIPv6K3SServer(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, k3sBastionPublicIP, k3sServerOnePublicIP,
 	k3sServerOnePrivateIP, k3sToken string, script []byte) {
-	nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, k3sBastionPublicIP, k3sServerOne)
+	nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(newFile, rootBody, terraformConfig, k3sBastionPublicIP, k3sServerOne)
 
 	command := "bash -c '/tmp/init-server.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
 		k3sServerOnePublicIP + " " + k3sServerOnePrivateIP + " " + terraformConfig.Standalone.RancherHostname + " " +
@@ -109,7 +110,7 @@ func createIPv6K3SServer(rootBody *hclwrite.Body, terraformConfig *config.Terraf
 }
 
 // addIPv6K3SServerNodes is a helper function that will add additional K3S server nodes to the initial K3S IPv6 server.
-func addIPv6K3SServerNodes(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, k3sBastionPublicIP, k3sServerOnePublicIP,
+func addIPv6K3SServerNodes(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, k3sBastionPublicIP, k3sServerOnePublicIP,
 	k3sServerTwoPrivateIP, k3sServerThreePrivateIP, k3sToken string, script []byte) {
 	privateIPInstances := []string{k3sServerTwoPrivateIP, k3sServerThreePrivateIP}
 	hosts := []string{k3sServerTwo, k3sServerThree}
@@ -117,7 +118,7 @@ func addIPv6K3SServerNodes(rootBody *hclwrite.Body, terraformConfig *config.Terr
 	for i, privateInstance := range privateIPInstances {
 		host := hosts[i]
 
-		nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, k3sBastionPublicIP, host)
+		nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(newFile, rootBody, terraformConfig, k3sBastionPublicIP, host)
 
 		command := "bash -c '/tmp/add-servers.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
 			k3sServerOnePublicIP + " " + privateInstance + " " + terraformConfig.Standalone.RancherHostname + " " +
@@ -13,6 +13,7 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -57,22 +58,22 @@ func CreateIPv6RKE2Cluster(file *os.File, newFile *hclwrite.File, rootBody *hclw
 
 	encodedPEMFile := base64.StdEncoding.EncodeToString([]byte(privateKey))
 
-	_, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, rke2BastionPublicIP, rke2Bastion)
+	_, provisionerBlockBody := rke2.SSHNullResource(newFile, rootBody, terraformConfig, rke2BastionPublicIP, rke2Bastion)
 
-	provisionerBlockBody.SetAttributeValue(defaults.Inline, cty.ListVal([]cty.Value{
-		cty.StringVal("echo '" + string(bastionScriptContent) + "' > /tmp/bastion.sh"),
-		cty.StringVal("chmod +x /tmp/bastion.sh"),
-		cty.StringVal("bash -c '/tmp/bastion.sh " + terraformConfig.Standalone.RKE2Version + " " + rke2ServerOnePrivateIP + " " +
-			rke2ServerTwoPrivateIP + " " + rke2ServerThreePrivateIP + " " + terraformConfig.Standalone.OSUser + " " + encodedPEMFile + "'"),
+	provisionerBlockBody.SetAttributeRaw(defaults.Inline, hclwrite.TokensForTuple([]hclwrite.Tokens{
+		hclwrite.TokensForValue(cty.StringVal("echo '" + string(bastionScriptContent) + "' > /tmp/bastion.sh")),
+		hclwrite.TokensForValue(cty.StringVal("chmod +x /tmp/bastion.sh")),
+		secrets.TemplateTokens(newFile, "bash -c '/tmp/bastion.sh "+terraformConfig.Standalone.RKE2Version+" "+rke2ServerOnePrivateIP+" "+
+			rke2ServerTwoPrivateIP+" "+rke2ServerThreePrivateIP+" "+terraformConfig.Standalone.OSUser+" ", secrets.PrivateKeyBase64, encodedPEMFile, "'"),
 	}))
 
 	rke2Token := namegen.AppendRandomString(token)
 
-	createIPv6RKE2Server(rootBody, terraformConfig, rke2BastionPublicIP, rke2ServerOnePublicIP, rke2ServerOnePrivateIP, rke2Token, serverOneScriptContent)
-	addIPv6RKE2ServerNodes(rootBody, terraformConfig, rke2BastionPublicIP, rke2ServerOnePublicIP, rke2ServerTwoPublicIP, rke2ServerThreePublicIP,
+	createIPv6RKE2Server(newFile, rootBody, terraformConfig, rke2BastionPublicIP, rke2ServerOnePublicIP, rke2ServerOnePrivateIP, rke2Token, serverOneScriptContent)
+	addIPv6RKE2ServerNodes(newFile, rootBody, terraformConfig, rke2BastionPublicIP, rke2ServerOnePublicIP, rke2ServerTwoPublicIP, rke2ServerThreePublicIP,
 		rke2ServerTwoPrivateIP, rke2ServerThreePrivateIP, rke2Token, newServersScriptContent)
 
-	_, err = file.Write(newFile.Bytes())
+	err = secrets.WriteMainTF(file, newFile)
 	if err != nil {
 		logrus.Infof("Failed to append configurations to main.tf file. Error: %v", err)
 		return nil, err
@@ -82,9 +83,9 @@ func CreateIPv6RKE2Cluster(file *os.File, newFile *hclwrite.File, rootBody *hclw
 }
 
 // createIPv6RKE2Server is a helper function that will create the RKE2 server.
-func createIPv6RKE2Server(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, rke2BastionPublicIP, rke2ServerOnePublicIP,
+func createIPv6RKE2Server(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, rke2BastionPublicIP, rke2ServerOnePublicIP,
 	rke2ServerOnePrivateIP, rke2Token string, script []byte) {
-	nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, rke2BastionPublicIP, rke2ServerOne)
+	nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(newFile, rootBody, terraformConfig, rke2BastionPublicIP, rke2ServerOne)
 
 	command := "bash -c '/tmp/init-server.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
 		rke2ServerOnePublicIP + " " + rke2ServerOnePrivateIP + " " + terraformConfig.Standalone.RancherHostname + " " +
@@ -109,7 +110,7 @@ func createIPv6RKE2Server(rootBody *hclwrite.Body, terraformConfig *config.Terra
 }
 
 // addIPv6RKE2ServerNodes is a helper function that will add additional RKE2 server nodes to the initial RKE2 IPv6 server.
-func addIPv6RKE2ServerNodes(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, rke2BastionPublicIP, rke2ServerOnePublicIP,
+func addIPv6RKE2ServerNodes(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, rke2BastionPublicIP, rke2ServerOnePublicIP,
 	rke2ServerTwoPublicIP, rke2ServerThreePublicIP, rke2ServerTwoPrivateIP, rke2ServerThreePrivateIP, rke2Token string, script []byte) {
 	privateIPInstances := []string{rke2ServerTwoPrivateIP, rke2ServerThreePrivateIP}
 	publicIPInstances := []string{rke2ServerTwoPublicIP, rke2ServerThreePublicIP}
@@ -119,7 +120,7 @@ func addIPv6RKE2ServerNodes(rootBody *hclwrite.Body, terraformConfig *config.Ter
 		publicIPInstance := publicIPInstances[i]
 		host := hosts[i]
 
-		nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, rke2BastionPublicIP, host)
+		nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(newFile, rootBody, terraformConfig, rke2BastionPublicIP, host)
 
 		command := "bash -c '/tmp/add-servers.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
 			rke2ServerOnePublicIP + " " + publicIPInstance + " " + privateInstance + " " + terraformConfig.Standalone.RancherHostname + " " +
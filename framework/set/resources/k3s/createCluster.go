@@ -12,6 +12,7 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -43,10 +44,10 @@ func CreateK3SCluster(file *os.File, newFile *hclwrite.File, rootBody *hclwrite.
 
 	k3sToken := namegen.AppendRandomString(token)
 
-	CreateK3SServer(rootBody, terraformConfig, k3sServerOnePublicDNS, k3sServerOnePrivateIP, k3sToken, serverOneScriptContent)
-	AddK3SServerNodes(rootBody, terraformConfig, k3sServerOnePrivateIP, k3sServerTwoPublicDNS, k3sServerThreePublicDNS, k3sToken, newServersScriptContent)
+	CreateK3SServer(newFile, rootBody, terraformConfig, k3sServerOnePublicDNS, k3sServerOnePrivateIP, k3sToken, serverOneScriptContent)
+	AddK3SServerNodes(newFile, rootBody, terraformConfig, k3sServerOnePrivateIP, k3sServerTwoPublicDNS, k3sServerThreePublicDNS, k3sToken, newServersScriptContent)
 
-	_, err = file.Write(newFile.Bytes())
+	err = secrets.WriteMainTF(file, newFile)
 	if err != nil {
 		logrus.Infof("Failed to append configurations to main.tf file. Error: %v", err)
 		return nil, err
@@ -56,9 +57,9 @@ func CreateK3SCluster(file *os.File, newFile *hclwrite.File, rootBody *hclwrite.
 }
 
 // CreateK3SServer is a helper function that will create the K3S server.
-func CreateK3SServer(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, k3sServerOnePublicDNS, k3sServerOnePrivateIP,
+func CreateK3SServer(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, k3sServerOnePublicDNS, k3sServerOnePrivateIP,
 	k3sToken string, script []byte) {
-	_, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, k3sServerOnePublicDNS, k3sServerOne)
+	_, provisionerBlockBody := rke2.SSHNullResource(newFile, rootBody, terraformConfig, k3sServerOnePublicDNS, k3sServerOne)
 
 	command := "bash -c '/tmp/init-server.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
 		terraformConfig.Standalone.K3SVersion + " " + k3sServerOnePrivateIP + " " + k3sToken + " " +
@@ -74,14 +75,14 @@ func CreateK3SServer(rootBody *hclwrite.Body, terraformConfig *config.TerraformC
 }
 
 // AddK3SServerNodes is a helper function that will add additional K3s server nodes to the initial K3s server.
-func AddK3SServerNodes(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, k3sServerOnePrivateIP, k3sServerTwoPublicDNS,
+func AddK3SServerNodes(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, k3sServerOnePrivateIP, k3sServerTwoPublicDNS,
 	k3sServerThreePublicDNS, k3sToken string, script []byte) {
 	instances := []string{k3sServerTwoPublicDNS, k3sServerThreePublicDNS}
 	hosts := []string{k3sServerTwo, k3sServerThree}
 
 	for i, instance := range instances {
 		host := hosts[i]
-		nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, instance, host)
+		nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(newFile, rootBody, terraformConfig, instance, host)
 
 		command := "bash -c '/tmp/add-servers.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
 			terraformConfig.Standalone.K3SVersion + " " + k3sServerOnePrivateIP + " " + instance + " " + k3sToken + " " +
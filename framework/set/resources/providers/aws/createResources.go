@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/sirupsen/logrus"
 )
 
@@ -15,7 +16,7 @@ func CreateAWSResources(file *os.File, newFile *hclwrite.File, tfBlockBody, root
 	CreateAWSTerraformProviderBlock(tfBlockBody)
 	rootBody.AppendNewline()
 
-	CreateAWSProviderBlock(rootBody, terraformConfig)
+	CreateAWSProviderBlock(newFile, rootBody, terraformConfig)
 	rootBody.AppendNewline()
 
 	for _, instance := range instances {
@@ -56,7 +57,7 @@ func CreateAWSResources(file *os.File, newFile *hclwrite.File, tfBlockBody, root
 		rootBody.AppendNewline()
 	}
 
-	_, err := file.Write(newFile.Bytes())
+	err := secrets.WriteMainTF(file, newFile)
 	if err != nil {
 		logrus.Infof("Failed to write configurations to main.tf file. Error: %v", err)
 		return nil, err
@@ -71,7 +72,7 @@ func CreateAirgappedAWSResources(file *os.File, newFile *hclwrite.File, tfBlockB
 	CreateAWSTerraformProviderBlock(tfBlockBody)
 	rootBody.AppendNewline()
 
-	CreateAWSProviderBlock(rootBody, terraformConfig)
+	CreateAWSProviderBlock(newFile, rootBody, terraformConfig)
 	rootBody.AppendNewline()
 
 	for _, instance := range instances {
@@ -125,7 +126,7 @@ func CreateAirgappedAWSResources(file *os.File, newFile *hclwrite.File, tfBlockB
 		rootBody.AppendNewline()
 	}
 
-	_, err := file.Write(newFile.Bytes())
+	err := secrets.WriteMainTF(file, newFile)
 	if err != nil {
 		logrus.Infof("Failed to write configurations to main.tf file. Error: %v", err)
 		return nil, err
@@ -140,7 +141,7 @@ func CreateIPv6AWSResources(file *os.File, newFile *hclwrite.File, tfBlockBody,
 	CreateAWSTerraformProviderBlock(tfBlockBody)
 	rootBody.AppendNewline()
 
-	CreateAWSProviderBlock(rootBody, terraformConfig)
+	CreateAWSProviderBlock(newFile, rootBody, terraformConfig)
 	rootBody.AppendNewline()
 
 	for _, instance := range instances {
@@ -179,7 +180,7 @@ func CreateIPv6AWSResources(file *os.File, newFile *hclwrite.File, tfBlockBody,
 		rootBody.AppendNewline()
 	}
 
-	_, err := file.Write(newFile.Bytes())
+	err := secrets.WriteMainTF(file, newFile)
 	if err != nil {
 		logrus.Infof("Failed to write configurations to main.tf file. Error: %v", err)
 		return nil, err
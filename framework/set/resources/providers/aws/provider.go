@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -32,13 +33,13 @@ func CreateAWSTerraformProviderBlock(tfBlockBody *hclwrite.Body) {
 }
 
 // CreateAWSProviderBlock will set up the aws provider block.
-func CreateAWSProviderBlock(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+func CreateAWSProviderBlock(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
 	awsProvBlock := rootBody.AppendNewBlock(defaults.Provider, []string{defaults.Aws})
 	awsProvBlockBody := awsProvBlock.Body()
 
 	awsProvBlockBody.SetAttributeValue(defaults.Region, cty.StringVal(terraformConfig.AWSConfig.Region))
-	awsProvBlockBody.SetAttributeValue(defaults.AccessKey, cty.StringVal(terraformConfig.AWSCredentials.AWSAccessKey))
-	awsProvBlockBody.SetAttributeValue(defaults.SecretKey, cty.StringVal(terraformConfig.AWSCredentials.AWSSecretKey))
+	secrets.SetSensitiveAttribute(newFile, awsProvBlockBody, defaults.AccessKey, secrets.AWSAccessKey, terraformConfig.AWSCredentials.AWSAccessKey)
+	secrets.SetSensitiveAttribute(newFile, awsProvBlockBody, defaults.SecretKey, secrets.AWSSecretKey, terraformConfig.AWSCredentials.AWSSecretKey)
 }
 
 // CreateAWSLocalBlock will set up the local block. Returns the local block.
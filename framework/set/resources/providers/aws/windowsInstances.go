@@ -11,6 +11,7 @@ import (
 	"github.com/rancher/tfp-automation/defaults/modules"
 	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -28,7 +29,7 @@ const (
 )
 
 // CreateWindowsAWSInstances is a function that will set the Windows AWS instances configurations in the main.tf file.
-func CreateWindowsAWSInstances(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
+func CreateWindowsAWSInstances(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
 	hostnamePrefix string) {
 	configBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.AwsInstance, hostnamePrefix + "-windows"})
 	configBlockBody := configBlock.Body()
@@ -79,9 +80,9 @@ func CreateWindowsAWSInstances(rootBody *hclwrite.Body, terraformConfig *config.
 	connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.AWSConfig.WindowsAWSUser))
 
 	if strings.Contains(terraformConfig.Module, clustertypes.WINDOWS) && strings.Contains(terraformConfig.Module, "2019") {
-		connectionBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(terraformConfig.AWSConfig.Windows2019Password))
+		secrets.SetSensitiveAttribute(newFile, connectionBlockBody, defaults.Password, secrets.Windows2019Password, terraformConfig.AWSConfig.Windows2019Password)
 	} else if strings.Contains(terraformConfig.Module, clustertypes.WINDOWS) && strings.Contains(terraformConfig.Module, "2022") {
-		connectionBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(terraformConfig.AWSConfig.Windows2022Password))
+		secrets.SetSensitiveAttribute(newFile, connectionBlockBody, defaults.Password, secrets.Windows2022Password, terraformConfig.AWSConfig.Windows2022Password)
 	}
 
 	connectionBlockBody.SetAttributeValue(defaults.Insecure, cty.BoolVal(true))
@@ -119,7 +120,7 @@ func CreateWindowsAWSInstances(rootBody *hclwrite.Body, terraformConfig *config.
 }
 
 // CreateAirgappedWindowsAWSInstances is a function that will set the Windows AWS instances configurations in the main.tf file.
-func CreateAirgappedWindowsAWSInstances(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, hostnamePrefix string) {
+func CreateAirgappedWindowsAWSInstances(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, hostnamePrefix string) {
 	configBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.AwsInstance, hostnamePrefix})
 	configBlockBody := configBlock.Body()
 
@@ -168,9 +169,9 @@ func CreateAirgappedWindowsAWSInstances(rootBody *hclwrite.Body, terraformConfig
 	connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.AWSConfig.WindowsAWSUser))
 
 	if strings.Contains(terraformConfig.Module, modules.AirgapRKE2Windows2019) {
-		connectionBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(terraformConfig.AWSConfig.Windows2019Password))
+		secrets.SetSensitiveAttribute(newFile, connectionBlockBody, defaults.Password, secrets.Windows2019Password, terraformConfig.AWSConfig.Windows2019Password)
 	} else if strings.Contains(terraformConfig.Module, modules.AirgapRKE2Windows2022) {
-		connectionBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(terraformConfig.AWSConfig.Windows2022Password))
+		secrets.SetSensitiveAttribute(newFile, connectionBlockBody, defaults.Password, secrets.Windows2022Password, terraformConfig.AWSConfig.Windows2022Password)
 	}
 
 	connectionBlockBody.SetAttributeValue(defaults.Insecure, cty.BoolVal(true))
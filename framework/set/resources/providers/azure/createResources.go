@@ -0,0 +1,53 @@
+package azure
+
+import (
+	"os"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
+	"github.com/sirupsen/logrus"
+)
+
+// CreateAzureResources is a helper function that will create the Azure resources needed for the RKE2 cluster.
+func CreateAzureResources(file *os.File, newFile *hclwrite.File, tfBlockBody, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig, instances []string) (*os.File, error) {
+	CreateAzureTerraformProviderBlock(tfBlockBody)
+	rootBody.AppendNewline()
+
+	CreateAzureProviderBlock(newFile, rootBody, terraformConfig)
+	rootBody.AppendNewline()
+
+	CreateAzureNetwork(rootBody, terraformConfig)
+	rootBody.AppendNewline()
+
+	for _, instance := range instances {
+		CreateAzureInstances(rootBody, terraformConfig, terratestConfig, instance)
+		rootBody.AppendNewline()
+	}
+
+	if terraformConfig.Standalone.RancherHostname != "" {
+		CreateAzureLoadBalancer(rootBody, terraformConfig, instances)
+		rootBody.AppendNewline()
+
+		ports := []int64{80, 443, 6443, 9345}
+		for _, port := range ports {
+			CreateAzureLoadBalancerRule(rootBody, port)
+			rootBody.AppendNewline()
+		}
+
+		CreateAzureDNSRecord(rootBody, terraformConfig)
+		rootBody.AppendNewline()
+	}
+
+	CreateAzureLocalBlock(rootBody, terraformConfig)
+	rootBody.AppendNewline()
+
+	err := secrets.WriteMainTF(file, newFile)
+	if err != nil {
+		logrus.Infof("Failed to write configurations to main.tf file. Error: %v", err)
+		return nil, err
+	}
+
+	return file, err
+}
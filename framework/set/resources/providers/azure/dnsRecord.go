@@ -0,0 +1,36 @@
+package azure
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	records  = "records"
+	ttl      = "ttl"
+	zoneName = "zone_name"
+)
+
+// CreateAzureDNSRecord is a function that will set the Azure DNS A record of the Rancher hostname in the main.tf file. The record
+// points to the public IP of the load balancer and is created in the DNS zone of the resource group.
+func CreateAzureDNSRecord(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+	recordBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.AzureDNSARecord, defaults.AzureDNSARecord})
+	recordBlockBody := recordBlock.Body()
+
+	recordName := strings.TrimSuffix(terraformConfig.Standalone.RancherHostname, "."+terraformConfig.AzureConfig.DNSZone)
+
+	recordBlockBody.SetAttributeValue(name, cty.StringVal(recordName))
+	recordBlockBody.SetAttributeValue(zoneName, cty.StringVal(terraformConfig.AzureConfig.DNSZone))
+	recordBlockBody.SetAttributeValue(resourceGroupName, cty.StringVal(terraformConfig.AzureConfig.ResourceGroup))
+	recordBlockBody.SetAttributeValue(ttl, cty.NumberIntVal(300))
+
+	recordsExpression := `[` + defaults.AzurePublicIP + `.` + defaults.AzureLoadBalancer + `.` + defaults.IPAddress + `]`
+	recordBlockBody.SetAttributeRaw(records, hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(recordsExpression)},
+	})
+}
@@ -0,0 +1,231 @@
+package azure
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	adminSSHKey                = "admin_ssh_key"
+	adminUsername              = "admin_username"
+	allocationMethod           = "allocation_method"
+	caching                    = "caching"
+	diskSizeGB                 = "disk_size_gb"
+	ipConfiguration            = "ip_configuration"
+	internal                   = "internal"
+	location                   = "location"
+	networkInterfaceID         = "network_interface_id"
+	networkInterfaceIDs        = "network_interface_ids"
+	networkSecurityGroupID     = "network_security_group_id"
+	offer                      = "offer"
+	osDisk                     = "os_disk"
+	privateIPAddressAllocation = "private_ip_address_allocation"
+	publicIPAddressID          = "public_ip_address_id"
+	publisher                  = "publisher"
+	readWrite                  = "ReadWrite"
+	sku                        = "sku"
+	sourceImageReference       = "source_image_reference"
+	standard                   = "Standard"
+	static                     = "Static"
+	storageAccountType         = "storage_account_type"
+	subnetID                   = "subnet_id"
+	username                   = "username"
+)
+
+// CreateAzureInstances is a function that will set the Azure public IPs, network interfaces and virtual machines configurations in the
+// main.tf file. The image of the virtual machines is given in the publisher:offer:sku:version format of the Azure node driver.
+func CreateAzureInstances(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
+	hostnamePrefix string) {
+	isCustom := strings.Contains(terraformConfig.Module, defaults.Custom)
+	totalNodeCount := terratestConfig.EtcdCount + terratestConfig.ControlPlaneCount + terratestConfig.WorkerCount
+
+	publicIPBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.AzurePublicIP, hostnamePrefix})
+	publicIPBlockBody := publicIPBlock.Body()
+
+	if isCustom {
+		publicIPBlockBody.SetAttributeValue(defaults.Count, cty.NumberIntVal(totalNodeCount))
+	}
+
+	setAzureName(publicIPBlockBody, terraformConfig, hostnamePrefix, isCustom)
+	setAzureLocation(publicIPBlockBody, terraformConfig)
+	publicIPBlockBody.SetAttributeValue(allocationMethod, cty.StringVal(static))
+	publicIPBlockBody.SetAttributeValue(sku, cty.StringVal(standard))
+
+	rootBody.AppendNewline()
+
+	networkInterfaceBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.AzureNetworkInterface, hostnamePrefix})
+	networkInterfaceBlockBody := networkInterfaceBlock.Body()
+
+	if isCustom {
+		networkInterfaceBlockBody.SetAttributeValue(defaults.Count, cty.NumberIntVal(totalNodeCount))
+	}
+
+	setAzureName(networkInterfaceBlockBody, terraformConfig, hostnamePrefix, isCustom)
+	setAzureLocation(networkInterfaceBlockBody, terraformConfig)
+
+	ipConfigurationBlock := networkInterfaceBlockBody.AppendNewBlock(ipConfiguration, nil)
+	ipConfigurationBlockBody := ipConfigurationBlock.Body()
+
+	ipConfigurationBlockBody.SetAttributeValue(name, cty.StringVal(internal))
+	ipConfigurationBlockBody.SetAttributeRaw(subnetID, reference(defaults.Data+"."+defaults.AzureSubnet, terraformConfig.ResourcePrefix, false))
+	ipConfigurationBlockBody.SetAttributeValue(privateIPAddressAllocation, cty.StringVal("Dynamic"))
+	ipConfigurationBlockBody.SetAttributeRaw(publicIPAddressID, reference(defaults.AzurePublicIP, hostnamePrefix, isCustom))
+
+	rootBody.AppendNewline()
+
+	securityGroupBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.AzureNetworkSecurityGroupAssociation, hostnamePrefix})
+	securityGroupBlockBody := securityGroupBlock.Body()
+
+	if isCustom {
+		securityGroupBlockBody.SetAttributeValue(defaults.Count, cty.NumberIntVal(totalNodeCount))
+	}
+
+	securityGroupBlockBody.SetAttributeRaw(networkInterfaceID, reference(defaults.AzureNetworkInterface, hostnamePrefix, isCustom))
+	securityGroupBlockBody.SetAttributeRaw(networkSecurityGroupID, reference(defaults.Data+"."+defaults.AzureNetworkSecurityGroup,
+		terraformConfig.ResourcePrefix, false))
+
+	rootBody.AppendNewline()
+
+	vmBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.AzureLinuxVirtualMachine, hostnamePrefix})
+	vmBlockBody := vmBlock.Body()
+
+	if isCustom {
+		vmBlockBody.SetAttributeValue(defaults.Count, cty.NumberIntVal(totalNodeCount))
+	}
+
+	setAzureName(vmBlockBody, terraformConfig, hostnamePrefix, isCustom)
+	setAzureLocation(vmBlockBody, terraformConfig)
+	vmBlockBody.SetAttributeValue(defaults.Size, cty.StringVal(terraformConfig.AzureConfig.Size))
+	vmBlockBody.SetAttributeValue(adminUsername, cty.StringVal(terraformConfig.AzureConfig.SSHUser))
+
+	networkInterfaceTokens := append(hclwrite.Tokens{{Type: hclsyntax.TokenOBrack, Bytes: []byte(`[`)}},
+		reference(defaults.AzureNetworkInterface, hostnamePrefix, isCustom)...)
+	networkInterfaceTokens = append(networkInterfaceTokens, &hclwrite.Token{Type: hclsyntax.TokenCBrack, Bytes: []byte(`]`)})
+	vmBlockBody.SetAttributeRaw(networkInterfaceIDs, networkInterfaceTokens)
+
+	// The network security group has to be associated before the virtual machine is reachable over SSH.
+	dependsOnExpression := `[` + defaults.AzureNetworkSecurityGroupAssociation + `.` + hostnamePrefix + `]`
+	vmBlockBody.SetAttributeRaw(defaults.DependsOn, hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(dependsOnExpression)},
+	})
+
+	vmBlockBody.AppendNewline()
+
+	sshKeyBlock := vmBlockBody.AppendNewBlock(adminSSHKey, nil)
+	sshKeyBlockBody := sshKeyBlock.Body()
+
+	sshKeyBlockBody.SetAttributeValue(username, cty.StringVal(terraformConfig.AzureConfig.SSHUser))
+
+	publicKeyExpression := defaults.File + `("` + terraformConfig.PrivateKeyPath + `.pub")`
+	sshKeyBlockBody.SetAttributeRaw(defaults.PublicKey, hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(publicKeyExpression)},
+	})
+
+	vmBlockBody.AppendNewline()
+
+	osDiskBlock := vmBlockBody.AppendNewBlock(osDisk, nil)
+	osDiskBlockBody := osDiskBlock.Body()
+
+	osDiskBlockBody.SetAttributeValue(caching, cty.StringVal(readWrite))
+	osDiskBlockBody.SetAttributeValue(storageAccountType, cty.StringVal(terraformConfig.AzureConfig.StorageType))
+
+	diskSize, err := strconv.ParseInt(terraformConfig.AzureConfig.DiskSize, 10, 64)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid disk size value: %s", terraformConfig.AzureConfig.DiskSize))
+	}
+
+	osDiskBlockBody.SetAttributeValue(diskSizeGB, cty.NumberIntVal(diskSize))
+
+	vmBlockBody.AppendNewline()
+
+	image := strings.Split(terraformConfig.AzureConfig.Image, ":")
+	if len(image) != 4 {
+		panic(fmt.Sprintf("Invalid image value: %s", terraformConfig.AzureConfig.Image))
+	}
+
+	imageBlock := vmBlockBody.AppendNewBlock(sourceImageReference, nil)
+	imageBlockBody := imageBlock.Body()
+
+	imageBlockBody.SetAttributeValue(publisher, cty.StringVal(image[0]))
+	imageBlockBody.SetAttributeValue(offer, cty.StringVal(image[1]))
+	imageBlockBody.SetAttributeValue(sku, cty.StringVal(image[2]))
+	imageBlockBody.SetAttributeValue(defaults.Version, cty.StringVal(image[3]))
+
+	vmBlockBody.AppendNewline()
+
+	connectionBlock := vmBlockBody.AppendNewBlock(defaults.Connection, nil)
+	connectionBlockBody := connectionBlock.Body()
+
+	connectionBlockBody.SetAttributeValue(defaults.Type, cty.StringVal(defaults.Ssh))
+	connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.AzureConfig.SSHUser))
+
+	hostExpression := defaults.Self + "." + defaults.PublicIPAddress
+	host := hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(hostExpression)},
+	}
+
+	connectionBlockBody.SetAttributeRaw(defaults.Host, host)
+
+	keyPathExpression := defaults.File + `("` + terraformConfig.PrivateKeyPath + `")`
+	keyPath := hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(keyPathExpression)},
+	}
+
+	connectionBlockBody.SetAttributeRaw(defaults.PrivateKey, keyPath)
+
+	vmBlockBody.AppendNewline()
+
+	provisionerBlock := vmBlockBody.AppendNewBlock(defaults.Provisioner, []string{defaults.RemoteExec})
+	provisionerBlockBody := provisionerBlock.Body()
+
+	provisionerBlockBody.SetAttributeValue(defaults.Inline, cty.ListVal([]cty.Value{
+		cty.StringVal("echo Connected!!!"),
+	}))
+}
+
+// setAzureName is a helper function that will set the name of an Azure resource. Azure does not allow underscores in the host
+// names of virtual machines, so they are replaced with hyphens.
+func setAzureName(blockBody *hclwrite.Body, terraformConfig *config.TerraformConfig, hostnamePrefix string, isCustom bool) {
+	resourceName := strings.ReplaceAll(terraformConfig.ResourcePrefix+"-"+hostnamePrefix, "_", "-")
+
+	if isCustom {
+		nameExpression := fmt.Sprintf(`"%s-${%s.%s}"`, resourceName, defaults.Count, defaults.Index)
+		blockBody.SetAttributeRaw(name, hclwrite.Tokens{
+			{Type: hclsyntax.TokenIdent, Bytes: []byte(nameExpression)},
+		})
+
+		return
+	}
+
+	blockBody.SetAttributeValue(name, cty.StringVal(resourceName))
+}
+
+// setAzureLocation is a helper function that will set the resource group and location of an Azure resource to the ones of the
+// existing resource group.
+func setAzureLocation(blockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+	blockBody.SetAttributeValue(resourceGroupName, cty.StringVal(terraformConfig.AzureConfig.ResourceGroup))
+
+	locationExpression := defaults.Data + "." + defaults.AzureResourceGroup + "." + terraformConfig.ResourcePrefix + "." + location
+	blockBody.SetAttributeRaw(location, hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(locationExpression)},
+	})
+}
+
+// reference is a helper function that will return the id of an Azure resource, which is indexed by the count of custom modules.
+func reference(resourceType, label string, isCustom bool) hclwrite.Tokens {
+	expression := resourceType + "." + label + ".id"
+	if isCustom {
+		expression = resourceType + "." + label + "[" + defaults.Count + "." + defaults.Index + "].id"
+	}
+
+	return hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(expression)},
+	}
+}
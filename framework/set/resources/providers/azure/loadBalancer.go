@@ -0,0 +1,105 @@
+package azure
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	backendAddressPoolID        = "backend_address_pool_id"
+	backendAddressPoolIDs       = "backend_address_pool_ids"
+	backendPort                 = "backend_port"
+	frontend                    = "frontend"
+	frontendIPConfiguration     = "frontend_ip_configuration"
+	frontendIPConfigurationName = "frontend_ip_configuration_name"
+	frontendPort                = "frontend_port"
+	ipConfigurationName         = "ip_configuration_name"
+	loadBalancerID              = "loadbalancer_id"
+	probeID                     = "probe_id"
+	protocol                    = "protocol"
+	tcp                         = "Tcp"
+)
+
+// CreateAzureLoadBalancer is a function that will set the Azure load balancer, its public IP and its backend address pool of the
+// given instances in the main.tf file.
+func CreateAzureLoadBalancer(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, instances []string) {
+	publicIPBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.AzurePublicIP, defaults.AzureLoadBalancer})
+	publicIPBlockBody := publicIPBlock.Body()
+
+	publicIPBlockBody.SetAttributeValue(name, cty.StringVal(terraformConfig.ResourcePrefix+"-lb"))
+	setAzureLocation(publicIPBlockBody, terraformConfig)
+	publicIPBlockBody.SetAttributeValue(allocationMethod, cty.StringVal(static))
+	publicIPBlockBody.SetAttributeValue(sku, cty.StringVal(standard))
+
+	rootBody.AppendNewline()
+
+	loadBalancerBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.AzureLoadBalancer, defaults.AzureLoadBalancer})
+	loadBalancerBlockBody := loadBalancerBlock.Body()
+
+	loadBalancerBlockBody.SetAttributeValue(name, cty.StringVal(terraformConfig.ResourcePrefix+"-lb"))
+	setAzureLocation(loadBalancerBlockBody, terraformConfig)
+	loadBalancerBlockBody.SetAttributeValue(sku, cty.StringVal(standard))
+
+	frontendBlock := loadBalancerBlockBody.AppendNewBlock(frontendIPConfiguration, nil)
+	frontendBlockBody := frontendBlock.Body()
+
+	frontendBlockBody.SetAttributeValue(name, cty.StringVal(frontend))
+	frontendBlockBody.SetAttributeRaw(publicIPAddressID, reference(defaults.AzurePublicIP, defaults.AzureLoadBalancer, false))
+
+	rootBody.AppendNewline()
+
+	backendPoolBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.AzureLoadBalancerBackendPool, defaults.AzureLoadBalancer})
+	backendPoolBlockBody := backendPoolBlock.Body()
+
+	backendPoolBlockBody.SetAttributeValue(name, cty.StringVal(terraformConfig.ResourcePrefix+"-pool"))
+	backendPoolBlockBody.SetAttributeRaw(loadBalancerID, reference(defaults.AzureLoadBalancer, defaults.AzureLoadBalancer, false))
+
+	for _, instance := range instances {
+		rootBody.AppendNewline()
+
+		associationBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.AzureLoadBalancerPoolAssociation, instance})
+		associationBlockBody := associationBlock.Body()
+
+		associationBlockBody.SetAttributeRaw(networkInterfaceID, reference(defaults.AzureNetworkInterface, instance, false))
+		associationBlockBody.SetAttributeValue(ipConfigurationName, cty.StringVal(internal))
+		associationBlockBody.SetAttributeRaw(backendAddressPoolID, reference(defaults.AzureLoadBalancerBackendPool, defaults.AzureLoadBalancer, false))
+	}
+}
+
+// CreateAzureLoadBalancerRule is a function that will set the Azure load balancer probe and rule of the given port in the main.tf file.
+func CreateAzureLoadBalancerRule(rootBody *hclwrite.Body, port int64) {
+	portName := tcp + "-" + strconv.FormatInt(port, 10)
+	label := defaults.AzureLoadBalancer + "_" + strconv.FormatInt(port, 10)
+
+	probeBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.AzureLoadBalancerProbe, label})
+	probeBlockBody := probeBlock.Body()
+
+	probeBlockBody.SetAttributeValue(name, cty.StringVal(portName))
+	probeBlockBody.SetAttributeRaw(loadBalancerID, reference(defaults.AzureLoadBalancer, defaults.AzureLoadBalancer, false))
+	probeBlockBody.SetAttributeValue(protocol, cty.StringVal(tcp))
+	probeBlockBody.SetAttributeValue(defaults.Port, cty.NumberIntVal(port))
+
+	rootBody.AppendNewline()
+
+	ruleBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.AzureLoadBalancerRule, label})
+	ruleBlockBody := ruleBlock.Body()
+
+	ruleBlockBody.SetAttributeValue(name, cty.StringVal(portName))
+	ruleBlockBody.SetAttributeRaw(loadBalancerID, reference(defaults.AzureLoadBalancer, defaults.AzureLoadBalancer, false))
+	ruleBlockBody.SetAttributeValue(protocol, cty.StringVal(tcp))
+	ruleBlockBody.SetAttributeValue(frontendPort, cty.NumberIntVal(port))
+	ruleBlockBody.SetAttributeValue(backendPort, cty.NumberIntVal(port))
+	ruleBlockBody.SetAttributeValue(frontendIPConfigurationName, cty.StringVal(frontend))
+
+	backendPoolExpression := `[` + defaults.AzureLoadBalancerBackendPool + `.` + defaults.AzureLoadBalancer + `.id]`
+	ruleBlockBody.SetAttributeRaw(backendAddressPoolIDs, hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(backendPoolExpression)},
+	})
+
+	ruleBlockBody.SetAttributeRaw(probeID, reference(defaults.AzureLoadBalancerProbe, label, false))
+}
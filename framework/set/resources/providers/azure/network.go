@@ -0,0 +1,40 @@
+package azure
+
+import (
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	name               = "name"
+	resourceGroupName  = "resource_group_name"
+	virtualNetworkName = "virtual_network_name"
+)
+
+// CreateAzureNetwork is a function that will set the existing Azure resource group, subnet and network security group that the
+// virtual machines are created in as data sources in the main.tf file.
+func CreateAzureNetwork(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+	resourceGroupBlock := rootBody.AppendNewBlock(defaults.Data, []string{defaults.AzureResourceGroup, terraformConfig.ResourcePrefix})
+	resourceGroupBlockBody := resourceGroupBlock.Body()
+
+	resourceGroupBlockBody.SetAttributeValue(name, cty.StringVal(terraformConfig.AzureConfig.ResourceGroup))
+
+	rootBody.AppendNewline()
+
+	subnetBlock := rootBody.AppendNewBlock(defaults.Data, []string{defaults.AzureSubnet, terraformConfig.ResourcePrefix})
+	subnetBlockBody := subnetBlock.Body()
+
+	subnetBlockBody.SetAttributeValue(name, cty.StringVal(terraformConfig.AzureConfig.Subnet))
+	subnetBlockBody.SetAttributeValue(virtualNetworkName, cty.StringVal(terraformConfig.AzureConfig.Vnet))
+	subnetBlockBody.SetAttributeValue(resourceGroupName, cty.StringVal(terraformConfig.AzureConfig.ResourceGroup))
+
+	rootBody.AppendNewline()
+
+	securityGroupBlock := rootBody.AppendNewBlock(defaults.Data, []string{defaults.AzureNetworkSecurityGroup, terraformConfig.ResourcePrefix})
+	securityGroupBlockBody := securityGroupBlock.Body()
+
+	securityGroupBlockBody.SetAttributeValue(name, cty.StringVal(terraformConfig.AzureConfig.NSG))
+	securityGroupBlockBody.SetAttributeValue(resourceGroupName, cty.StringVal(terraformConfig.AzureConfig.ResourceGroup))
+}
@@ -0,0 +1,73 @@
+package azure
+
+import (
+	"os"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/azure"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	features          = "features"
+	locals            = "locals"
+	requiredProviders = "required_providers"
+	instanceIDs       = "instance_ids"
+	serverOne         = "server1"
+	serverTwo         = "server2"
+	serverThree       = "server3"
+)
+
+// CreateAzureTerraformProviderBlock will up the terraform block with the required azurerm provider.
+func CreateAzureTerraformProviderBlock(tfBlockBody *hclwrite.Body) {
+	cloudProviderVersion := os.Getenv("CLOUD_PROVIDER_VERSION")
+
+	reqProvsBlock := tfBlockBody.AppendNewBlock(requiredProviders, nil)
+	reqProvsBlockBody := reqProvsBlock.Body()
+
+	reqProvsBlockBody.SetAttributeValue(defaults.Azurerm, cty.ObjectVal(map[string]cty.Value{
+		defaults.Source:  cty.StringVal(defaults.AzurermSource),
+		defaults.Version: cty.StringVal(cloudProviderVersion),
+	}))
+}
+
+// CreateAzureProviderBlock will set up the azurerm provider block.
+func CreateAzureProviderBlock(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+	azureProvBlock := rootBody.AppendNewBlock(defaults.Provider, []string{defaults.Azurerm})
+	azureProvBlockBody := azureProvBlock.Body()
+
+	azureProvBlockBody.AppendNewBlock(features, nil)
+
+	azureProvBlockBody.SetAttributeValue(azure.ClientID, cty.StringVal(terraformConfig.AzureCredentials.ClientID))
+	secrets.SetSensitiveAttribute(newFile, azureProvBlockBody, azure.ClientSecret, secrets.AzureClientSecret, terraformConfig.AzureCredentials.ClientSecret)
+	azureProvBlockBody.SetAttributeValue(azure.SubscriptionID, cty.StringVal(terraformConfig.AzureCredentials.SubscriptionID))
+	azureProvBlockBody.SetAttributeValue(azure.TenantID, cty.StringVal(terraformConfig.AzureCredentials.TenantID))
+}
+
+// CreateAzureLocalBlock will set up the local block. Returns the local block.
+func CreateAzureLocalBlock(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+	localBlock := rootBody.AppendNewBlock(locals, nil)
+	localBlockBody := localBlock.Body()
+
+	instanceIds := map[string]any{
+		serverOne:   defaults.AzureLinuxVirtualMachine + "." + serverOne + ".id",
+		serverTwo:   defaults.AzureLinuxVirtualMachine + "." + serverTwo + ".id",
+		serverThree: defaults.AzureLinuxVirtualMachine + "." + serverThree + ".id",
+	}
+
+	instanceIdsBlock := localBlockBody.AppendNewBlock(instanceIDs+" =", nil)
+	instanceIdsBlockBody := instanceIdsBlock.Body()
+
+	for key, value := range instanceIds {
+		expression := value.(string)
+		instanceValues := hclwrite.Tokens{
+			{Type: hclsyntax.TokenIdent, Bytes: []byte(expression)},
+		}
+
+		instanceIdsBlockBody.SetAttributeRaw(key, instanceValues)
+	}
+}
@@ -0,0 +1,50 @@
+package google
+
+import (
+	"os"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
+	"github.com/sirupsen/logrus"
+)
+
+// CreateGoogleResources is a helper function that will create the Google resources needed for the RKE2 cluster.
+func CreateGoogleResources(file *os.File, newFile *hclwrite.File, tfBlockBody, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig, instances []string) (*os.File, error) {
+	CreateGoogleTerraformProviderBlock(tfBlockBody)
+	rootBody.AppendNewline()
+
+	CreateGoogleProviderBlock(newFile, rootBody, terraformConfig)
+	rootBody.AppendNewline()
+
+	for _, instance := range instances {
+		CreateGoogleInstances(rootBody, terraformConfig, terratestConfig, instance)
+		rootBody.AppendNewline()
+	}
+
+	if terraformConfig.Standalone.RancherHostname != "" {
+		CreateGoogleLoadBalancer(rootBody, terraformConfig, instances)
+		rootBody.AppendNewline()
+
+		ports := []int64{80, 443, 6443, 9345}
+		for _, port := range ports {
+			CreateGoogleForwardingRule(rootBody, terraformConfig, port)
+			rootBody.AppendNewline()
+		}
+
+		CreateGoogleDNSRecord(rootBody, terraformConfig)
+		rootBody.AppendNewline()
+	}
+
+	CreateGoogleLocalBlock(rootBody)
+	rootBody.AppendNewline()
+
+	err := secrets.WriteMainTF(file, newFile)
+	if err != nil {
+		logrus.Infof("Failed to write configurations to main.tf file. Error: %v", err)
+		return nil, err
+	}
+
+	return file, err
+}
@@ -0,0 +1,34 @@
+package google
+
+import (
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	managedZone = "managed_zone"
+	recordA     = "A"
+	rrdatas     = "rrdatas"
+	ttl         = "ttl"
+)
+
+// CreateGoogleDNSRecord is a function that will set the Google Cloud DNS A record of the Rancher hostname in the main.tf file. The
+// record points to the static address of the load balancer and is created in the given managed zone.
+func CreateGoogleDNSRecord(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+	recordBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.GoogleDNSRecordSet, defaults.GoogleDNSRecordSet})
+	recordBlockBody := recordBlock.Body()
+
+	// Cloud DNS expects fully qualified record names, ending with a dot.
+	recordBlockBody.SetAttributeValue(name, cty.StringVal(terraformConfig.Standalone.RancherHostname+"."))
+	recordBlockBody.SetAttributeValue(managedZone, cty.StringVal(terraformConfig.GoogleConfig.ManagedZone))
+	recordBlockBody.SetAttributeValue(defaults.Type, cty.StringVal(recordA))
+	recordBlockBody.SetAttributeValue(ttl, cty.NumberIntVal(300))
+
+	rrdatasExpression := `[` + defaults.GoogleComputeAddress + `.` + defaults.GoogleComputeAddress + `.` + address + `]`
+	recordBlockBody.SetAttributeRaw(rrdatas, hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(rrdatasExpression)},
+	})
+}
@@ -0,0 +1,142 @@
+package google
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/google"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	accessConfig     = "access_config"
+	bootDisk         = "boot_disk"
+	image            = "image"
+	initializeParams = "initialize_params"
+	metadata         = "metadata"
+	name             = "name"
+	networkInterface = "network_interface"
+	sshKeys          = "ssh-keys"
+	subnetwork       = "subnetwork"
+)
+
+// CreateGoogleInstances is a function that will set the Google compute instances configurations in the main.tf file. The instances
+// get an ephemeral public IP and the public key of the private key path in their SSH keys metadata.
+func CreateGoogleInstances(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
+	hostnamePrefix string) {
+	isCustom := strings.Contains(terraformConfig.Module, defaults.Custom)
+	totalNodeCount := terratestConfig.EtcdCount + terratestConfig.ControlPlaneCount + terratestConfig.WorkerCount
+
+	instanceBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.GoogleComputeInstance, hostnamePrefix})
+	instanceBlockBody := instanceBlock.Body()
+
+	if isCustom {
+		instanceBlockBody.SetAttributeValue(defaults.Count, cty.NumberIntVal(totalNodeCount))
+	}
+
+	setGoogleName(instanceBlockBody, terraformConfig, hostnamePrefix, isCustom)
+	instanceBlockBody.SetAttributeValue(google.MachineType, cty.StringVal(terraformConfig.GoogleConfig.MachineType))
+	instanceBlockBody.SetAttributeValue(google.Zone, cty.StringVal(terraformConfig.GoogleConfig.Zone))
+
+	if len(terraformConfig.GoogleConfig.Tags) > 0 {
+		tags := make([]cty.Value, len(terraformConfig.GoogleConfig.Tags))
+		for i, tag := range terraformConfig.GoogleConfig.Tags {
+			tags[i] = cty.StringVal(tag)
+		}
+
+		instanceBlockBody.SetAttributeValue(google.Tags, cty.ListVal(tags))
+	}
+
+	publicKeyExpression := fmt.Sprintf(`{ %s = "%s:${%s("%s.pub")}" }`, sshKeys, terraformConfig.GoogleConfig.Username, defaults.File,
+		terraformConfig.PrivateKeyPath)
+	instanceBlockBody.SetAttributeRaw(metadata, hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(publicKeyExpression)},
+	})
+
+	instanceBlockBody.AppendNewline()
+
+	bootDiskBlock := instanceBlockBody.AppendNewBlock(bootDisk, nil)
+	bootDiskBlockBody := bootDiskBlock.Body()
+
+	initializeParamsBlock := bootDiskBlockBody.AppendNewBlock(initializeParams, nil)
+	initializeParamsBlockBody := initializeParamsBlock.Body()
+
+	initializeParamsBlockBody.SetAttributeValue(image, cty.StringVal(terraformConfig.GoogleConfig.MachineImage))
+
+	diskSize, err := strconv.ParseInt(terraformConfig.GoogleConfig.DiskSize, 10, 64)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid disk size value: %s", terraformConfig.GoogleConfig.DiskSize))
+	}
+
+	initializeParamsBlockBody.SetAttributeValue(defaults.Size, cty.NumberIntVal(diskSize))
+
+	if terraformConfig.GoogleConfig.DiskType != "" {
+		initializeParamsBlockBody.SetAttributeValue(defaults.Type, cty.StringVal(terraformConfig.GoogleConfig.DiskType))
+	}
+
+	instanceBlockBody.AppendNewline()
+
+	networkInterfaceBlock := instanceBlockBody.AppendNewBlock(networkInterface, nil)
+	networkInterfaceBlockBody := networkInterfaceBlock.Body()
+
+	networkInterfaceBlockBody.SetAttributeValue(google.Network, cty.StringVal(terraformConfig.GoogleConfig.Network))
+
+	if terraformConfig.GoogleConfig.Subnetwork != "" {
+		networkInterfaceBlockBody.SetAttributeValue(subnetwork, cty.StringVal(terraformConfig.GoogleConfig.Subnetwork))
+	}
+
+	networkInterfaceBlockBody.AppendNewBlock(accessConfig, nil)
+
+	instanceBlockBody.AppendNewline()
+
+	connectionBlock := instanceBlockBody.AppendNewBlock(defaults.Connection, nil)
+	connectionBlockBody := connectionBlock.Body()
+
+	connectionBlockBody.SetAttributeValue(defaults.Type, cty.StringVal(defaults.Ssh))
+	connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.GoogleConfig.Username))
+
+	hostExpression := defaults.Self + "." + defaults.GoogleNatIP
+	host := hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(hostExpression)},
+	}
+
+	connectionBlockBody.SetAttributeRaw(defaults.Host, host)
+
+	keyPathExpression := defaults.File + `("` + terraformConfig.PrivateKeyPath + `")`
+	keyPath := hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(keyPathExpression)},
+	}
+
+	connectionBlockBody.SetAttributeRaw(defaults.PrivateKey, keyPath)
+
+	instanceBlockBody.AppendNewline()
+
+	provisionerBlock := instanceBlockBody.AppendNewBlock(defaults.Provisioner, []string{defaults.RemoteExec})
+	provisionerBlockBody := provisionerBlock.Body()
+
+	provisionerBlockBody.SetAttributeValue(defaults.Inline, cty.ListVal([]cty.Value{
+		cty.StringVal("echo Connected!!!"),
+	}))
+}
+
+// setGoogleName is a helper function that will set the name of a Google resource. Google only allows lowercase letters, numbers
+// and hyphens in resource names, so underscores are replaced with hyphens.
+func setGoogleName(blockBody *hclwrite.Body, terraformConfig *config.TerraformConfig, hostnamePrefix string, isCustom bool) {
+	resourceName := strings.ToLower(strings.ReplaceAll(terraformConfig.ResourcePrefix+"-"+hostnamePrefix, "_", "-"))
+
+	if isCustom {
+		nameExpression := fmt.Sprintf(`"%s-${%s.%s}"`, resourceName, defaults.Count, defaults.Index)
+		blockBody.SetAttributeRaw(name, hclwrite.Tokens{
+			{Type: hclsyntax.TokenIdent, Bytes: []byte(nameExpression)},
+		})
+
+		return
+	}
+
+	blockBody.SetAttributeValue(name, cty.StringVal(resourceName))
+}
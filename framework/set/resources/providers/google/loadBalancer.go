@@ -0,0 +1,75 @@
+package google
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	address    = "address"
+	instances  = "instances"
+	ipAddress  = "ip_address"
+	ipProtocol = "ip_protocol"
+	portRange  = "port_range"
+	selfLink   = "self_link"
+	target     = "target"
+	tcp        = "TCP"
+)
+
+// CreateGoogleLoadBalancer is a function that will set the Google static address and the target pool of the given instances in
+// the main.tf file. The forwarding rules of each port share the static address.
+func CreateGoogleLoadBalancer(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, instanceNames []string) {
+	resourceName := strings.ToLower(strings.ReplaceAll(terraformConfig.ResourcePrefix, "_", "-"))
+
+	addressBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.GoogleComputeAddress, defaults.GoogleComputeAddress})
+	addressBlockBody := addressBlock.Body()
+
+	addressBlockBody.SetAttributeValue(name, cty.StringVal(resourceName+"-lb"))
+
+	rootBody.AppendNewline()
+
+	targetPoolBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.GoogleComputeTargetPool, defaults.GoogleComputeTargetPool})
+	targetPoolBlockBody := targetPoolBlock.Body()
+
+	targetPoolBlockBody.SetAttributeValue(name, cty.StringVal(resourceName+"-pool"))
+
+	instanceLinks := make([]string, len(instanceNames))
+	for i, instance := range instanceNames {
+		instanceLinks[i] = defaults.GoogleComputeInstance + "." + instance + "." + selfLink
+	}
+
+	instancesExpression := `[` + strings.Join(instanceLinks, ", ") + `]`
+	targetPoolBlockBody.SetAttributeRaw(instances, hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(instancesExpression)},
+	})
+}
+
+// CreateGoogleForwardingRule is a function that will set the Google forwarding rule of the given port in the main.tf file.
+func CreateGoogleForwardingRule(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, port int64) {
+	resourceName := strings.ToLower(strings.ReplaceAll(terraformConfig.ResourcePrefix, "_", "-"))
+	label := defaults.GoogleComputeForwardingRule + "_" + strconv.FormatInt(port, 10)
+
+	ruleBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.GoogleComputeForwardingRule, label})
+	ruleBlockBody := ruleBlock.Body()
+
+	ruleBlockBody.SetAttributeValue(name, cty.StringVal(resourceName+"-"+strconv.FormatInt(port, 10)))
+
+	targetExpression := defaults.GoogleComputeTargetPool + "." + defaults.GoogleComputeTargetPool + ".id"
+	ruleBlockBody.SetAttributeRaw(target, hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(targetExpression)},
+	})
+
+	addressExpression := defaults.GoogleComputeAddress + "." + defaults.GoogleComputeAddress + "." + address
+	ruleBlockBody.SetAttributeRaw(ipAddress, hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(addressExpression)},
+	})
+
+	ruleBlockBody.SetAttributeValue(ipProtocol, cty.StringVal(tcp))
+	ruleBlockBody.SetAttributeValue(portRange, cty.StringVal(strconv.FormatInt(port, 10)))
+}
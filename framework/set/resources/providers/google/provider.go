@@ -0,0 +1,82 @@
+package google
+
+import (
+	"os"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/google"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	credentials       = "credentials"
+	locals            = "locals"
+	requiredProviders = "required_providers"
+	instanceIDs       = "instance_ids"
+	serverOne         = "server1"
+	serverTwo         = "server2"
+	serverThree       = "server3"
+)
+
+// CreateGoogleTerraformProviderBlock will up the terraform block with the required google provider.
+func CreateGoogleTerraformProviderBlock(tfBlockBody *hclwrite.Body) {
+	cloudProviderVersion := os.Getenv("CLOUD_PROVIDER_VERSION")
+
+	reqProvsBlock := tfBlockBody.AppendNewBlock(requiredProviders, nil)
+	reqProvsBlockBody := reqProvsBlock.Body()
+
+	reqProvsBlockBody.SetAttributeValue(defaults.Google, cty.ObjectVal(map[string]cty.Value{
+		defaults.Source:  cty.StringVal(defaults.GoogleSource),
+		defaults.Version: cty.StringVal(cloudProviderVersion),
+	}))
+}
+
+// CreateGoogleProviderBlock will set up the google provider block.
+func CreateGoogleProviderBlock(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+	googleProvBlock := rootBody.AppendNewBlock(defaults.Provider, []string{defaults.Google})
+	googleProvBlockBody := googleProvBlock.Body()
+
+	secrets.SetSensitiveAttribute(newFile, googleProvBlockBody, credentials, secrets.GoogleAuthEncodedJSON, terraformConfig.GoogleCredentials.AuthEncodedJSON)
+	googleProvBlockBody.SetAttributeValue(google.Project, cty.StringVal(terraformConfig.GoogleConfig.ProjectID))
+	googleProvBlockBody.SetAttributeValue(defaults.Region, cty.StringVal(zoneRegion(terraformConfig.GoogleConfig.Zone)))
+	googleProvBlockBody.SetAttributeValue(google.Zone, cty.StringVal(terraformConfig.GoogleConfig.Zone))
+}
+
+// CreateGoogleLocalBlock will set up the local block. Returns the local block.
+func CreateGoogleLocalBlock(rootBody *hclwrite.Body) {
+	localBlock := rootBody.AppendNewBlock(locals, nil)
+	localBlockBody := localBlock.Body()
+
+	instanceIds := map[string]any{
+		serverOne:   defaults.GoogleComputeInstance + "." + serverOne + ".id",
+		serverTwo:   defaults.GoogleComputeInstance + "." + serverTwo + ".id",
+		serverThree: defaults.GoogleComputeInstance + "." + serverThree + ".id",
+	}
+
+	instanceIdsBlock := localBlockBody.AppendNewBlock(instanceIDs+" =", nil)
+	instanceIdsBlockBody := instanceIdsBlock.Body()
+
+	for key, value := range instanceIds {
+		expression := value.(string)
+		instanceValues := hclwrite.Tokens{
+			{Type: hclsyntax.TokenIdent, Bytes: []byte(expression)},
+		}
+
+		instanceIdsBlockBody.SetAttributeRaw(key, instanceValues)
+	}
+}
+
+// zoneRegion is a helper function that will return the region of a Google zone, such as us-central1 for us-central1-c.
+func zoneRegion(zone string) string {
+	index := strings.LastIndex(zone, "-")
+	if index < 0 {
+		return zone
+	}
+
+	return zone[:index]
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/sirupsen/logrus"
 )
 
@@ -41,7 +42,7 @@ func CreateHarvesterResources(file *os.File, newFile *hclwrite.File, tfBlockBody
 		return nil, err
 	}
 
-	_, err = file.Write(newFile.Bytes())
+	err = secrets.WriteMainTF(file, newFile)
 	if err != nil {
 		logrus.Infof("Failed to write configurations to main.tf file. Error: %v", err)
 		return nil, err
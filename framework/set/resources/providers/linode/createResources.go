@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/sirupsen/logrus"
 )
 
@@ -14,7 +15,7 @@ func CreateLinodeResources(file *os.File, newFile *hclwrite.File, tfBlockBody, r
 	CreateLinodeTerraformProviderBlock(tfBlockBody)
 	rootBody.AppendNewline()
 
-	CreateLinodeProviderBlock(rootBody, terraformConfig)
+	CreateLinodeProviderBlock(newFile, rootBody, terraformConfig)
 	rootBody.AppendNewline()
 
 	if terraformConfig.Standalone.RancherHostname != "" {
@@ -32,14 +33,14 @@ func CreateLinodeResources(file *os.File, newFile *hclwrite.File, tfBlockBody, r
 	}
 
 	for _, instance := range instances {
-		CreateLinodeInstances(rootBody, terraformConfig, terratestConfig, instance)
+		CreateLinodeInstances(newFile, rootBody, terraformConfig, terratestConfig, instance)
 		rootBody.AppendNewline()
 	}
 
 	CreateLinodeLocalBlock(rootBody, terraformConfig)
 	rootBody.AppendNewline()
 
-	_, err := file.Write(newFile.Bytes())
+	err := secrets.WriteMainTF(file, newFile)
 	if err != nil {
 		logrus.Infof("Failed to write configurations to main.tf file. Error: %v", err)
 		return nil, err
@@ -9,11 +9,12 @@ import (
 	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/linode"
 	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/zclconf/go-cty/cty"
 )
 
 // CreateLinodeInstances is a function that will set the Linode instances configurations in the main.tf file.
-func CreateLinodeInstances(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
+func CreateLinodeInstances(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
 	hostnamePrefix string) {
 	configBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.LinodeInstance, hostnamePrefix})
 	configBlockBody := configBlock.Body()
@@ -26,7 +27,7 @@ func CreateLinodeInstances(rootBody *hclwrite.Body, terraformConfig *config.Terr
 	configBlockBody.SetAttributeValue(linode.Image, cty.StringVal(terraformConfig.LinodeConfig.LinodeImage))
 	configBlockBody.SetAttributeValue(linode.Region, cty.StringVal(terraformConfig.LinodeConfig.Region))
 	configBlockBody.SetAttributeValue(linode.Type, cty.StringVal(terraformConfig.LinodeConfig.Type))
-	configBlockBody.SetAttributeValue(linode.RootPass, cty.StringVal(terraformConfig.LinodeConfig.LinodeRootPass))
+	secrets.SetSensitiveAttribute(newFile, configBlockBody, linode.RootPass, secrets.LinodeRootPass, terraformConfig.LinodeConfig.LinodeRootPass)
 	configBlockBody.SetAttributeValue(linode.SwapSize, cty.NumberIntVal(terraformConfig.LinodeConfig.SwapSize))
 	configBlockBody.SetAttributeValue(linode.PrivateIP, cty.BoolVal(terraformConfig.LinodeConfig.PrivateIP))
 	configBlockBody.SetAttributeValue(linode.Label, cty.StringVal(terraformConfig.ResourcePrefix+"-"+hostnamePrefix))
@@ -41,7 +42,7 @@ func CreateLinodeInstances(rootBody *hclwrite.Body, terraformConfig *config.Terr
 
 	connectionBlockBody.SetAttributeValue(defaults.Type, cty.StringVal(defaults.Ssh))
 	connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(linode.RootUser))
-	connectionBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(terraformConfig.LinodeConfig.LinodeRootPass))
+	secrets.SetSensitiveAttribute(newFile, connectionBlockBody, defaults.Password, secrets.LinodeRootPass, terraformConfig.LinodeConfig.LinodeRootPass)
 
 	hostExpression := defaults.Self + "." + defaults.IPAddress
 	host := hclwrite.Tokens{
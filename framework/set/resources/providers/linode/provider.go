@@ -8,6 +8,7 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/linode"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -34,11 +35,11 @@ func CreateLinodeTerraformProviderBlock(tfBlockBody *hclwrite.Body) {
 }
 
 // CreateLinodeProviderBlock will set up the linode provider block.
-func CreateLinodeProviderBlock(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+func CreateLinodeProviderBlock(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
 	linodeProvBlock := rootBody.AppendNewBlock(defaults.Provider, []string{defaults.Linode})
 	linodeProvBlockBody := linodeProvBlock.Body()
 
-	linodeProvBlockBody.SetAttributeValue(linode.Token, cty.StringVal(terraformConfig.LinodeCredentials.LinodeToken))
+	secrets.SetSensitiveAttribute(newFile, linodeProvBlockBody, linode.Token, secrets.LinodeToken, terraformConfig.LinodeCredentials.LinodeToken)
 }
 
 // CreateLinodeLocalBlock will set up the local block. Returns the local block.
@@ -8,6 +8,8 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/providers"
 	"github.com/rancher/tfp-automation/framework/set/resources/providers/aws"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/azure"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/google"
 	"github.com/rancher/tfp-automation/framework/set/resources/providers/harvester"
 	"github.com/rancher/tfp-automation/framework/set/resources/providers/linode"
 	"github.com/rancher/tfp-automation/framework/set/resources/providers/vsphere"
@@ -33,6 +35,16 @@ func TunnelToProvider(provider string) ProviderResources {
 			CreateNonAirgap: aws.CreateAWSResources,
 			CreateIPv6:      aws.CreateIPv6AWSResources,
 		}
+	case providers.Azure:
+		logrus.Infof("Creating Azure resources...")
+		return ProviderResources{
+			CreateNonAirgap: azure.CreateAzureResources,
+		}
+	case providers.Google:
+		logrus.Infof("Creating Google resources...")
+		return ProviderResources{
+			CreateNonAirgap: google.CreateGoogleResources,
+		}
 	case providers.Linode:
 		logrus.Infof("Creating Linode resources...")
 		return ProviderResources{
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/sirupsen/logrus"
 )
 
@@ -36,7 +37,7 @@ func CreateVsphereResources(file *os.File, newFile *hclwrite.File, tfBlockBody,
 	CreateVsphereTerraformProviderBlock(tfBlockBody)
 	rootBody.AppendNewline()
 
-	CreateVsphereProviderBlock(rootBody, terraformConfig)
+	CreateVsphereProviderBlock(newFile, rootBody, terraformConfig)
 	rootBody.AppendNewline()
 
 	dataCenterExpression := fmt.Sprintf(defaults.Data + `.` + defaults.VsphereDatacenter + `.` + defaults.VsphereDatacenter + `.id`)
@@ -67,7 +68,7 @@ func CreateVsphereResources(file *os.File, newFile *hclwrite.File, tfBlockBody,
 	CreateVsphereLocalBlock(rootBody, terraformConfig)
 	rootBody.AppendNewline()
 
-	_, err := file.Write(newFile.Bytes())
+	err := secrets.WriteMainTF(file, newFile)
 	if err != nil {
 		logrus.Infof("Failed to write configurations to main.tf file. Error: %v", err)
 		return nil, err
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -33,12 +34,12 @@ func CreateVsphereTerraformProviderBlock(tfBlockBody *hclwrite.Body) {
 }
 
 // CreateVsphereProviderBlock will set up the vsphere provider block.
-func CreateVsphereProviderBlock(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+func CreateVsphereProviderBlock(newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
 	vsphereProvBlock := rootBody.AppendNewBlock(defaults.Provider, []string{defaults.Vsphere})
 	vsphereProvBlockBody := vsphereProvBlock.Body()
 
 	vsphereProvBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.VsphereCredentials.Username))
-	vsphereProvBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(terraformConfig.VsphereCredentials.Password))
+	secrets.SetSensitiveAttribute(newFile, vsphereProvBlockBody, defaults.Password, secrets.VspherePassword, terraformConfig.VsphereCredentials.Password)
 	vsphereProvBlockBody.SetAttributeValue(defaults.VsphereServer, cty.StringVal(terraformConfig.VsphereCredentials.Vcenter))
 	vsphereProvBlockBody.SetAttributeValue(allowUnverifiedSSL, cty.BoolVal(true))
 }
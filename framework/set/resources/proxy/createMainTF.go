@@ -10,6 +10,7 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/providers"
 	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/set/backend"
 	tunnel "github.com/rancher/tfp-automation/framework/set/resources/providers"
 	"github.com/rancher/tfp-automation/framework/set/resources/proxy/rancher"
 	"github.com/rancher/tfp-automation/framework/set/resources/proxy/rke2"
@@ -48,7 +49,13 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 	tfBlock := rootBody.AppendNewBlock(terraformConst, nil)
 	tfBlockBody := tfBlock.Body()
 
-	var err error
+	err := backend.ValidateBackend(terratestConfig.Backend)
+	if err != nil {
+		return "", "", err
+	}
+
+	backend.SetBackend(tfBlockBody, terratestConfig.Backend, terraformConfig.ResourcePrefix)
+
 	var linodeNodeBalancerHostname string
 
 	instances := []string{bastion}
@@ -10,6 +10,7 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -41,22 +42,22 @@ func CreateSquidProxy(file *os.File, newFile *hclwrite.File, rootBody *hclwrite.
 		return nil, err
 	}
 
-	_, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, rke2BastionPublicDNS, installSquidProxy)
+	_, provisionerBlockBody := rke2.SSHNullResource(newFile, rootBody, terraformConfig, rke2BastionPublicDNS, installSquidProxy)
 
 	command := "bash -c '/tmp/setup.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
 		terraformConfig.Standalone.RegistryUsername + " " + terraformConfig.Standalone.RegistryPassword + " " +
 		terraformConfig.Standalone.RKE2Version + " " + rke2ServerOnePrivateIP + " " + rke2ServerTwoPrivateIP + " " +
 		rke2ServerThreePrivateIP + " || true'"
 
-	provisionerBlockBody.SetAttributeValue(defaults.Inline, cty.ListVal([]cty.Value{
-		cty.StringVal("echo '" + string(scriptContent) + "' > /tmp/setup.sh"),
-		cty.StringVal("echo '" + string(squidConfContent) + "' > /tmp/squid.conf"),
-		cty.StringVal("echo '" + string(privateKey) + "' > /tmp/keyfile.pem"),
-		cty.StringVal("chmod +x /tmp/setup.sh"),
-		cty.StringVal(command),
+	provisionerBlockBody.SetAttributeRaw(defaults.Inline, hclwrite.TokensForTuple([]hclwrite.Tokens{
+		hclwrite.TokensForValue(cty.StringVal("echo '" + string(scriptContent) + "' > /tmp/setup.sh")),
+		hclwrite.TokensForValue(cty.StringVal("echo '" + string(squidConfContent) + "' > /tmp/squid.conf")),
+		secrets.TemplateTokens(newFile, "echo '", secrets.PrivateKey, string(privateKey), "' > /tmp/keyfile.pem"),
+		hclwrite.TokensForValue(cty.StringVal("chmod +x /tmp/setup.sh")),
+		hclwrite.TokensForValue(cty.StringVal(command)),
 	}))
 
-	_, err = file.Write(newFile.Bytes())
+	err = secrets.WriteMainTF(file, newFile)
 	if err != nil {
 		logrus.Infof("Failed to append configurations to main.tf file. Error: %v", err)
 		return nil, err
@@ -11,10 +11,16 @@ import (
 
 // InitializeMainTF is a function that will create a new main.tf file for the downstream Rancher cluster tests
 func InitializeMainTF(terratestConfig *config.TerratestConfig) (*hclwrite.File, *hclwrite.Body, *os.File) {
+	_, keyPath := SetKeyPath(keypath.RancherKeyPath, terratestConfig.PathToRepo, "")
+
+	return InitializeWorkspaceMainTF(keyPath)
+}
+
+// InitializeWorkspaceMainTF is a function that will create a new main.tf file in the given Terraform workspace
+func InitializeWorkspaceMainTF(keyPath string) (*hclwrite.File, *hclwrite.Body, *os.File) {
 	newFile := hclwrite.NewEmptyFile()
 	rootBody := newFile.Body()
 
-	_, keyPath := SetKeyPath(keypath.RancherKeyPath, terratestConfig.PathToRepo, "")
 	file, err := os.Create(keyPath + configs.MainTF)
 	if err != nil {
 		return nil, nil, nil
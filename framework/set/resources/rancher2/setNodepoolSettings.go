@@ -0,0 +1,42 @@
+package rancher2
+
+import (
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	drainBeforeDelete = "drain_before_delete"
+	effect            = "effect"
+	key               = "key"
+)
+
+// SetNodepoolSettings is a function that will set the labels, taints and drain setting of a node pool in the main.tf file. The
+// taints are set as blocks named taintsBlock, which is taints for RKE2/K3s machine pools and node_taints for RKE1 node pools.
+func SetNodepoolSettings(poolBlockBody *hclwrite.Body, pool config.Nodepool, taintsBlock string) {
+	if len(pool.Labels) > 0 {
+		labels := map[string]cty.Value{}
+		for labelKey, labelValue := range pool.Labels {
+			labels[labelKey] = cty.StringVal(labelValue)
+		}
+
+		poolBlockBody.SetAttributeValue(defaults.Labels, cty.MapVal(labels))
+	}
+
+	for _, taint := range pool.Taints {
+		taintBlockBody := poolBlockBody.AppendNewBlock(taintsBlock, nil).Body()
+
+		taintBlockBody.SetAttributeValue(key, cty.StringVal(taint.Key))
+		taintBlockBody.SetAttributeValue(defaults.Value, cty.StringVal(taint.Value))
+
+		if taint.Effect != "" {
+			taintBlockBody.SetAttributeValue(effect, cty.StringVal(taint.Effect))
+		}
+	}
+
+	if pool.DrainBeforeDelete {
+		poolBlockBody.SetAttributeValue(drainBeforeDelete, cty.BoolVal(true))
+	}
+}
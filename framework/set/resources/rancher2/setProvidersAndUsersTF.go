@@ -11,7 +11,12 @@ import (
 	"github.com/rancher/shepherd/pkg/config/operations"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/rancher/tfp-automation/framework"
+	"github.com/rancher/tfp-automation/framework/set/backend"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/azure"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/google"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -54,8 +59,19 @@ const (
 func SetProvidersAndUsersTF(rancherConfig *rancher.Config, testUser, testPassword string, authProvider bool,
 	newFile *hclwrite.File, rootBody *hclwrite.Body, configMap []map[string]any, customModule bool) (*hclwrite.File, *hclwrite.Body) {
 	createRequiredProviders(rootBody, configMap, customModule)
-	createProvider(rancherConfig, rootBody, configMap, customModule)
-	createProviderAlias(rancherConfig, rootBody)
+	createProvider(rancherConfig, newFile, rootBody, configMap, customModule)
+	createProviderAlias(rancherConfig, newFile, rootBody)
+
+	return newFile, rootBody
+}
+
+// SetRenderProvidersTF is a helper function that will set the general Terraform configurations in the main.tf file without
+// reaching out to Rancher. The admin token from the Rancher config is used for the provider alias.
+func SetRenderProvidersTF(rancherConfig *rancher.Config, newFile *hclwrite.File, rootBody *hclwrite.Body, configMap []map[string]any,
+	customModule bool) (*hclwrite.File, *hclwrite.Body) {
+	createRequiredProviders(rootBody, configMap, customModule)
+	createProvider(rancherConfig, newFile, rootBody, configMap, customModule)
+	setProviderAlias(newFile, rootBody, rancherConfig.Host, rancherConfig.AdminToken)
 
 	return newFile, rootBody
 }
@@ -71,46 +87,67 @@ func createRequiredProviders(rootBody *hclwrite.Body, configMap []map[string]any
 	terraformConfig := new(config.TerraformConfig)
 	operations.LoadObjectFromMap(config.TerraformConfigurationFileKey, configMap[0], terraformConfig)
 
+	terratestConfig := new(config.TerratestConfig)
+	operations.LoadObjectFromMap(config.TerratestConfigurationFileKey, configMap[0], terratestConfig)
+
+	backend.SetBackend(tfBlockBody, terratestConfig.Backend, terraformConfig.ResourcePrefix)
+
+	engine := framework.Engine(terratestConfig)
+
 	source, rancherProviderVersion, cloudProviderVersion, localProviderVersion, rkeProviderVersion := getRequiredProviderVersions(configMap)
 
 	if rancherProviderVersion != "" {
 		reqProvsBlockBody.SetAttributeValue(rancher2, cty.ObjectVal(map[string]cty.Value{
-			rancherSource: cty.StringVal(source),
+			rancherSource: cty.StringVal(framework.ProviderSource(engine, source)),
 			version:       cty.StringVal(rancherProviderVersion),
 		}))
 	}
 
 	if cloudProviderVersion != "" && terraformConfig.Provider == defaults.Aws && customModule {
 		reqProvsBlockBody.SetAttributeValue(defaults.Aws, cty.ObjectVal(map[string]cty.Value{
-			defaults.Source:  cty.StringVal(defaults.AwsSource),
+			defaults.Source:  cty.StringVal(framework.ProviderSource(engine, defaults.AwsSource)),
+			defaults.Version: cty.StringVal(cloudProviderVersion),
+		}))
+	}
+
+	if cloudProviderVersion != "" && terraformConfig.Provider == defaults.Azure && customModule {
+		reqProvsBlockBody.SetAttributeValue(defaults.Azurerm, cty.ObjectVal(map[string]cty.Value{
+			defaults.Source:  cty.StringVal(framework.ProviderSource(engine, defaults.AzurermSource)),
+			defaults.Version: cty.StringVal(cloudProviderVersion),
+		}))
+	}
+
+	if cloudProviderVersion != "" && terraformConfig.Provider == defaults.Google && customModule {
+		reqProvsBlockBody.SetAttributeValue(defaults.Google, cty.ObjectVal(map[string]cty.Value{
+			defaults.Source:  cty.StringVal(framework.ProviderSource(engine, defaults.GoogleSource)),
 			defaults.Version: cty.StringVal(cloudProviderVersion),
 		}))
 	}
 
 	if cloudProviderVersion != "" && terraformConfig.Provider == defaults.Linode && customModule {
 		reqProvsBlockBody.SetAttributeValue(defaults.Linode, cty.ObjectVal(map[string]cty.Value{
-			defaults.Source:  cty.StringVal(defaults.LinodeSource),
+			defaults.Source:  cty.StringVal(framework.ProviderSource(engine, defaults.LinodeSource)),
 			defaults.Version: cty.StringVal(cloudProviderVersion),
 		}))
 	}
 
 	if cloudProviderVersion != "" && terraformConfig.Provider == defaults.Vsphere && customModule {
 		reqProvsBlockBody.SetAttributeValue(defaults.Vsphere, cty.ObjectVal(map[string]cty.Value{
-			defaults.Source:  cty.StringVal(defaults.VsphereSource),
+			defaults.Source:  cty.StringVal(framework.ProviderSource(engine, defaults.VsphereSource)),
 			defaults.Version: cty.StringVal(cloudProviderVersion),
 		}))
 	}
 
 	if localProviderVersion != "" {
 		reqProvsBlockBody.SetAttributeValue(defaults.Local, cty.ObjectVal(map[string]cty.Value{
-			defaults.Source:  cty.StringVal(defaults.LocalSource),
+			defaults.Source:  cty.StringVal(framework.ProviderSource(engine, defaults.LocalSource)),
 			defaults.Version: cty.StringVal(localProviderVersion),
 		}))
 	}
 
 	if rkeProviderVersion != "" {
 		reqProvsBlockBody.SetAttributeValue(defaults.RKE, cty.ObjectVal(map[string]cty.Value{
-			defaults.Source:  cty.StringVal(rancherRKE),
+			defaults.Source:  cty.StringVal(framework.ProviderSource(engine, rancherRKE)),
 			defaults.Version: cty.StringVal(rkeProviderVersion),
 		}))
 	}
@@ -119,7 +156,7 @@ func createRequiredProviders(rootBody *hclwrite.Body, configMap []map[string]any
 }
 
 // createProvider creates a provider block for the given rancher config.
-func createProvider(rancherConfig *rancher.Config, rootBody *hclwrite.Body, configMap []map[string]any, customModule bool) {
+func createProvider(rancherConfig *rancher.Config, newFile *hclwrite.File, rootBody *hclwrite.Body, configMap []map[string]any, customModule bool) {
 	_, _, cloudProviderVersion, _, _ := getRequiredProviderVersions(configMap)
 
 	terraformConfig := new(config.TerraformConfig)
@@ -130,8 +167,24 @@ func createProvider(rancherConfig *rancher.Config, rootBody *hclwrite.Body, conf
 		awsProvBlockBody := awsProvBlock.Body()
 
 		awsProvBlockBody.SetAttributeValue(defaults.Region, cty.StringVal(terraformConfig.AWSConfig.Region))
-		awsProvBlockBody.SetAttributeValue(defaults.AccessKey, cty.StringVal(terraformConfig.AWSCredentials.AWSAccessKey))
-		awsProvBlockBody.SetAttributeValue(defaults.SecretKey, cty.StringVal(terraformConfig.AWSCredentials.AWSSecretKey))
+		secrets.SetSensitiveAttribute(newFile, awsProvBlockBody, defaults.AccessKey, secrets.AWSAccessKey, terraformConfig.AWSCredentials.AWSAccessKey)
+		secrets.SetSensitiveAttribute(newFile, awsProvBlockBody, defaults.SecretKey, secrets.AWSSecretKey, terraformConfig.AWSCredentials.AWSSecretKey)
+
+		rootBody.AppendNewline()
+		rootBody.AppendNewBlock(defaults.Provider, []string{defaults.Local})
+		rootBody.AppendNewline()
+	}
+
+	if cloudProviderVersion != "" && terraformConfig.Provider == defaults.Azure && customModule {
+		azure.CreateAzureProviderBlock(newFile, rootBody, terraformConfig)
+
+		rootBody.AppendNewline()
+		rootBody.AppendNewBlock(defaults.Provider, []string{defaults.Local})
+		rootBody.AppendNewline()
+	}
+
+	if cloudProviderVersion != "" && terraformConfig.Provider == defaults.Google && customModule {
+		google.CreateGoogleProviderBlock(newFile, rootBody, terraformConfig)
 
 		rootBody.AppendNewline()
 		rootBody.AppendNewBlock(defaults.Provider, []string{defaults.Local})
@@ -142,7 +195,7 @@ func createProvider(rancherConfig *rancher.Config, rootBody *hclwrite.Body, conf
 		linodeProvBlock := rootBody.AppendNewBlock(defaults.Provider, []string{defaults.Linode})
 		linodeProvBlockBody := linodeProvBlock.Body()
 
-		linodeProvBlockBody.SetAttributeValue(defaults.Token, cty.StringVal(terraformConfig.LinodeCredentials.LinodeToken))
+		secrets.SetSensitiveAttribute(newFile, linodeProvBlockBody, defaults.Token, secrets.LinodeToken, terraformConfig.LinodeCredentials.LinodeToken)
 
 		rootBody.AppendNewline()
 		rootBody.AppendNewBlock(defaults.Provider, []string{defaults.Local})
@@ -154,7 +207,7 @@ func createProvider(rancherConfig *rancher.Config, rootBody *hclwrite.Body, conf
 		vsphereProvBlockBody := vsphereProvBlock.Body()
 
 		vsphereProvBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.VsphereCredentials.Username))
-		vsphereProvBlockBody.SetAttributeValue(defaults.Password, cty.StringVal(terraformConfig.VsphereCredentials.Password))
+		secrets.SetSensitiveAttribute(newFile, vsphereProvBlockBody, defaults.Password, secrets.VspherePassword, terraformConfig.VsphereCredentials.Password)
 		vsphereProvBlockBody.SetAttributeValue(defaults.VsphereServer, cty.StringVal(terraformConfig.VsphereCredentials.Vcenter))
 		vsphereProvBlockBody.SetAttributeValue(allowUnverifiedSSL, cty.BoolVal(true))
 
@@ -167,20 +220,14 @@ func createProvider(rancherConfig *rancher.Config, rootBody *hclwrite.Body, conf
 	rancher2ProvBlockBody := rancher2ProvBlock.Body()
 
 	rancher2ProvBlockBody.SetAttributeValue(apiURL, cty.StringVal("https://"+rancherConfig.Host))
-	rancher2ProvBlockBody.SetAttributeValue(tokenKey, cty.StringVal(rancherConfig.AdminToken))
+	secrets.SetSensitiveAttribute(newFile, rancher2ProvBlockBody, tokenKey, secrets.RancherAdminToken, rancherConfig.AdminToken)
 	rancher2ProvBlockBody.SetAttributeValue(insecure, cty.BoolVal(*rancherConfig.Insecure))
 
 	rootBody.AppendNewline()
 }
 
 // createProviderAlias creates a provider alias block for the standard user.
-func createProviderAlias(rancherConfig *rancher.Config, rootBody *hclwrite.Body) {
-	providerBlock := rootBody.AppendNewBlock(defaults.Provider, []string{rancher2})
-	providerBlockBody := providerBlock.Body()
-
-	providerBlockBody.SetAttributeValue(alias, cty.StringVal(defaults.AdminUser))
-	providerBlockBody.SetAttributeValue(apiURL, cty.StringVal("https://"+rancherConfig.Host))
-
+func createProviderAlias(rancherConfig *rancher.Config, newFile *hclwrite.File, rootBody *hclwrite.Body) {
 	adminUser := &management.User{
 		Username: admin,
 		Password: rancherConfig.AdminPassword,
@@ -191,7 +238,17 @@ func createProviderAlias(rancherConfig *rancher.Config, rootBody *hclwrite.Body)
 		logrus.Fatalf("Failed to generate admin token: %v", err)
 	}
 
-	providerBlockBody.SetAttributeValue(tokenKey, cty.StringVal(adminToken.Token))
+	setProviderAlias(newFile, rootBody, rancherConfig.Host, adminToken.Token)
+}
+
+// setProviderAlias sets the provider alias block for the given token.
+func setProviderAlias(newFile *hclwrite.File, rootBody *hclwrite.Body, host, adminToken string) {
+	providerBlock := rootBody.AppendNewBlock(defaults.Provider, []string{rancher2})
+	providerBlockBody := providerBlock.Body()
+
+	providerBlockBody.SetAttributeValue(alias, cty.StringVal(defaults.AdminUser))
+	providerBlockBody.SetAttributeValue(apiURL, cty.StringVal("https://"+host))
+	secrets.SetSensitiveAttribute(newFile, providerBlockBody, tokenKey, secrets.RancherAdminUserToken, adminToken)
 	providerBlockBody.SetAttributeValue(insecure, cty.BoolVal(true))
 
 	rootBody.AppendNewline()
@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/framework/set/resources/providers/aws"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/sirupsen/logrus"
 )
 
@@ -26,7 +27,7 @@ func CreateAWSResources(file *os.File, newFile *hclwrite.File, tfBlockBody, root
 	createTerraformProviderBlock(tfBlockBody)
 	rootBody.AppendNewline()
 
-	aws.CreateAWSProviderBlock(rootBody, terraformConfig)
+	aws.CreateAWSProviderBlock(newFile, rootBody, terraformConfig)
 	rootBody.AppendNewline()
 
 	createRKEProviderBlock(rootBody)
@@ -39,7 +40,7 @@ func CreateAWSResources(file *os.File, newFile *hclwrite.File, tfBlockBody, root
 		rootBody.AppendNewline()
 	}
 
-	_, err := file.Write(newFile.Bytes())
+	err := secrets.WriteMainTF(file, newFile)
 	if err != nil {
 		logrus.Infof("Failed to write configurations to main.tf file. Error: %v", err)
 		return nil, err
@@ -10,6 +10,7 @@ import (
 	shepherdConfig "github.com/rancher/shepherd/clients/rancher"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/set/backend"
 	"github.com/rancher/tfp-automation/framework/set/resources/rke/aws"
 	rke "github.com/rancher/tfp-automation/framework/set/resources/rke/rke"
 	resources "github.com/rancher/tfp-automation/framework/set/resources/sanity"
@@ -37,8 +38,15 @@ func CreateRKEMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath
 	tfBlock := rootBody.AppendNewBlock(terraformConst, nil)
 	tfBlockBody := tfBlock.Body()
 
+	err := backend.ValidateBackend(terratestConfig.Backend)
+	if err != nil {
+		return "", err
+	}
+
+	backend.SetBackend(tfBlockBody, terratestConfig.Backend, terraformConfig.ResourcePrefix)
+
 	logrus.Infof("Creating resources using AWS")
-	file, err := aws.CreateAWSResources(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig)
+	file, err = aws.CreateAWSResources(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig)
 	if err != nil {
 		return "", err
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -31,7 +32,7 @@ func CheckClusterStatus(file *os.File, newFile *hclwrite.File, rootBody *hclwrit
 	encodedKubeConfig := base64.StdEncoding.EncodeToString([]byte(kubeConfig))
 	command := fmt.Sprintf("bash -c \"/tmp/cluster.sh '%s'\"", encodedKubeConfig)
 
-	_, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, rkeServerOnePublicIP, rkeServerOne)
+	_, provisionerBlockBody := rke2.SSHNullResource(newFile, rootBody, terraformConfig, rkeServerOnePublicIP, rkeServerOne)
 
 	provisionerBlockBody.SetAttributeValue(defaults.Inline, cty.ListVal([]cty.Value{
 		cty.StringVal("printf '" + string(scriptContent) + "' > /tmp/cluster.sh"),
@@ -39,7 +40,7 @@ func CheckClusterStatus(file *os.File, newFile *hclwrite.File, rootBody *hclwrit
 		cty.StringVal(command),
 	}))
 
-	_, err = file.Write(newFile.Bytes())
+	err = secrets.WriteMainTF(file, newFile)
 	if err != nil {
 		logrus.Infof("Failed to append configurations to main.tf file. Error: %v", err)
 		return nil, err
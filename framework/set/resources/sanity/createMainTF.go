@@ -11,6 +11,7 @@ import (
 	"github.com/rancher/tfp-automation/defaults/configs"
 	"github.com/rancher/tfp-automation/defaults/providers"
 	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/set/backend"
 	tunnel "github.com/rancher/tfp-automation/framework/set/resources/providers"
 	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity/rancher"
@@ -43,7 +44,13 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 	tfBlock := rootBody.AppendNewBlock(terraformConst, nil)
 	tfBlockBody := tfBlock.Body()
 
-	var err error
+	err := backend.ValidateBackend(terratestConfig.Backend)
+	if err != nil {
+		return "", err
+	}
+
+	backend.SetBackend(tfBlockBody, terratestConfig.Backend, terraformConfig.ResourcePrefix)
+
 	var nodeBalancerHostname string
 
 	instances := []string{serverOne, serverTwo, serverThree}
@@ -68,6 +75,12 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 	case providers.Harvester, providers.Vsphere:
 		nodeBalancerHostname = terraform.Output(t, terraformOptions, serverOnePublicIP) + sslipioSuffix
 		terraformConfig.Standalone.RancherHostname = nodeBalancerHostname
+	case providers.Azure, providers.Google:
+		// Without a Rancher hostname, no load balancer or DNS record is created and Rancher is reached through the first server.
+		if terraformConfig.Standalone.RancherHostname == "" {
+			nodeBalancerHostname = terraform.Output(t, terraformOptions, serverOnePublicIP) + sslipioSuffix
+			terraformConfig.Standalone.RancherHostname = nodeBalancerHostname
+		}
 	}
 
 	serverOnePublicIP := terraform.Output(t, terraformOptions, serverOnePublicIP)
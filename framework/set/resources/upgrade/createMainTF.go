@@ -8,9 +8,13 @@ import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/shepherd/clients/rancher"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/providers"
 	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/set/backend"
 	airgap "github.com/rancher/tfp-automation/framework/set/resources/airgap/rancher"
 	"github.com/rancher/tfp-automation/framework/set/resources/providers/aws"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/azure"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/google"
 	proxy "github.com/rancher/tfp-automation/framework/set/resources/proxy/rancher"
 	registry "github.com/rancher/tfp-automation/framework/set/resources/registries/createRegistry"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity"
@@ -37,11 +41,33 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 	tfBlock := rootBody.AppendNewBlock(terraformConst, nil)
 	tfBlockBody := tfBlock.Body()
 
-	aws.CreateAWSTerraformProviderBlock(tfBlockBody)
-	rootBody.AppendNewline()
+	err := backend.ValidateBackend(terratestConfig.Backend)
+	if err != nil {
+		return err
+	}
+
+	backend.SetBackend(tfBlockBody, terratestConfig.Backend, terraformConfig.ResourcePrefix)
+
+	switch terraformConfig.Provider {
+	case providers.Azure:
+		azure.CreateAzureTerraformProviderBlock(tfBlockBody)
+		rootBody.AppendNewline()
+
+		azure.CreateAzureProviderBlock(newFile, rootBody, terraformConfig)
+		rootBody.AppendNewline()
+	case providers.Google:
+		google.CreateGoogleTerraformProviderBlock(tfBlockBody)
+		rootBody.AppendNewline()
 
-	aws.CreateAWSProviderBlock(rootBody, terraformConfig)
-	rootBody.AppendNewline()
+		google.CreateGoogleProviderBlock(newFile, rootBody, terraformConfig)
+		rootBody.AppendNewline()
+	default:
+		aws.CreateAWSTerraformProviderBlock(tfBlockBody)
+		rootBody.AppendNewline()
+
+		aws.CreateAWSProviderBlock(newFile, rootBody, terraformConfig)
+		rootBody.AppendNewline()
+	}
 
 	file = sanity.OpenFile(file, keyPath)
 	switch {
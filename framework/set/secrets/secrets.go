@@ -0,0 +1,211 @@
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	variable  = "variable"
+	varPrefix = "var"
+	typeKey   = "type"
+	sensitive = "sensitive"
+)
+
+// Names of the sensitive variables that hold secrets in the generated main.tf file.
+const (
+	AWSAccessKey                   = "aws_access_key"
+	AWSSecretKey                   = "aws_secret_key"
+	AzureClientSecret              = "azure_client_secret"
+	GoogleAuthEncodedJSON          = "google_auth_encoded_json"
+	HarvesterKubeconfig            = "harvester_kubeconfig"
+	LinodeToken                    = "linode_token"
+	LinodeRootPass                 = "linode_root_pass"
+	VspherePassword                = "vsphere_password"
+	VsphereSSHPassword             = "vsphere_ssh_password"
+	Windows2019Password            = "windows_2019_password"
+	Windows2022Password            = "windows_2022_password"
+	RancherAdminToken              = "rancher_admin_token"
+	RancherAdminUserToken          = "rancher_admin_user_token"
+	RegistryPassword               = "registry_password"
+	EtcdS3AccessKey                = "etcd_s3_access_key"
+	EtcdS3SecretKey                = "etcd_s3_secret_key"
+	TestUserPassword               = "test_user_password"
+	ADServiceAccountPassword       = "ad_service_account_password"
+	ADTestPassword                 = "ad_test_password"
+	OpenLDAPServiceAccountPassword = "openldap_service_account_password"
+	OpenLDAPTestPassword           = "openldap_test_password"
+	GithubClientSecret             = "github_client_secret"
+	AzureADApplicationSecret       = "azuread_application_secret"
+	PrivateKey                     = "private_key"
+	PrivateKeyBase64               = "private_key_base64"
+	WindowsPrivateKeyBase64        = "windows_private_key_base64"
+)
+
+// registries holds the values of the sensitive variables registered for each generated file, so files that are generated at the
+// same time never share variables.
+var (
+	mutex      sync.Mutex
+	registries = map[*hclwrite.File]map[string]string{}
+)
+
+// SetSensitiveAttribute is a function that will set the attribute to a reference of a sensitive variable holding the value, instead
+// of inlining the value in the main.tf file of newFile.
+func SetSensitiveAttribute(newFile *hclwrite.File, body *hclwrite.Body, attribute, name, value string) {
+	name = register(newFile, name, value)
+
+	body.SetAttributeTraversal(attribute, hcl.Traversal{
+		hcl.TraverseRoot{Name: varPrefix},
+		hcl.TraverseAttr{Name: name},
+	})
+}
+
+// TemplateTokens is a function that will return the tokens of a quoted string made of the prefix, a reference of a sensitive
+// variable holding the value and the suffix. This is used when a secret is embedded in a larger string, such as a command.
+func TemplateTokens(newFile *hclwrite.File, prefix, name, value, suffix string) hclwrite.Tokens {
+	name = register(newFile, name, value)
+
+	tokens := hclwrite.Tokens{{Type: hclsyntax.TokenOQuote, Bytes: []byte(`"`)}}
+	tokens = append(tokens, quotedLiteral(prefix)...)
+	tokens = append(tokens,
+		&hclwrite.Token{Type: hclsyntax.TokenTemplateInterp, Bytes: []byte(`${`)},
+		&hclwrite.Token{Type: hclsyntax.TokenIdent, Bytes: []byte(varPrefix + "." + name)},
+		&hclwrite.Token{Type: hclsyntax.TokenTemplateSeqEnd, Bytes: []byte(`}`)},
+	)
+	tokens = append(tokens, quotedLiteral(suffix)...)
+	tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenCQuote, Bytes: []byte(`"`)})
+
+	return tokens
+}
+
+// Interpolation is a function that will return the template interpolation of a sensitive variable holding the value. This
+// is used when building raw template tokens that already contain other interpolations.
+func Interpolation(newFile *hclwrite.File, name, value string) string {
+	return "${" + varPrefix + "." + register(newFile, name, value) + "}"
+}
+
+// DeclareVariables is a function that will declare a sensitive variable block for every secret referenced in the file and
+// return the values of those variables.
+func DeclareVariables(newFile *hclwrite.File) map[string]string {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	rootBody := newFile.Body()
+	content := newFile.Bytes()
+	values := registries[newFile]
+	referenced := map[string]string{}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		reference := regexp.MustCompile(`\b` + varPrefix + `\.` + regexp.QuoteMeta(name) + `\b`)
+		if !reference.Match(content) {
+			continue
+		}
+
+		referenced[name] = values[name]
+
+		if rootBody.FirstMatchingBlock(variable, []string{name}) != nil {
+			continue
+		}
+
+		variableBlockBody := rootBody.AppendNewBlock(variable, []string{name}).Body()
+		variableBlockBody.SetAttributeRaw(typeKey, hclwrite.TokensForIdentifier("string"))
+		variableBlockBody.SetAttributeValue(sensitive, cty.BoolVal(true))
+
+		rootBody.AppendNewline()
+	}
+
+	return referenced
+}
+
+// Reset is a function that will forget the sensitive variables registered for newFile. This is used when the file is cleared or
+// is no longer needed.
+func Reset(newFile *hclwrite.File) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	delete(registries, newFile)
+}
+
+// WriteTFVars is a function that will write the values of the sensitive variables to secrets.auto.tfvars.json in the given
+// directory. The file is only readable by the current user.
+func WriteTFVars(dir string, values map[string]string) error {
+	data, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, configs.SecretsTFVars)
+
+	err = os.WriteFile(path, data, 0600)
+	if err != nil {
+		return err
+	}
+
+	return os.Chmod(path, 0600)
+}
+
+// WriteMainTF is a function that will declare the sensitive variables referenced in newFile, write newFile to the main.tf file
+// and write the variable values to secrets.auto.tfvars.json next to it.
+func WriteMainTF(file *os.File, newFile *hclwrite.File) error {
+	referenced := DeclareVariables(newFile)
+
+	_, err := file.Write(newFile.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return WriteTFVars(filepath.Dir(file.Name()), referenced)
+}
+
+// register is a helper function that will store the value under the variable name of newFile. If the name already holds a
+// different value, a numbered suffix is added to the name.
+func register(newFile *hclwrite.File, name, value string) string {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	values, ok := registries[newFile]
+	if !ok {
+		values = map[string]string{}
+		registries[newFile] = values
+	}
+
+	candidate := name
+	for i := 2; ; i++ {
+		existing, ok := values[candidate]
+		if !ok || existing == value {
+			values[candidate] = value
+			return candidate
+		}
+
+		candidate = fmt.Sprintf("%s_%d", name, i)
+	}
+}
+
+// quotedLiteral is a helper function that will return the escaped literal tokens of a string, without the surrounding quotes.
+func quotedLiteral(value string) hclwrite.Tokens {
+	if value == "" {
+		return nil
+	}
+
+	tokens := hclwrite.TokensForValue(cty.StringVal(value))
+
+	return tokens[1 : len(tokens)-1]
+}
@@ -0,0 +1,74 @@
+package secrets
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestWriteMainTF(t *testing.T) {
+	newFile := hclwrite.NewEmptyFile()
+	rootBody := newFile.Body()
+
+	providerBody := rootBody.AppendNewBlock("provider", []string{"aws"}).Body()
+	providerBody.SetAttributeValue("region", cty.StringVal("us-east-2"))
+	SetSensitiveAttribute(newFile, providerBody, "secret_key", AWSSecretKey, "super-secret")
+
+	provisionerBody := rootBody.AppendNewBlock("provisioner", []string{"remote-exec"}).Body()
+	provisionerBody.SetAttributeRaw("inline", hclwrite.TokensForTuple([]hclwrite.Tokens{
+		TemplateTokens(newFile, "echo '", PrivateKey, "private-key-pem", "' > /tmp/keyfile.pem"),
+	}))
+
+	dir := t.TempDir()
+
+	file, err := os.Create(filepath.Join(dir, configs.MainTF))
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	require.NoError(t, WriteMainTF(file, newFile))
+
+	mainTF, err := os.ReadFile(file.Name())
+	require.NoError(t, err)
+	require.NotContains(t, string(mainTF), "super-secret")
+	require.NotContains(t, string(mainTF), "private-key-pem")
+	require.Contains(t, string(mainTF), "secret_key = var.aws_secret_key")
+	require.Contains(t, string(mainTF), `"echo '${var.private_key}' > /tmp/keyfile.pem"`)
+	require.Contains(t, string(mainTF), `variable "aws_secret_key"`)
+	require.Contains(t, string(mainTF), `variable "private_key"`)
+
+	info, err := os.Stat(filepath.Join(dir, configs.SecretsTFVars))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	data, err := os.ReadFile(filepath.Join(dir, configs.SecretsTFVars))
+	require.NoError(t, err)
+
+	tfvars := map[string]string{}
+	require.NoError(t, json.Unmarshal(data, &tfvars))
+	require.Equal(t, map[string]string{AWSSecretKey: "super-secret", PrivateKey: "private-key-pem"}, tfvars)
+}
+
+func TestRegisterConflictingValues(t *testing.T) {
+	newFile := hclwrite.NewEmptyFile()
+
+	first := Interpolation(newFile, RegistryPassword, "first-password")
+	second := Interpolation(newFile, RegistryPassword, "second-password")
+	again := Interpolation(newFile, RegistryPassword, "first-password")
+
+	require.Equal(t, "${var.registry_password}", first)
+	require.Equal(t, "${var.registry_password_2}", second)
+	require.Equal(t, first, again)
+
+	otherFile := hclwrite.NewEmptyFile()
+	require.Equal(t, "${var.registry_password}", Interpolation(otherFile, RegistryPassword, "second-password"))
+
+	Reset(newFile)
+	require.Equal(t, "${var.registry_password}", Interpolation(newFile, RegistryPassword, "second-password"))
+}
@@ -7,12 +7,13 @@ import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/shepherd/clients/rancher"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/clustertypes"
 	"github.com/rancher/tfp-automation/defaults/configs"
 	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework/set/backend"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/custom/locals"
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
 	"github.com/sirupsen/logrus"
 )
 
@@ -20,67 +21,79 @@ import (
 func ConfigTF(client *rancher.Client, rancherConfig *rancher.Config, terratestConfig *config.TerratestConfig, testUser, testPassword string,
 	rbacRole config.Role, configMap []map[string]any, newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File, isWindows, persistClusters,
 	customModule bool, customClusterNames []string) ([]string, []string, error) {
-	var err error
-
-	clusterNames := []string{}
-	containsCustomModule := false
+	err := backend.ValidateBackend(terratestConfig.Backend)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	if !persistClusters {
 		newFile.Body().Clear()
+		secrets.Reset(newFile)
 	}
 
 	if !strings.Contains(string(newFile.Bytes()), defaults.RequiredProviders) {
 		newFile, rootBody = rancher2.SetProvidersAndUsersTF(rancherConfig, testUser, testPassword, false, newFile, rootBody, configMap, customModule)
 	}
 
-	rootBody.AppendNewline()
+	clusterNames, customClusterNames, err := setClusters(client, rbacRole, configMap, newFile, rootBody, file, isWindows, customClusterNames)
+	if err != nil {
+		return clusterNames, customClusterNames, err
+	}
 
-	for i, cattleConfig := range configMap {
-		_, terraformConfig, terratestConfig, _ := config.LoadTFPConfigs(cattleConfig)
+	// The main.tf file is written where the given file lives, which is either the shared module directory or a test workspace.
+	var mainTF string
+	if file != nil {
+		mainTF = file.Name()
+	} else {
+		_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, terratestConfig.PathToRepo, "")
+		mainTF = keyPath + configs.MainTF
+	}
 
-		if strings.Contains(terraformConfig.Module, clustertypes.CUSTOM) || strings.Contains(terraformConfig.Module, defaults.Airgap) {
-			containsCustomModule = true
-		}
+	// // This is needed to ensure there is no duplications in the main.tf file.
+	file, err = os.Create(mainTF)
+	if err != nil {
+		logrus.Infof("Failed to reset/overwrite main.tf file. Error: %v", err)
+		return clusterNames, customClusterNames, err
+	}
 
-		clusterNames = append(clusterNames, terraformConfig.ResourcePrefix)
+	err = secrets.WriteMainTF(file, newFile)
+	if err != nil {
+		logrus.Infof("Failed to write configurations to main.tf file. Error: %v", err)
+		return clusterNames, customClusterNames, err
+	}
 
-		if (strings.Contains(terraformConfig.Module, defaults.Custom) || strings.Contains(terraformConfig.Module, defaults.Airgap)) && !strings.Contains(terraformConfig.Module, clustertypes.RKE1) {
-			customClusterNames = append(customClusterNames, terraformConfig.ResourcePrefix)
-		}
+	return clusterNames, customClusterNames, nil
+}
 
-		if strings.Contains(terraformConfig.Module, clustertypes.AKS) || strings.Contains(terraformConfig.Module, clustertypes.EKS) || strings.Contains(terraformConfig.Module, clustertypes.GKE) {
-			newFile, file, err = HostedClusters(terraformConfig, terratestConfig, newFile, rootBody, file)
-			if err != nil {
-				return clusterNames, nil, err
-			}
-		}
+// setClusters is a helper function that will set the cluster configurations of each config in the configMap.
+func setClusters(client *rancher.Client, rbacRole config.Role, configMap []map[string]any, newFile *hclwrite.File, rootBody *hclwrite.Body,
+	file *os.File, isWindows bool, customClusterNames []string) ([]string, []string, error) {
+	clusterNames := []string{}
+	containsCustomModule := false
 
-		if !strings.Contains(terraformConfig.Module, defaults.Custom) && !strings.Contains(terraformConfig.Module, defaults.Import) && !strings.Contains(terraformConfig.Module, defaults.Airgap) {
-			newFile, file, err = NodeDriverClusters(client, terraformConfig, terratestConfig, rbacRole, newFile, rootBody, file)
-			if err != nil {
-				return clusterNames, nil, err
-			}
-		}
+	rootBody.AppendNewline()
 
-		if strings.Contains(terraformConfig.Module, defaults.Custom) {
-			newFile, file, err = CustomClusters(client, terraformConfig, terratestConfig, newFile, rootBody, file, configMap, isWindows)
-			if err != nil {
-				return clusterNames, nil, err
-			}
+	for i, cattleConfig := range configMap {
+		_, terraformConfig, terratestConfig, _ := config.LoadTFPConfigs(cattleConfig)
+
+		module, err := LookupModule(terraformConfig.Module)
+		if err != nil {
+			return clusterNames, nil, err
 		}
 
-		if strings.Contains(terraformConfig.Module, defaults.Airgap) {
-			newFile, file, err = AirgapClusters(client, terraformConfig, terratestConfig, newFile, rootBody, file, configMap, isWindows)
-			if err != nil {
-				return clusterNames, nil, err
+		if module.Mode == Custom || module.Mode == Airgap {
+			containsCustomModule = true
+
+			if module.Distro != RKE1 {
+				customClusterNames = append(customClusterNames, terraformConfig.ResourcePrefix)
 			}
 		}
 
-		if strings.Contains(terraformConfig.Module, defaults.Import) {
-			newFile, file, err = ImportedClusters(client, terraformConfig, terratestConfig, newFile, rootBody, file, configMap, isWindows)
-			if err != nil {
-				return clusterNames, nil, err
-			}
+		clusterNames = append(clusterNames, terraformConfig.ResourcePrefix)
+
+		newFile, file, err = module.Generate(client, module, terraformConfig, terratestConfig, rbacRole, newFile, rootBody, file, configMap, isWindows)
+		if err != nil {
+			return clusterNames, nil, err
 		}
 
 		if i == len(configMap)-1 && containsCustomModule {
@@ -90,23 +103,13 @@ func ConfigTF(client *rancher.Client, rancherConfig *rancher.Config, terratestCo
 			}
 
 			file, err = locals.SetLocals(rootBody, terraformConfig, terratestConfig, configMap, newFile, file, customClusterNames)
+			if err != nil {
+				return clusterNames, nil, err
+			}
+
 			rootBody.AppendNewline()
 		}
 	}
 
-	// // This is needed to ensure there is no duplications in the main.tf file.
-	_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, terratestConfig.PathToRepo, "")
-	file, err = os.Create(keyPath + configs.MainTF)
-	if err != nil {
-		logrus.Infof("Failed to reset/overwrite main.tf file. Error: %v", err)
-		return clusterNames, customClusterNames, err
-	}
-
-	_, err = file.Write(newFile.Bytes())
-	if err != nil {
-		logrus.Infof("Failed to write configurations to main.tf file. Error: %v", err)
-		return clusterNames, customClusterNames, err
-	}
-
 	return clusterNames, customClusterNames, nil
 }
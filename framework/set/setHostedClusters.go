@@ -2,38 +2,31 @@ package set
 
 import (
 	"os"
-	"strings"
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/shepherd/clients/rancher"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/rancher/tfp-automation/defaults/modules"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/hosted"
 )
 
 // HostedClusters is a function that will set the hosted clusters in the main.tf file.
-func HostedClusters(terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, newFile *hclwrite.File,
-	rootBody *hclwrite.Body, file *os.File) (*hclwrite.File, *os.File, error) {
-	var err error
-
-	if strings.Contains(terraformConfig.Module, clustertypes.AKS) {
-		newFile, file, err = hosted.SetAKS(terraformConfig, terratestConfig, newFile, rootBody, file)
-		if err != nil {
-			return newFile, file, err
-		}
-	}
-
-	if strings.Contains(terraformConfig.Module, clustertypes.EKS) {
-		newFile, file, err = hosted.SetEKS(terraformConfig, terratestConfig, newFile, rootBody, file)
-		if err != nil {
-			return newFile, file, err
-		}
-	}
-
-	if strings.Contains(terraformConfig.Module, clustertypes.GKE) {
-		newFile, file, err = hosted.SetGKE(terraformConfig, terratestConfig, newFile, rootBody, file)
-		if err != nil {
-			return newFile, file, err
-		}
+func HostedClusters(client *rancher.Client, module Module, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
+	rbacRole config.Role, newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File, configMap []map[string]any,
+	isWindows bool) (*hclwrite.File, *os.File, error) {
+	switch module.Name {
+	case modules.AKS:
+		return hosted.SetAKS(terraformConfig, terratestConfig, newFile, rootBody, file)
+	case modules.EKS:
+		return hosted.SetEKS(terraformConfig, terratestConfig, newFile, rootBody, file)
+	case modules.GKE:
+		return hosted.SetGKE(terraformConfig, terratestConfig, newFile, rootBody, file)
+	case modules.AKSImport:
+		return hosted.SetImportedAKS(terraformConfig, terratestConfig, newFile, rootBody, file)
+	case modules.EKSImport:
+		return hosted.SetImportedEKS(terraformConfig, terratestConfig, newFile, rootBody, file)
+	case modules.GKEImport:
+		return hosted.SetImportedGKE(terraformConfig, terratestConfig, newFile, rootBody, file)
 	}
 
 	return newFile, file, nil
@@ -0,0 +1,238 @@
+terraform {
+  required_providers {
+    rancher2 = {
+      source  = "rancher/rancher2"
+      version = "8.0.0"
+    }
+    aws = {
+      source  = "hashicorp/aws"
+      version = "5.95.0"
+    }
+    local = {
+      source  = "hashicorp/local"
+      version = "2.5.2"
+    }
+  }
+}
+
+provider "aws" {
+  region     = "us-east-2"
+  access_key = var.aws_access_key
+  secret_key = var.aws_secret_key
+}
+
+provider "local" {
+}
+
+provider "rancher2" {
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_token
+  insecure  = true
+}
+
+provider "rancher2" {
+  alias     = "admin_user"
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_user_token
+  insecure  = true
+}
+
+
+resource "rancher2_cluster" "tfp" {
+  name = "tfp"
+  rke_config {
+    kubernetes_version = "v1.32.5+rke2r1"
+    network {
+      plugin = "calico"
+    }
+  }
+}
+
+resource "aws_instance" "bastion_tfp" {
+  ami                    = "ami-0123456789"
+  instance_type          = "t3a.xlarge"
+  subnet_id              = "subnet-0123456789"
+  vpc_security_group_ids = ["sg-0123456789"]
+  key_name               = "tfp-key"
+
+  root_block_device {
+    volume_size = 100
+  }
+
+  tags = {
+    Name = "tfp-bastion_tfp"
+  }
+
+  connection {
+    type        = "ssh"
+    user        = "ubuntu"
+    host        = self.public_ip
+    private_key = file("testdata/id_rsa")
+    timeout     = "5m"
+  }
+
+  provisioner "remote-exec" {
+    inline = ["echo Connected!!!"]
+  }
+}
+
+resource "aws_instance" "airgap_node1_tfp" {
+  associate_public_ip_address = false
+  ami                         = "ami-0123456789"
+  instance_type               = "t3a.xlarge"
+  subnet_id                   = "subnet-0123456789"
+  vpc_security_group_ids      = ["sg-0123456789"]
+  key_name                    = "tfp-key"
+
+  root_block_device {
+    volume_size = 100
+  }
+
+  tags = {
+    Name = "tfp-airgap_node1_tfp"
+  }
+
+  connection {
+    type        = "ssh"
+    user        = "ubuntu"
+    host        = self.private_ip
+    private_key = file("testdata/id_rsa")
+    timeout     = "5m"
+  }
+}
+
+resource "aws_instance" "airgap_node2_tfp" {
+  associate_public_ip_address = false
+  ami                         = "ami-0123456789"
+  instance_type               = "t3a.xlarge"
+  subnet_id                   = "subnet-0123456789"
+  vpc_security_group_ids      = ["sg-0123456789"]
+  key_name                    = "tfp-key"
+
+  root_block_device {
+    volume_size = 100
+  }
+
+  tags = {
+    Name = "tfp-airgap_node2_tfp"
+  }
+
+  connection {
+    type        = "ssh"
+    user        = "ubuntu"
+    host        = self.private_ip
+    private_key = file("testdata/id_rsa")
+    timeout     = "5m"
+  }
+}
+
+resource "aws_instance" "airgap_node3_tfp" {
+  associate_public_ip_address = false
+  ami                         = "ami-0123456789"
+  instance_type               = "t3a.xlarge"
+  subnet_id                   = "subnet-0123456789"
+  vpc_security_group_ids      = ["sg-0123456789"]
+  key_name                    = "tfp-key"
+
+  root_block_device {
+    volume_size = 100
+  }
+
+  tags = {
+    Name = "tfp-airgap_node3_tfp"
+  }
+
+  connection {
+    type        = "ssh"
+    user        = "ubuntu"
+    host        = self.private_ip
+    private_key = file("testdata/id_rsa")
+    timeout     = "5m"
+  }
+}
+
+resource "null_resource" "copy_script_to_bastion_tfp" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${aws_instance.bastion_tfp.public_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+      timeout     = "5m"
+    }
+    inline = ["echo '#!/bin/bash\n\nPEM_FILE=$1\nUSER=$2\nGROUP=$3\nNODE_PRIVATE_IP=$4\nREGISTRATION_COMMAND=$5\nREGISTRY=$6\n\necho $${PEM_FILE} | sudo base64 -d > /home/$${USER}/airgap.pem\necho \"$${REGISTRATION_COMMAND}\" > /home/$${USER}/registration_command.txt\nREGISTRATION_COMMAND=$(cat /home/$USER/registration_command.txt)\n\nPEM=/home/$${USER}/airgap.pem\nsudo chmod 600 $${PEM}\nsudo chown $${USER}:$${GROUP} $${PEM}\n\nrunSSH() {\n  local server=\"$1\"\n  local cmd=\"$2\"\n\n  ssh -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null -o ConnectTimeout=300 -o ServerAliveInterval=30 -o ServerAliveCountMax=10 -i \"$PEM\" \"$USER@$server\" \\\n  \"export USER=$${USER}; \\\n   export GROUP=$${GROUP}; \\\n   export NODE_PRIVATE_IP=$${NODE_PRIVATE_IP}; \\\n   export REGISTRY=$${REGISTRY}; \\\n   export REGISTRATION_COMMAND=$${REGISTRATION_COMMAND}; \\\n   export REGISTRY=$${REGISTRY}; $cmd\"\n}\n\nsetupDockerDaemon() {\n  echo \"{ \\\"insecure-registries\\\" : [ \\\"$${REGISTRY}\\\" ] }\" | sudo tee /etc/docker/daemon.json > /dev/null\n}\n\ndockerDaemonFunction=$(declare -f setupDockerDaemon)\nrunSSH \"$${NODE_PRIVATE_IP}\" \"$${dockerDaemonFunction}; setupDockerDaemon\"\n\nrunSSH \"$${NODE_PRIVATE_IP}\" \"sudo systemctl daemon-reload && sudo systemctl restart docker\"\nrunSSH \"$${NODE_PRIVATE_IP}\" \"$${REGISTRATION_COMMAND}\"' > /tmp/register-nodes.sh", "chmod +x /tmp/register-nodes.sh", "echo '#!/bin/bash\n\nWINS_PEM_FILE=$1\nUSER=$2\nGROUP=$3\nWINS_USER=$4\nNODE_PRIVATE_IP=$5\nREGISTRATION_COMMAND=$6\nREGISTRY=$7\n\necho $${WINS_PEM_FILE} | base64 -d | sudo tee /home/$${USER}/wins.pem > /dev/null\necho \"powershell.exe $${REGISTRATION_COMMAND}\" > /home/$${USER}/wins_registration_command.txt\necho \"{ \\\"insecure-registries\\\" : [ \\\"$${REGISTRY}\\\" ] }\" | sudo tee /tmp/daemon.json\n\nREGISTRATION_COMMAND=$(cat /home/$USER/wins_registration_command.txt)\nWINS_PEM=/home/$${USER}/wins.pem\n\nsudo chmod 600 $${WINS_PEM}\nsudo chown $${USER}:$${GROUP} $${WINS_PEM}\n\nrunSSH() {\n  local server=\"$1\"\n  local cmd=\"$2\"\n  \n  ssh -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null -o ConnectTimeout=300 -o ServerAliveInterval=30 -o ServerAliveCountMax=10 -o TCPKeepAlive=yes -i \"$WINS_PEM\" \"$WINS_USER@$server\" \\\n  \"$cmd\"\n}\n\nrunSSH \"$${NODE_PRIVATE_IP}\" \"powershell.exe -Command New-Item -Path C:\\\\ProgramData\\\\docker\\\\config -ItemType Directory -Force\"\nrunSSH \"$${NODE_PRIVATE_IP}\" \"icacls C:\\\\ProgramData\\\\docker\\\\config /grant \\\"Everyone:(F)\\\"\"\nscp -i \"$${WINS_PEM}\" -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null /tmp/daemon.json \"$${WINS_USER}@$${NODE_PRIVATE_IP}:C:/ProgramData/docker/config/daemon.json\"\nrunSSH \"$${NODE_PRIVATE_IP}\" \"$${REGISTRATION_COMMAND}\" || true\n\n# Fail-safe sleep to ensure the Windows registration command has time to fully finish\nsleep 70' > /tmp/register-windows-nodes.sh", "chmod +x /tmp/register-windows-nodes.sh"]
+  }
+}
+
+resource "null_resource" "register_airgap_node1_tfp" {
+  depends_on = [null_resource.copy_script_to_bastion_tfp]
+  provisioner "remote-exec" {
+    connection {
+      host        = "${aws_instance.bastion_tfp.public_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+      timeout     = "5m"
+    }
+    inline = ["/tmp/register-nodes.sh ${var.private_key_base64} ubuntu ubuntu ${aws_instance.airgap_node1_tfp.private_ip} \"${rancher2_cluster.tfp.cluster_registration_token[0].node_command} --etcd\" registry.example.com || true"]
+  }
+}
+
+resource "null_resource" "register_airgap_node2_tfp" {
+  depends_on = [null_resource.register_airgap_node1_tfp]
+  provisioner "remote-exec" {
+    connection {
+      host        = "${aws_instance.bastion_tfp.public_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+      timeout     = "5m"
+    }
+    inline = ["/tmp/register-nodes.sh ${var.private_key_base64} ubuntu ubuntu ${aws_instance.airgap_node2_tfp.private_ip} \"${rancher2_cluster.tfp.cluster_registration_token[0].node_command} --controlplane\" registry.example.com || true"]
+  }
+}
+
+resource "null_resource" "register_airgap_node3_tfp" {
+  depends_on = [null_resource.register_airgap_node2_tfp]
+  provisioner "remote-exec" {
+    connection {
+      host        = "${aws_instance.bastion_tfp.public_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+      timeout     = "5m"
+    }
+    inline = ["/tmp/register-nodes.sh ${var.private_key_base64} ubuntu ubuntu ${aws_instance.airgap_node3_tfp.private_ip} \"${rancher2_cluster.tfp.cluster_registration_token[0].node_command} --worker\" registry.example.com || true"]
+  }
+}
+
+locals {
+  role_flags      = ["--etcd", "--controlplane", "--worker"]
+  resource_prefix = [for i in range(3) : "tfp-${i}"]
+}
+
+variable "aws_access_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "aws_secret_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "private_key_base64" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_user_token" {
+  type      = string
+  sensitive = true
+}
+
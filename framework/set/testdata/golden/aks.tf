@@ -0,0 +1,96 @@
+terraform {
+  required_providers {
+    rancher2 = {
+      source  = "rancher/rancher2"
+      version = "8.0.0"
+    }
+  }
+}
+
+provider "rancher2" {
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_token
+  insecure  = true
+}
+
+provider "rancher2" {
+  alias     = "admin_user"
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_user_token
+  insecure  = true
+}
+
+
+resource "rancher2_cloud_credential" "tfp" {
+  name = "tfp"
+  azure_credential_config {
+    client_id       = "azure-client-id"
+    client_secret   = var.azure_client_secret
+    subscription_id = "azure-subscription-id"
+    tenant_id       = "azure-tenant-id"
+  }
+}
+
+resource "rancher2_cluster" "tfp" {
+  name = "tfp"
+  aks_config_v2 {
+    cloud_credential_id        = rancher2_cloud_credential.tfp.id
+    outbound_type              = "LoadBalancer"
+    resource_group             = "tfp-resource-group"
+    resource_location          = "westus"
+    dns_prefix                 = "tfp"
+    kubernetes_version         = "v1.32.5+rke2r1"
+    network_plugin             = "azure"
+    virtual_network            = "tfp-vnet"
+    subnet                     = "tfp-subnet"
+    network_dns_service_ip     = "10.0.0.10"
+    network_docker_bridge_cidr = "172.17.0.1/16"
+    network_service_cidr       = "10.0.0.0/16"
+    node_pools {
+      availability_zones   = ["1", "2", "3"]
+      mode                 = "System"
+      name                 = "agentpool"
+      count                = 1
+      orchestrator_version = "v1.32.5+rke2r1"
+      os_disk_size_gb      = 128
+      vm_size              = "Standard_DS2_v2"
+      taints               = ["none:PreferNoSchedule"]
+    }
+    node_pools {
+      availability_zones   = ["1", "2", "3"]
+      mode                 = "System"
+      name                 = "agentpool1"
+      count                = 1
+      orchestrator_version = "v1.32.5+rke2r1"
+      os_disk_size_gb      = 128
+      vm_size              = "Standard_DS2_v2"
+      taints               = ["none:PreferNoSchedule"]
+    }
+    node_pools {
+      availability_zones   = ["1", "2", "3"]
+      mode                 = "System"
+      name                 = "agentpool2"
+      count                = 1
+      orchestrator_version = "v1.32.5+rke2r1"
+      os_disk_size_gb      = 128
+      vm_size              = "Standard_DS2_v2"
+      taints               = ["none:PreferNoSchedule"]
+    }
+  }
+}
+
+variable "azure_client_secret" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_user_token" {
+  type      = string
+  sensitive = true
+}
+
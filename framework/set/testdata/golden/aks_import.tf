@@ -0,0 +1,70 @@
+terraform {
+  required_providers {
+    rancher2 = {
+      source  = "rancher/rancher2"
+      version = "8.0.0"
+    }
+    local = {
+      source  = "hashicorp/local"
+      version = "2.5.2"
+    }
+  }
+}
+
+provider "rancher2" {
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_token
+  insecure  = true
+}
+
+provider "rancher2" {
+  alias     = "admin_user"
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_user_token
+  insecure  = true
+}
+
+
+resource "rancher2_cloud_credential" "tfp" {
+  name = "tfp"
+  azure_credential_config {
+    client_id       = "azure-client-id"
+    client_secret   = var.azure_client_secret
+    subscription_id = "azure-subscription-id"
+    tenant_id       = "azure-tenant-id"
+  }
+}
+
+resource "rancher2_cluster" "tfp" {
+  name = "tfp"
+  aks_config_v2 {
+    cloud_credential_id = rancher2_cloud_credential.tfp.id
+    name                = "existing-cluster"
+    resource_group      = "tfp-resource-group"
+    resource_location   = "westus"
+    imported            = true
+    node_pools {
+      name            = "existing-pool"
+      count           = 3
+      mode            = "System"
+      vm_size         = "Standard_DS2_v2"
+      os_disk_size_gb = 128
+    }
+  }
+}
+
+variable "azure_client_secret" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_user_token" {
+  type      = string
+  sensitive = true
+}
+
@@ -0,0 +1,122 @@
+terraform {
+  required_providers {
+    rancher2 = {
+      source  = "rancher/rancher2"
+      version = "8.0.0"
+    }
+  }
+}
+
+provider "rancher2" {
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_token
+  insecure  = true
+}
+
+provider "rancher2" {
+  alias     = "admin_user"
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_user_token
+  insecure  = true
+}
+
+
+resource "rancher2_cloud_credential" "tfp" {
+  name = "tfp"
+  azure_credential_config {
+    client_id       = "azure-client-id"
+    client_secret   = var.azure_client_secret
+    subscription_id = "azure-subscription-id"
+    environment     = "AzurePublicCloud"
+    tenant_id       = "azure-tenant-id"
+  }
+}
+
+resource "rancher2_machine_config_v2" "tfp" {
+  generate_name = "tfp"
+  azure_config {
+    availability_set    = "docker-machine"
+    custom_data         = ""
+    disk_size           = "100"
+    fault_domain_count  = ""
+    image               = "canonical:UbuntuServer:22.04-LTS:latest"
+    location            = "westus"
+    managed_disks       = false
+    no_public_ip        = false
+    open_port           = ["6443/tcp", "2379/tcp", "2380/tcp", "8472/udp"]
+    private_ip_address  = ""
+    resource_group      = "tfp-resource-group"
+    size                = "Standard_D2_v2"
+    ssh_user            = "azureuser"
+    static_public_ip    = false
+    storage_type        = "Standard_LRS"
+    update_domain_count = ""
+    use_private_ip      = false
+  }
+}
+
+resource "rancher2_cluster_v2" "tfp" {
+  name                                                       = "tfp"
+  kubernetes_version                                         = "v1.32.5+rke2r1"
+  enable_network_policy                                      = false
+  default_pod_security_admission_configuration_template_name = ""
+  default_cluster_role_for_project_members                   = "true"
+  rke_config {
+    machine_global_config = <<EOF
+cni: calico
+disable-kube-proxy: 
+EOF
+    machine_pools {
+      name                         = "tfp0"
+      cloud_credential_secret_name = rancher2_cloud_credential.tfp.id
+      control_plane_role           = false
+      etcd_role                    = true
+      worker_role                  = false
+      quantity                     = 1
+      machine_config {
+        kind = rancher2_machine_config_v2.tfp.kind
+        name = rancher2_machine_config_v2.tfp.name
+      }
+    }
+    machine_pools {
+      name                         = "tfp1"
+      cloud_credential_secret_name = rancher2_cloud_credential.tfp.id
+      control_plane_role           = true
+      etcd_role                    = false
+      worker_role                  = false
+      quantity                     = 1
+      machine_config {
+        kind = rancher2_machine_config_v2.tfp.kind
+        name = rancher2_machine_config_v2.tfp.name
+      }
+    }
+    machine_pools {
+      name                         = "tfp2"
+      cloud_credential_secret_name = rancher2_cloud_credential.tfp.id
+      control_plane_role           = false
+      etcd_role                    = false
+      worker_role                  = true
+      quantity                     = 1
+      machine_config {
+        kind = rancher2_machine_config_v2.tfp.kind
+        name = rancher2_machine_config_v2.tfp.name
+      }
+    }
+  }
+}
+
+variable "azure_client_secret" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_user_token" {
+  type      = string
+  sensitive = true
+}
+
@@ -0,0 +1,121 @@
+terraform {
+  required_providers {
+    rancher2 = {
+      source  = "rancher/rancher2"
+      version = "8.0.0"
+    }
+  }
+}
+
+provider "rancher2" {
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_token
+  insecure  = true
+}
+
+provider "rancher2" {
+  alias     = "admin_user"
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_user_token
+  insecure  = true
+}
+
+
+resource "rancher2_node_template" "tfp" {
+  name                     = "tfp"
+  engine_insecure_registry = ["registry.example.com"]
+  azure_config {
+    availability_set    = "docker-machine"
+    client_id           = "azure-client-id"
+    client_secret       = var.azure_client_secret
+    subscription_id     = "azure-subscription-id"
+    environment         = "AzurePublicCloud"
+    custom_data         = ""
+    disk_size           = "100"
+    fault_domain_count  = ""
+    image               = "canonical:UbuntuServer:22.04-LTS:latest"
+    location            = "westus"
+    managed_disks       = false
+    no_public_ip        = false
+    open_port           = ["6443/tcp", "2379/tcp", "2380/tcp", "8472/udp"]
+    private_ip_address  = ""
+    resource_group      = "tfp-resource-group"
+    size                = "Standard_D2_v2"
+    ssh_user            = "azureuser"
+    static_public_ip    = false
+    storage_type        = "Standard_LRS"
+    update_domain_count = ""
+    use_private_ip      = false
+  }
+}
+
+resource "rancher2_cluster" "tfp" {
+  depends_on                                                 = [rancher2_node_template.tfp]
+  name                                                       = "tfp"
+  default_pod_security_admission_configuration_template_name = ""
+  rke_config {
+    kubernetes_version = "v1.32.5+rke2r1"
+    network {
+      plugin = "calico"
+    }
+  }
+}
+
+resource "rancher2_node_pool" "tfpnode-pool0" {
+  depends_on       = [rancher2_cluster.tfp]
+  cluster_id       = rancher2_cluster.tfp.id
+  name             = "tfp0"
+  hostname_prefix  = "tfp-pool0"
+  node_template_id = rancher2_node_template.tfp.id
+  quantity         = 1
+  control_plane    = false
+  etcd             = true
+  worker           = false
+}
+
+resource "rancher2_node_pool" "tfpnode-pool1" {
+  depends_on       = [rancher2_cluster.tfp]
+  cluster_id       = rancher2_cluster.tfp.id
+  name             = "tfp1"
+  hostname_prefix  = "tfp-pool1"
+  node_template_id = rancher2_node_template.tfp.id
+  quantity         = 1
+  control_plane    = true
+  etcd             = false
+  worker           = false
+}
+
+resource "rancher2_node_pool" "tfpnode-pool2" {
+  depends_on       = [rancher2_cluster.tfp]
+  cluster_id       = rancher2_cluster.tfp.id
+  name             = "tfp2"
+  hostname_prefix  = "tfp-pool2"
+  node_template_id = rancher2_node_template.tfp.id
+  quantity         = 1
+  control_plane    = false
+  etcd             = false
+  worker           = true
+}
+
+resource "rancher2_cluster_sync" "tfp" {
+  cluster_id    = rancher2_cluster.tfp.id
+  node_pool_ids = []
+  state_confirm = 2
+}
+
+
+variable "azure_client_secret" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_user_token" {
+  type      = string
+  sensitive = true
+}
+
@@ -0,0 +1,209 @@
+terraform {
+  required_providers {
+    rancher2 = {
+      source  = "rancher/rancher2"
+      version = "8.0.0"
+    }
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "5.95.0"
+    }
+    local = {
+      source  = "hashicorp/local"
+      version = "2.5.2"
+    }
+  }
+}
+
+provider "azurerm" {
+  features {
+  }
+  client_id       = "azure-client-id"
+  client_secret   = var.azure_client_secret
+  subscription_id = "azure-subscription-id"
+  tenant_id       = "azure-tenant-id"
+}
+
+provider "local" {
+}
+
+provider "rancher2" {
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_token
+  insecure  = true
+}
+
+provider "rancher2" {
+  alias     = "admin_user"
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_user_token
+  insecure  = true
+}
+
+
+data "azurerm_resource_group" "tfp" {
+  name = "tfp-resource-group"
+}
+
+data "azurerm_subnet" "tfp" {
+  name                 = "tfp-subnet"
+  virtual_network_name = "tfp-vnet"
+  resource_group_name  = "tfp-resource-group"
+}
+
+data "azurerm_network_security_group" "tfp" {
+  name                = "tfp-nsg"
+  resource_group_name = "tfp-resource-group"
+}
+
+resource "azurerm_public_ip" "tfp" {
+  count               = 3
+  name                = "tfp-tfp-${count.index}"
+  resource_group_name = "tfp-resource-group"
+  location            = data.azurerm_resource_group.tfp.location
+  allocation_method   = "Static"
+  sku                 = "Standard"
+}
+
+resource "azurerm_network_interface" "tfp" {
+  count               = 3
+  name                = "tfp-tfp-${count.index}"
+  resource_group_name = "tfp-resource-group"
+  location            = data.azurerm_resource_group.tfp.location
+  ip_configuration {
+    name                          = "internal"
+    subnet_id                     = data.azurerm_subnet.tfp.id
+    private_ip_address_allocation = "Dynamic"
+    public_ip_address_id          = azurerm_public_ip.tfp[count.index].id
+  }
+}
+
+resource "azurerm_network_interface_security_group_association" "tfp" {
+  count                     = 3
+  network_interface_id      = azurerm_network_interface.tfp[count.index].id
+  network_security_group_id = data.azurerm_network_security_group.tfp.id
+}
+
+resource "azurerm_linux_virtual_machine" "tfp" {
+  count                 = 3
+  name                  = "tfp-tfp-${count.index}"
+  resource_group_name   = "tfp-resource-group"
+  location              = data.azurerm_resource_group.tfp.location
+  size                  = "Standard_D2_v2"
+  admin_username        = "azureuser"
+  network_interface_ids = [azurerm_network_interface.tfp[count.index].id]
+  depends_on            = [azurerm_network_interface_security_group_association.tfp]
+
+  admin_ssh_key {
+    username   = "azureuser"
+    public_key = file("testdata/id_rsa.pub")
+  }
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+    disk_size_gb         = 100
+  }
+
+  source_image_reference {
+    publisher = "canonical"
+    offer     = "UbuntuServer"
+    sku       = "22.04-LTS"
+    version   = "latest"
+  }
+
+  connection {
+    type        = "ssh"
+    user        = "azureuser"
+    host        = self.public_ip_address
+    private_key = file("testdata/id_rsa")
+  }
+
+  provisioner "remote-exec" {
+    inline = ["echo Connected!!!"]
+  }
+}
+
+resource "rancher2_cluster_v2" "tfp" {
+  name               = "tfp"
+  kubernetes_version = "v1.32.5+rke2r1"
+  rke_config {
+    machine_global_config = <<EOF
+cni: calico
+EOF
+    machine_selector_config {
+      config = <<EOF
+system-default-registry: registry.example.com
+EOF
+    }
+    registries {
+      configs {
+        hostname                = "registry.example.com"
+        auth_config_secret_name = "registry-auth-tfp"
+        tls_secret_name         = ""
+        ca_bundle               = ""
+        insecure                = true
+      }
+      mirrors {
+        hostname  = "docker.io"
+        endpoints = ["https://registry.example.com"]
+      }
+    }
+  }
+}
+
+resource "rancher2_secret_v2" "tfp" {
+  provider   = rancher2.admin_user
+  cluster_id = "local"
+  name       = "registry-auth-tfp"
+  namespace  = "fleet-default"
+  type       = "kubernetes.io/basic-auth"
+  data = {
+    password = var.registry_password
+    username = "registry-user"
+  }
+}
+
+resource "null_resource" "register_nodes-tfp" {
+  count = length(azurerm_linux_virtual_machine.tfp)
+  provisioner "remote-exec" {
+    inline = ["${local.tfp_insecure_node_command} ${local.role_flags[count.index]} --node-name ${local.resource_prefix[count.index]}"]
+    connection {
+      type        = "ssh"
+      user        = "azureuser"
+      host        = "${azurerm_linux_virtual_machine.tfp[count.index].public_ip_address}"
+      private_key = file("testdata/id_rsa")
+    }
+  }
+  depends_on = [rancher2_cluster_v2.tfp]
+}
+
+locals {
+  role_flags                        = ["--etcd", "--controlplane", "--worker"]
+  resource_prefix                   = [for i in range(3) : "tfp-${i}"]
+  tfp_original_node_command         = rancher2_cluster_v2.tfp.cluster_registration_token[0].node_command
+  tfp_windows_original_node_command = rancher2_cluster_v2.tfp.cluster_registration_token[0].windows_node_command
+  tfp_insecure_node_command         = "${replace(local.tfp_original_node_command, "curl", "curl --insecure")}"
+  tfp_insecure_windows_node_command = "${replace(local.tfp_windows_original_node_command, "curl.exe", "curl.exe --insecure")}"
+}
+
+variable "azure_client_secret" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_user_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "registry_password" {
+  type      = string
+  sensitive = true
+}
+
@@ -0,0 +1,156 @@
+terraform {
+  required_providers {
+    rancher2 = {
+      source  = "rancher/rancher2"
+      version = "8.0.0"
+    }
+    local = {
+      source  = "hashicorp/local"
+      version = "2.5.2"
+    }
+  }
+}
+
+provider "rancher2" {
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_token
+  insecure  = true
+}
+
+provider "rancher2" {
+  alias     = "admin_user"
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_user_token
+  insecure  = true
+}
+
+
+resource "rancher2_cloud_credential" "tfp" {
+  name = "tfp"
+  amazonec2_credential_config {
+    access_key = var.aws_access_key
+    secret_key = var.aws_secret_key
+  }
+}
+
+resource "rancher2_machine_config_v2" "tfp" {
+  generate_name = "tfp"
+  amazonec2_config {
+    region         = "us-east-2"
+    ami            = "ami-0123456789"
+    instance_type  = "t3a.xlarge"
+    ssh_user       = "ubuntu"
+    volume_type    = "gp3"
+    root_size      = 100
+    security_group = ["tfp-sg"]
+    subnet_id      = "subnet-0123456789"
+    vpc_id         = "vpc-0123456789"
+    zone           = "a"
+  }
+}
+
+resource "rancher2_cluster_v2" "tfp" {
+  name                                                       = "tfp"
+  kubernetes_version                                         = "v1.32.5+rke2r1"
+  enable_network_policy                                      = false
+  default_pod_security_admission_configuration_template_name = ""
+  default_cluster_role_for_project_members                   = "true"
+  rke_config {
+    machine_global_config = <<EOF
+cni: calico
+disable-kube-proxy: 
+EOF
+    machine_pools {
+      name                         = "tfp0"
+      cloud_credential_secret_name = rancher2_cloud_credential.tfp.id
+      control_plane_role           = false
+      etcd_role                    = true
+      worker_role                  = false
+      quantity                     = 1
+      machine_config {
+        kind = rancher2_machine_config_v2.tfp.kind
+        name = rancher2_machine_config_v2.tfp.name
+      }
+    }
+    machine_pools {
+      name                         = "tfp1"
+      cloud_credential_secret_name = rancher2_cloud_credential.tfp.id
+      control_plane_role           = true
+      etcd_role                    = false
+      worker_role                  = false
+      quantity                     = 1
+      machine_config {
+        kind = rancher2_machine_config_v2.tfp.kind
+        name = rancher2_machine_config_v2.tfp.name
+      }
+    }
+    machine_pools {
+      name                         = "tfp2"
+      cloud_credential_secret_name = rancher2_cloud_credential.tfp.id
+      control_plane_role           = false
+      etcd_role                    = false
+      worker_role                  = true
+      quantity                     = 1
+      machine_config {
+        kind = rancher2_machine_config_v2.tfp.kind
+        name = rancher2_machine_config_v2.tfp.name
+      }
+    }
+    machine_selector_config {
+      config = <<EOF
+system-default-registry: registry.example.com
+EOF
+    }
+    registries {
+      configs {
+        hostname                = "registry.example.com"
+        auth_config_secret_name = "registry-auth-tfp"
+        tls_secret_name         = ""
+        ca_bundle               = ""
+        insecure                = true
+      }
+      mirrors {
+        hostname  = "docker.io"
+        endpoints = ["https://registry.example.com"]
+      }
+    }
+  }
+}
+
+resource "rancher2_secret_v2" "tfp" {
+  provider   = rancher2.admin_user
+  cluster_id = "local"
+  name       = "registry-auth-tfp"
+  namespace  = "fleet-default"
+  type       = "kubernetes.io/basic-auth"
+  data = {
+    password = var.registry_password
+    username = "registry-user"
+  }
+}
+
+variable "aws_access_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "aws_secret_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_user_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "registry_password" {
+  type      = string
+  sensitive = true
+}
+
@@ -0,0 +1,259 @@
+terraform {
+  required_providers {
+    rancher2 = {
+      source  = "rancher/rancher2"
+      version = "8.0.0"
+    }
+    aws = {
+      source  = "hashicorp/aws"
+      version = "5.95.0"
+    }
+    local = {
+      source  = "hashicorp/local"
+      version = "2.5.2"
+    }
+  }
+}
+
+provider "aws" {
+  region     = "us-east-2"
+  access_key = var.aws_access_key
+  secret_key = var.aws_secret_key
+}
+
+provider "local" {
+}
+
+provider "rancher2" {
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_token
+  insecure  = true
+}
+
+provider "rancher2" {
+  alias     = "admin_user"
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_user_token
+  insecure  = true
+}
+
+
+resource "rancher2_cluster" "tfp" {
+  name        = "tfp"
+  description = "tfp-automation imported cluster"
+}
+
+resource "aws_instance" "tfp_server1" {
+  ami                    = "ami-0123456789"
+  instance_type          = "t3a.xlarge"
+  subnet_id              = "subnet-0123456789"
+  vpc_security_group_ids = ["sg-0123456789"]
+  key_name               = "tfp-key"
+
+  root_block_device {
+    volume_size = 100
+  }
+
+  tags = {
+    Name = "tfp-tfp_server1"
+  }
+
+  connection {
+    type        = "ssh"
+    user        = "ubuntu"
+    host        = self.public_ip
+    private_key = file("testdata/id_rsa")
+    timeout     = "5m"
+  }
+
+  provisioner "remote-exec" {
+    inline = ["echo Connected!!!"]
+  }
+}
+
+resource "aws_instance" "tfp_server2" {
+  ami                    = "ami-0123456789"
+  instance_type          = "t3a.xlarge"
+  subnet_id              = "subnet-0123456789"
+  vpc_security_group_ids = ["sg-0123456789"]
+  key_name               = "tfp-key"
+
+  root_block_device {
+    volume_size = 100
+  }
+
+  tags = {
+    Name = "tfp-tfp_server2"
+  }
+
+  connection {
+    type        = "ssh"
+    user        = "ubuntu"
+    host        = self.public_ip
+    private_key = file("testdata/id_rsa")
+    timeout     = "5m"
+  }
+
+  provisioner "remote-exec" {
+    inline = ["echo Connected!!!"]
+  }
+}
+
+resource "aws_instance" "tfp_server3" {
+  ami                    = "ami-0123456789"
+  instance_type          = "t3a.xlarge"
+  subnet_id              = "subnet-0123456789"
+  vpc_security_group_ids = ["sg-0123456789"]
+  key_name               = "tfp-key"
+
+  root_block_device {
+    volume_size = 100
+  }
+
+  tags = {
+    Name = "tfp-tfp_server3"
+  }
+
+  connection {
+    type        = "ssh"
+    user        = "ubuntu"
+    host        = self.public_ip
+    private_key = file("testdata/id_rsa")
+    timeout     = "5m"
+  }
+
+  provisioner "remote-exec" {
+    inline = ["echo Connected!!!"]
+  }
+}
+
+resource "null_resource" "tfp_copy_script_server1" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${aws_instance.tfp_server1.public_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["echo '#!/bin/bash\n\nUSER=$1\nGROUP=$2\nK8S_VERSION=$3\nK3S_SERVER_IP=$4\nK3S_TOKEN=$5\nREGISTRY_USERNAME=$6\nREGISTRY_PASSWORD=$7\n\nset -e\n\nsudo hostnamectl set-hostname $${K3S_SERVER_IP}\n\nsudo mkdir -p /etc/rancher/k3s\nsudo touch /etc/rancher/k3s/registries.yaml\n\necho \"mirrors:\n  docker.io:\n    endpoint:\n      - \"https://registry-1.docker.io\"\nconfigs:\n  \"registry-1.docker.io\":\n    auth:\n      username: \"$${REGISTRY_USERNAME}\"\n      password: \"$${REGISTRY_PASSWORD}\"\n  \"docker.io\":\n    auth:\n      username: \"$${REGISTRY_USERNAME}\"\n      password: \"$${REGISTRY_PASSWORD}\"\" | sudo tee -a /etc/rancher/k3s/registries.yaml > /dev/null\n\ncurl -sfL https://get.k3s.io | INSTALL_K3S_VERSION=$${K8S_VERSION} K3S_TOKEN=$${K3S_TOKEN} sh -s - server --cluster-init\n\nsudo mkdir -p /home/$${USER}/.kube\nsudo chown $${USER}:$${GROUP} /etc/rancher/k3s/k3s.yaml\nsudo cp /etc/rancher/k3s/k3s.yaml /home/$${USER}/.kube/config\nsudo chown $${USER}:$${GROUP} /home/$${USER}/.kube/config' > /tmp/init-server.sh", "chmod +x /tmp/init-server.sh"]
+  }
+  depends_on = [aws_instance.tfp_server1, aws_instance.tfp_server2, aws_instance.tfp_server3]
+}
+
+resource "null_resource" "tfp_create_cluster" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${aws_instance.tfp_server1.public_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["bash -c '/tmp/init-server.sh ubuntu ubuntu v1.32.5+k3s1 ${aws_instance.tfp_server1.private_ip} auto-import-xxxxx' || true"]
+  }
+  depends_on = [null_resource.tfp_copy_script_server1]
+}
+
+resource "null_resource" "tfp_server2" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${aws_instance.tfp_server2.public_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["echo '#!/bin/bash\n\nUSER=$1\nGROUP=$2\nK8S_VERSION=$3\nK3S_SERVER_IP=$4\nK3S_NEW_SERVER_IP=$5\nK3S_TOKEN=$6\nREGISTRY_USERNAME=$7\nREGISTRY_PASSWORD=$8\n\nset -e\n\nsudo hostnamectl set-hostname $${K3S_NEW_SERVER_IP}\n\nsudo mkdir -p /etc/rancher/k3s\nsudo touch /etc/rancher/k3s/registries.yaml\n\necho \"mirrors:\n  docker.io:\n    endpoint:\n      - \"https://registry-1.docker.io\"\nconfigs:\n  \"registry-1.docker.io\":\n    auth:\n      username: \"$${REGISTRY_USERNAME}\"\n      password: \"$${REGISTRY_PASSWORD}\"\n  \"docker.io\":\n    auth:\n      username: \"$${REGISTRY_USERNAME}\"\n      password: \"$${REGISTRY_PASSWORD}\"\" | sudo tee -a /etc/rancher/k3s/registries.yaml > /dev/null\n\ncurl -sfL https://get.k3s.io | INSTALL_K3S_VERSION=$${K8S_VERSION} K3S_TOKEN=$${K3S_TOKEN} sh -s - server --server https://$${K3S_SERVER_IP}:6443' > /tmp/add-servers.sh", "chmod +x /tmp/add-servers.sh"]
+  }
+  depends_on = [null_resource.tfp_create_cluster]
+}
+
+resource "null_resource" "add_server_tfp_server2" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${aws_instance.tfp_server2.public_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["bash -c '/tmp/add-servers.sh ubuntu ubuntu v1.32.5+k3s1 ${aws_instance.tfp_server1.private_ip} ${aws_instance.tfp_server2.public_ip} auto-import-xxxxx' || true"]
+  }
+  depends_on = [null_resource.tfp_server2]
+}
+
+resource "null_resource" "tfp_server3" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${aws_instance.tfp_server3.public_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["echo '#!/bin/bash\n\nUSER=$1\nGROUP=$2\nK8S_VERSION=$3\nK3S_SERVER_IP=$4\nK3S_NEW_SERVER_IP=$5\nK3S_TOKEN=$6\nREGISTRY_USERNAME=$7\nREGISTRY_PASSWORD=$8\n\nset -e\n\nsudo hostnamectl set-hostname $${K3S_NEW_SERVER_IP}\n\nsudo mkdir -p /etc/rancher/k3s\nsudo touch /etc/rancher/k3s/registries.yaml\n\necho \"mirrors:\n  docker.io:\n    endpoint:\n      - \"https://registry-1.docker.io\"\nconfigs:\n  \"registry-1.docker.io\":\n    auth:\n      username: \"$${REGISTRY_USERNAME}\"\n      password: \"$${REGISTRY_PASSWORD}\"\n  \"docker.io\":\n    auth:\n      username: \"$${REGISTRY_USERNAME}\"\n      password: \"$${REGISTRY_PASSWORD}\"\" | sudo tee -a /etc/rancher/k3s/registries.yaml > /dev/null\n\ncurl -sfL https://get.k3s.io | INSTALL_K3S_VERSION=$${K8S_VERSION} K3S_TOKEN=$${K3S_TOKEN} sh -s - server --server https://$${K3S_SERVER_IP}:6443' > /tmp/add-servers.sh", "chmod +x /tmp/add-servers.sh"]
+  }
+  depends_on = [null_resource.tfp_create_cluster]
+}
+
+resource "null_resource" "add_server_tfp_server3" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${aws_instance.tfp_server3.public_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["bash -c '/tmp/add-servers.sh ubuntu ubuntu v1.32.5+k3s1 ${aws_instance.tfp_server1.private_ip} ${aws_instance.tfp_server3.public_ip} auto-import-xxxxx' || true"]
+  }
+  depends_on = [null_resource.tfp_server3]
+}
+
+
+resource "null_resource" "tfp_copy_script" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${aws_instance.tfp_server1.public_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["echo '#!/bin/bash\n\nPEM_FILE=$1\nUSER=$2\nGROUP=$3\nNODE_ONE_PUBLIC_DNS=$4\nIMPORT_COMMAND=$5\nRKE_KUBE_CONFIG_FILE=$${6}\n\nset -ex\n\nARCH=$(uname -m)\nif [[ $ARCH == \"x86_64\" ]]; then\n    ARCH=\"amd64\"\nelif [[ $ARCH == \"arm64\" || $ARCH == \"aarch64\" ]]; then\n    ARCH=\"arm64\"\nfi\n\necho \"Installing kubectl\"\ncurl -LO \"https://dl.k8s.io/release/$(curl -L -s https://dl.k8s.io/release/stable.txt)/bin/linux/$${ARCH}/kubectl\"\nsudo install -o root -g root -m 0755 kubectl /usr/local/bin/kubectl\nmkdir -p ~/.kube\nrm kubectl\n\nif [ -n \"$${RKE_KUBE_CONFIG_FILE}\" ]; then\n    echo \"$${RKE_KUBE_CONFIG_FILE}\" > /home/$USER/.kube/config\nfi\n\necho $${PEM_FILE} | sudo base64 -d > /home/$${USER}/key.pem\necho \"$${IMPORT_COMMAND}\" > /home/$${USER}/import_command.txt\nIMPORT_COMMAND=$(cat /home/$USER/import_command.txt)\n\nPEM=/home/$${USER}/key.pem\nsudo chmod 600 $${PEM}\nsudo chown $${USER}:$${GROUP} $${PEM}\n\neval \"$IMPORT_COMMAND\"' > /tmp/import-nodes.sh", "chmod +x /tmp/import-nodes.sh"]
+  }
+  depends_on = [null_resource.add_server_tfp_server2, null_resource.add_server_tfp_server3]
+}
+
+resource "null_resource" "tfp_import_cluster" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${aws_instance.tfp_server1.public_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["bash -c '/tmp/import-nodes.sh ${var.private_key_base64} ubuntu ubuntu ${aws_instance.tfp_server1.public_ip} \"${rancher2_cluster.tfp.cluster_registration_token[0].insecure_command}\"'"]
+  }
+  depends_on = [null_resource.tfp_copy_script]
+}
+
+variable "aws_access_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "aws_secret_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "private_key_base64" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_user_token" {
+  type      = string
+  sensitive = true
+}
+
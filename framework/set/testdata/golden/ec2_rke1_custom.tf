@@ -0,0 +1,115 @@
+terraform {
+  required_providers {
+    rancher2 = {
+      source  = "rancher/rancher2"
+      version = "8.0.0"
+    }
+    aws = {
+      source  = "hashicorp/aws"
+      version = "5.95.0"
+    }
+    local = {
+      source  = "hashicorp/local"
+      version = "2.5.2"
+    }
+  }
+}
+
+provider "aws" {
+  region     = "us-east-2"
+  access_key = var.aws_access_key
+  secret_key = var.aws_secret_key
+}
+
+provider "local" {
+}
+
+provider "rancher2" {
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_token
+  insecure  = true
+}
+
+provider "rancher2" {
+  alias     = "admin_user"
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_user_token
+  insecure  = true
+}
+
+
+resource "aws_instance" "tfp" {
+  count                  = 3
+  ami                    = "ami-0123456789"
+  instance_type          = "t3a.xlarge"
+  subnet_id              = "subnet-0123456789"
+  vpc_security_group_ids = ["sg-0123456789"]
+  key_name               = "tfp-key"
+
+  root_block_device {
+    volume_size = 100
+  }
+
+  tags = {
+    Name = "tfp-tfp-${count.index}"
+  }
+
+  connection {
+    type        = "ssh"
+    user        = "ubuntu"
+    host        = self.public_ip
+    private_key = file("testdata/id_rsa")
+    timeout     = "5m"
+  }
+
+  provisioner "remote-exec" {
+    inline = ["echo Connected!!!"]
+  }
+}
+resource "rancher2_cluster" "tfp" {
+  name = "tfp"
+  rke_config {
+    kubernetes_version = "v1.32.5+rke2r1"
+    network {
+      plugin = "calico"
+    }
+  }
+}
+resource "null_resource" "register_nodes-tfp" {
+  count = length(aws_instance.tfp)
+  provisioner "remote-exec" {
+    inline = ["${rancher2_cluster.tfp.cluster_registration_token[0].node_command} ${local.role_flags[count.index]} --node-name ${local.resource_prefix[count.index]}"]
+    connection {
+      type        = "ssh"
+      user        = "ubuntu"
+      host        = "${aws_instance.tfp[count.index].public_ip}"
+      private_key = file("testdata/id_rsa")
+    }
+  }
+  depends_on = [rancher2_cluster.tfp]
+}
+locals {
+  role_flags      = ["--etcd", "--controlplane", "--worker"]
+  resource_prefix = [for i in range(3) : "tfp-${i}"]
+}
+
+variable "aws_access_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "aws_secret_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_user_token" {
+  type      = string
+  sensitive = true
+}
+
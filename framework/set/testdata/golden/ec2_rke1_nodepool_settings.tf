@@ -0,0 +1,141 @@
+terraform {
+  required_providers {
+    rancher2 = {
+      source  = "rancher/rancher2"
+      version = "8.0.0"
+    }
+    local = {
+      source  = "hashicorp/local"
+      version = "2.5.2"
+    }
+  }
+}
+
+provider "rancher2" {
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_token
+  insecure  = true
+}
+
+provider "rancher2" {
+  alias     = "admin_user"
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_user_token
+  insecure  = true
+}
+
+
+resource "rancher2_node_template" "tfp" {
+  name                     = "tfp"
+  engine_insecure_registry = ["registry.example.com"]
+  amazonec2_config {
+    access_key     = var.aws_access_key
+    secret_key     = var.aws_secret_key
+    region         = "us-east-2"
+    ami            = "ami-0123456789"
+    instance_type  = "t3a.xlarge"
+    ssh_user       = "ubuntu"
+    volume_type    = "gp3"
+    root_size      = 100
+    security_group = ["tfp-sg"]
+    subnet_id      = "subnet-0123456789"
+    vpc_id         = "vpc-0123456789"
+    zone           = "a"
+  }
+}
+
+resource "rancher2_cluster" "tfp" {
+  depends_on                                                 = [rancher2_node_template.tfp]
+  name                                                       = "tfp"
+  default_pod_security_admission_configuration_template_name = ""
+  rke_config {
+    kubernetes_version = "v1.32.5+rke2r1"
+    network {
+      plugin = "calico"
+    }
+    private_registries {
+      url      = "registry.example.com"
+      user     = "registry-user"
+      password = var.registry_password
+    }
+  }
+}
+
+
+resource "rancher2_node_pool" "tfpnode-pool0" {
+  depends_on       = [rancher2_cluster.tfp]
+  cluster_id       = rancher2_cluster.tfp.id
+  name             = "tfp0"
+  hostname_prefix  = "tfp-pool0"
+  node_template_id = rancher2_node_template.tfp.id
+  quantity         = 1
+  control_plane    = false
+  etcd             = true
+  worker           = false
+}
+
+resource "rancher2_node_pool" "tfpnode-pool1" {
+  depends_on       = [rancher2_cluster.tfp]
+  cluster_id       = rancher2_cluster.tfp.id
+  name             = "tfp1"
+  hostname_prefix  = "tfp-pool1"
+  node_template_id = rancher2_node_template.tfp.id
+  quantity         = 1
+  control_plane    = true
+  etcd             = false
+  worker           = false
+}
+
+resource "rancher2_node_pool" "tfpnode-pool2" {
+  depends_on       = [rancher2_cluster.tfp]
+  cluster_id       = rancher2_cluster.tfp.id
+  name             = "tfp2"
+  hostname_prefix  = "tfp-pool2"
+  node_template_id = rancher2_node_template.tfp.id
+  quantity         = 1
+  control_plane    = false
+  etcd             = false
+  worker           = true
+  labels = {
+    "tfp.rancher.io/pool" = "workers"
+  }
+  node_taints {
+    key    = "dedicated"
+    value  = "workers"
+    effect = "NoSchedule"
+  }
+  drain_before_delete = true
+}
+
+resource "rancher2_cluster_sync" "tfp" {
+  cluster_id    = rancher2_cluster.tfp.id
+  node_pool_ids = []
+  state_confirm = 2
+}
+
+
+variable "aws_access_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "aws_secret_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_user_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "registry_password" {
+  type      = string
+  sensitive = true
+}
+
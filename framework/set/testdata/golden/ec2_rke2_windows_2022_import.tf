@@ -0,0 +1,336 @@
+terraform {
+  required_providers {
+    rancher2 = {
+      source  = "rancher/rancher2"
+      version = "8.0.0"
+    }
+    aws = {
+      source  = "hashicorp/aws"
+      version = "5.95.0"
+    }
+    local = {
+      source  = "hashicorp/local"
+      version = "2.5.2"
+    }
+  }
+}
+
+provider "aws" {
+  region     = "us-east-2"
+  access_key = var.aws_access_key
+  secret_key = var.aws_secret_key
+}
+
+provider "local" {
+}
+
+provider "rancher2" {
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_token
+  insecure  = true
+}
+
+provider "rancher2" {
+  alias     = "admin_user"
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_user_token
+  insecure  = true
+}
+
+
+resource "rancher2_cluster" "tfp" {
+  name        = "tfp"
+  description = "tfp-automation imported cluster"
+}
+
+resource "aws_instance" "tfp_server1" {
+  ami                    = "ami-0123456789"
+  instance_type          = "t3a.xlarge"
+  subnet_id              = "subnet-0123456789"
+  vpc_security_group_ids = ["sg-0123456789"]
+  key_name               = "tfp-key"
+
+  root_block_device {
+    volume_size = 100
+  }
+
+  tags = {
+    Name = "tfp-tfp_server1"
+  }
+
+  connection {
+    type        = "ssh"
+    user        = "ubuntu"
+    host        = self.public_ip
+    private_key = file("testdata/id_rsa")
+    timeout     = "5m"
+  }
+
+  provisioner "remote-exec" {
+    inline = ["echo Connected!!!"]
+  }
+}
+
+resource "aws_instance" "tfp_server2" {
+  ami                    = "ami-0123456789"
+  instance_type          = "t3a.xlarge"
+  subnet_id              = "subnet-0123456789"
+  vpc_security_group_ids = ["sg-0123456789"]
+  key_name               = "tfp-key"
+
+  root_block_device {
+    volume_size = 100
+  }
+
+  tags = {
+    Name = "tfp-tfp_server2"
+  }
+
+  connection {
+    type        = "ssh"
+    user        = "ubuntu"
+    host        = self.public_ip
+    private_key = file("testdata/id_rsa")
+    timeout     = "5m"
+  }
+
+  provisioner "remote-exec" {
+    inline = ["echo Connected!!!"]
+  }
+}
+
+resource "aws_instance" "tfp_server3" {
+  ami                    = "ami-0123456789"
+  instance_type          = "t3a.xlarge"
+  subnet_id              = "subnet-0123456789"
+  vpc_security_group_ids = ["sg-0123456789"]
+  key_name               = "tfp-key"
+
+  root_block_device {
+    volume_size = 100
+  }
+
+  tags = {
+    Name = "tfp-tfp_server3"
+  }
+
+  connection {
+    type        = "ssh"
+    user        = "ubuntu"
+    host        = self.public_ip
+    private_key = file("testdata/id_rsa")
+    timeout     = "5m"
+  }
+
+  provisioner "remote-exec" {
+    inline = ["echo Connected!!!"]
+  }
+}
+
+resource "null_resource" "tfp_copy_script_server1" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${aws_instance.tfp_server1.public_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["echo '#!/bin/bash\n\nUSER=$1\nGROUP=$2\nK8S_VERSION=$3\nRKE2_SERVER_IP=$4\nRKE2_TOKEN=$5\nCNI=$6\nREGISTRY_USERNAME=$7\nREGISTRY_PASSWORD=$8\n\nset -e\n\nsudo hostnamectl set-hostname $${RKE2_SERVER_IP}\n\nARCH=$(uname -m)\nif [[ $ARCH == \"x86_64\" ]]; then\n    ARCH=\"amd64\"\nelif [[ $ARCH == \"arm64\" || $ARCH == \"aarch64\" ]]; then\n    ARCH=\"arm64\"\nfi\n\nwget https://github.com/rancher/rke2/releases/download/$${K8S_VERSION}+rke2r1/rke2.linux-$${ARCH}.tar.gz\nwget https://github.com/rancher/rke2/releases/download/$${K8S_VERSION}+rke2r1/rke2-images.linux-$${ARCH}.tar.zst\nwget https://github.com/rancher/rke2/releases/download/$${K8S_VERSION}+rke2r1/sha256sum-$${ARCH}.txt\n\nif [[ \"$${USER}\" == \"root\" ]]; then\n  mkdir -p /home/root\n  mv rke2.linux-$${ARCH}.tar.gz /home/root/\n  mv rke2-images.linux-$${ARCH}.tar.zst /home/root/\n  mv sha256sum-$${ARCH}.txt /home/root/\nfi\n\nsudo mkdir -p /etc/rancher/rke2\nsudo touch /etc/rancher/rke2/config.yaml\n\necho \"cni: $${CNI}\ntoken: $${RKE2_TOKEN}\ntls-san:\n  - $${RKE2_SERVER_IP}\" | sudo tee /etc/rancher/rke2/config.yaml > /dev/null\n\necho \"mirrors:\n  docker.io:\n    endpoint:\n    - \"https://registry-1.docker.io\"\nconfigs:\n  \"registry-1.docker.io\":\n    auth:\n      username: \"$${REGISTRY_USERNAME}\"\n      password: \"$${REGISTRY_PASSWORD}\"\n  \"docker.io\":\n    auth:\n      username: \"$${REGISTRY_USERNAME}\"\n      password: \"$${REGISTRY_PASSWORD}\"\" | sudo tee -a /etc/rancher/rke2/registries.yaml > /dev/null\n\ncurl -sfL https://get.rke2.io --output install.sh\nchmod +x install.sh\n\nsudo INSTALL_RKE2_ARTIFACT_PATH=/home/$${USER} sh install.sh\nsudo systemctl enable rke2-server\nsudo systemctl start rke2-server\n\nif [[ \"$${USER}\" == \"root\" ]]; then\n  sudo mkdir -p /root/.kube\n  sudo cp /etc/rancher/rke2/rke2.yaml /root/.kube/config\nelse\n  sudo mkdir -p /home/$${USER}/.kube\n  sudo cp /etc/rancher/rke2/rke2.yaml /home/$${USER}/.kube/config\n  sudo chown -R $${USER}:$${GROUP} /home/$${USER}/.kube\nfi' > /tmp/init-server.sh", "chmod +x /tmp/init-server.sh"]
+  }
+  depends_on = [aws_instance.tfp_server1, aws_instance.tfp_server2, aws_instance.tfp_server3]
+}
+
+resource "null_resource" "tfp_create_cluster" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${aws_instance.tfp_server1.public_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["bash -c '/tmp/init-server.sh ubuntu ubuntu v1.32.5+rke2r1 ${aws_instance.tfp_server1.private_ip} auto-import-xxxxx calico' || true"]
+  }
+  depends_on = [null_resource.tfp_copy_script_server1]
+}
+
+resource "null_resource" "tfp_server2" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${aws_instance.tfp_server2.public_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["echo '#!/bin/bash\n\nUSER=$1\nK8S_VERSION=$2\nRKE2_SERVER_ONE_IP=$3\nRKE2_NEW_SERVER_IP=$4\nRKE2_TOKEN=$5\nCNI=$6\nREGISTRY_USERNAME=$7\nREGISTRY_PASSWORD=$8\n\nset -e\n\nsudo hostnamectl set-hostname $${RKE2_NEW_SERVER_IP}\n\nARCH=$(uname -m)\nif [[ $ARCH == \"x86_64\" ]]; then\n    ARCH=\"amd64\"\nelif [[ $ARCH == \"arm64\" || $ARCH == \"aarch64\" ]]; then\n    ARCH=\"arm64\"\nfi\n\nwget https://github.com/rancher/rke2/releases/download/$${K8S_VERSION}+rke2r1/rke2.linux-$${ARCH}.tar.gz\nwget https://github.com/rancher/rke2/releases/download/$${K8S_VERSION}+rke2r1/rke2-images.linux-$${ARCH}.tar.zst\nwget https://github.com/rancher/rke2/releases/download/$${K8S_VERSION}+rke2r1/sha256sum-$${ARCH}.txt\n\nif [[ \"$${USER}\" == \"root\" ]]; then\n  mkdir -p /home/root\n  mv rke2.linux-$${ARCH}.tar.gz /home/root/\n  mv rke2-images.linux-$${ARCH}.tar.zst /home/root/\n  mv sha256sum-$${ARCH}.txt /home/root/\nfi\n\nsudo mkdir -p /etc/rancher/rke2\nsudo touch /etc/rancher/rke2/config.yaml\n\necho \"server: https://$${RKE2_SERVER_ONE_IP}:9345\ncni: $${CNI}\ntoken: $${RKE2_TOKEN}\ntls-san:\n  - $${RKE2_SERVER_ONE_IP}\" | sudo tee /etc/rancher/rke2/config.yaml > /dev/null\n\necho \"mirrors:\n  docker.io:\n    endpoint:\n      - \"https://registry-1.docker.io\"\nconfigs:\n  \"registry-1.docker.io\":\n    auth:\n      username: \"$${REGISTRY_USERNAME}\"\n      password: \"$${REGISTRY_PASSWORD}\"\n  \"docker.io\":\n    auth:\n      username: \"$${REGISTRY_USERNAME}\"\n      password: \"$${REGISTRY_PASSWORD}\"\" | sudo tee -a /etc/rancher/rke2/registries.yaml > /dev/null\n\ncurl -sfL https://get.rke2.io --output install.sh\nchmod +x install.sh\n\nsudo INSTALL_RKE2_ARTIFACT_PATH=/home/$${USER} sh install.sh\nsudo systemctl enable rke2-server\nsudo systemctl start rke2-server' > /tmp/add-servers.sh", "chmod +x /tmp/add-servers.sh"]
+  }
+  depends_on = [null_resource.tfp_create_cluster]
+}
+
+resource "null_resource" "add_server_tfp_server2" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${aws_instance.tfp_server2.public_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["bash -c '/tmp/add-servers.sh ubuntu v1.32.5+rke2r1 ${aws_instance.tfp_server1.private_ip} ${aws_instance.tfp_server2.public_ip} auto-import-xxxxx calico' || true"]
+  }
+  depends_on = [null_resource.tfp_server2]
+}
+
+resource "null_resource" "tfp_server3" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${aws_instance.tfp_server3.public_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["echo '#!/bin/bash\n\nUSER=$1\nK8S_VERSION=$2\nRKE2_SERVER_ONE_IP=$3\nRKE2_NEW_SERVER_IP=$4\nRKE2_TOKEN=$5\nCNI=$6\nREGISTRY_USERNAME=$7\nREGISTRY_PASSWORD=$8\n\nset -e\n\nsudo hostnamectl set-hostname $${RKE2_NEW_SERVER_IP}\n\nARCH=$(uname -m)\nif [[ $ARCH == \"x86_64\" ]]; then\n    ARCH=\"amd64\"\nelif [[ $ARCH == \"arm64\" || $ARCH == \"aarch64\" ]]; then\n    ARCH=\"arm64\"\nfi\n\nwget https://github.com/rancher/rke2/releases/download/$${K8S_VERSION}+rke2r1/rke2.linux-$${ARCH}.tar.gz\nwget https://github.com/rancher/rke2/releases/download/$${K8S_VERSION}+rke2r1/rke2-images.linux-$${ARCH}.tar.zst\nwget https://github.com/rancher/rke2/releases/download/$${K8S_VERSION}+rke2r1/sha256sum-$${ARCH}.txt\n\nif [[ \"$${USER}\" == \"root\" ]]; then\n  mkdir -p /home/root\n  mv rke2.linux-$${ARCH}.tar.gz /home/root/\n  mv rke2-images.linux-$${ARCH}.tar.zst /home/root/\n  mv sha256sum-$${ARCH}.txt /home/root/\nfi\n\nsudo mkdir -p /etc/rancher/rke2\nsudo touch /etc/rancher/rke2/config.yaml\n\necho \"server: https://$${RKE2_SERVER_ONE_IP}:9345\ncni: $${CNI}\ntoken: $${RKE2_TOKEN}\ntls-san:\n  - $${RKE2_SERVER_ONE_IP}\" | sudo tee /etc/rancher/rke2/config.yaml > /dev/null\n\necho \"mirrors:\n  docker.io:\n    endpoint:\n      - \"https://registry-1.docker.io\"\nconfigs:\n  \"registry-1.docker.io\":\n    auth:\n      username: \"$${REGISTRY_USERNAME}\"\n      password: \"$${REGISTRY_PASSWORD}\"\n  \"docker.io\":\n    auth:\n      username: \"$${REGISTRY_USERNAME}\"\n      password: \"$${REGISTRY_PASSWORD}\"\" | sudo tee -a /etc/rancher/rke2/registries.yaml > /dev/null\n\ncurl -sfL https://get.rke2.io --output install.sh\nchmod +x install.sh\n\nsudo INSTALL_RKE2_ARTIFACT_PATH=/home/$${USER} sh install.sh\nsudo systemctl enable rke2-server\nsudo systemctl start rke2-server' > /tmp/add-servers.sh", "chmod +x /tmp/add-servers.sh"]
+  }
+  depends_on = [null_resource.tfp_create_cluster]
+}
+
+resource "null_resource" "add_server_tfp_server3" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${aws_instance.tfp_server3.public_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["bash -c '/tmp/add-servers.sh ubuntu v1.32.5+rke2r1 ${aws_instance.tfp_server1.private_ip} ${aws_instance.tfp_server3.public_ip} auto-import-xxxxx calico' || true"]
+  }
+  depends_on = [null_resource.tfp_server3]
+}
+
+
+resource "aws_instance" "tfp-windows" {
+  count                  = 1
+  ami                    = "ami-2022"
+  instance_type          = "t3a.2xlarge"
+  subnet_id              = "subnet-0123456789"
+  vpc_security_group_ids = ["sg-0123456789"]
+  key_name               = "tfp-windows-key"
+
+  root_block_device {
+    volume_size = 100
+  }
+
+  tags = {
+    Name = "tfp-windows-${count.index}"
+  }
+
+  user_data = "<<-EOF\n              <powershell>\n              winrm quickconfig -q\n              winrm set winrm/config/service '@{AllowUnencrypted=\"true\"}'\n              winrm set winrm/config/service/auth '@{Basic=\"true\"}'\n\n              netsh advfirewall firewall add rule name=\"WinRM HTTP\" dir=in action=allow protocol=TCP localport=5985\n              netsh advfirewall firewall add rule name=\"WinRM HTTPS\" dir=in action=allow protocol=TCP localport=5986\n              </powershell>\n              EOF"
+
+  connection {
+    type     = "winrm"
+    user     = "Administrator"
+    password = var.windows_2022_password
+    insecure = true
+    use_ntlm = true
+    host     = self.public_ip
+    timeout  = "5m"
+  }
+
+  provisioner "remote-exec" {
+    inline = ["echo Connected!!!"]
+  }
+
+  depends_on = [null_resource.tfp_server2, null_resource.tfp_server3]
+}
+
+resource "null_resource" "tfp_copy_script_windows_server" {
+  provisioner "remote-exec" {
+    connection {
+      host     = "${aws_instance.tfp-windows[0].public_ip}"
+      type     = "winrm"
+      user     = "Administrator"
+      password = var.windows_2022_password
+      insecure = true
+      use_ntlm = true
+      timeout  = "5m"
+    }
+    inline = ["echo param ( > C:\\Windows\\Temp\\init-server.ps1", "echo     [string]$K8S_VERSION, >> C:\\Windows\\Temp\\init-server.ps1", "echo     [string]$RKE2_SERVER_IP, >> C:\\Windows\\Temp\\init-server.ps1", "echo     [string]$RKE2_TOKEN >> C:\\Windows\\Temp\\init-server.ps1", "echo ) >> C:\\Windows\\Temp\\init-server.ps1", "echo powershell.exe -Command \"Start-Process PowerShell -Verb RunAs\" >> C:\\Windows\\Temp\\init-server.ps1", "echo Enable-WindowsOptionalFeature -Online -FeatureName containers -All >> C:\\Windows\\Temp\\init-server.ps1", "echo Invoke-WebRequest -Uri https://raw.githubusercontent.com/rancher/rke2/master/install.ps1 -Outfile install.ps1 >> C:\\Windows\\Temp\\init-server.ps1", "echo New-Item -Type Directory -Path C:\\etc\\rancher\\rke2 -Force >> C:\\Windows\\Temp\\init-server.ps1", "echo New-Item -Type File -Path C:\\etc\\rancher\\rke2\\config.yaml -Force >> C:\\Windows\\Temp\\init-server.ps1", "echo $RKE2_SERVER_URL = \"https://\" + $RKE2_SERVER_IP + \":9345\" >> C:\\Windows\\Temp\\init-server.ps1", "echo cmd.exe /c \"(echo server: $RKE2_SERVER_URL && echo token: $RKE2_TOKEN && echo node-name: tfp-wins && echo tls-san: && echo   - $RKE2_SERVER_IP) > C:\\etc\\rancher\\rke2\\config.yaml\" >> C:\\Windows\\Temp\\init-server.ps1", "echo $env:PATH+=\";c:\\var\\lib\\rancher\\rke2\\bin;c:\\usr\\local\\bin\" >> C:\\Windows\\Temp\\init-server.ps1", "echo [Environment]::SetEnvironmentVariable( >> C:\\Windows\\Temp\\init-server.ps1", "echo     \"Path\", >> C:\\Windows\\Temp\\init-server.ps1", "echo     [Environment]::GetEnvironmentVariable(\"Path\", [EnvironmentVariableTarget]::Machine) + \";C:\\var\\lib\\rancher\\rke2\\bin;c:\\usr\\local\\bin\", >> C:\\Windows\\Temp\\init-server.ps1", "echo     [EnvironmentVariableTarget]::Machine) >> C:\\Windows\\Temp\\init-server.ps1", "echo .\\install.ps1 -Version $K8S_VERSION >> C:\\Windows\\Temp\\init-server.ps1", "echo rke2.exe agent service --add >> C:\\Windows\\Temp\\init-server.ps1", "echo Start-Service rke2 >> C:\\Windows\\Temp\\init-server.ps1"]
+  }
+  depends_on = [aws_instance.tfp-windows]
+}
+
+resource "null_resource" "add_windows_node" {
+  provisioner "remote-exec" {
+    connection {
+      host     = "${aws_instance.tfp-windows[0].public_ip}"
+      type     = "winrm"
+      user     = "Administrator"
+      password = var.windows_2022_password
+      insecure = true
+      use_ntlm = true
+      timeout  = "5m"
+    }
+    inline = ["powershell.exe -File C:\\Windows\\Temp\\init-server.ps1 -ArgumentList -K8S_VERSION v1.32.5+rke2r1+rke2r1 -RKE2_SERVER_IP ${aws_instance.tfp_server1.private_ip} -RKE2_TOKEN auto-import-xxxxx"]
+  }
+  depends_on = [null_resource.tfp_copy_script_windows_server]
+}
+
+resource "time_sleep" "time_sleep-tfp-import_wins" {
+  create_duration = "10s"
+  depends_on      = [null_resource.add_windows_node]
+}
+
+resource "null_resource" "tfp_copy_script" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${aws_instance.tfp_server1.public_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["echo '#!/bin/bash\n\nPEM_FILE=$1\nUSER=$2\nGROUP=$3\nNODE_ONE_PUBLIC_DNS=$4\nIMPORT_COMMAND=$5\nRKE_KUBE_CONFIG_FILE=$${6}\n\nset -ex\n\nARCH=$(uname -m)\nif [[ $ARCH == \"x86_64\" ]]; then\n    ARCH=\"amd64\"\nelif [[ $ARCH == \"arm64\" || $ARCH == \"aarch64\" ]]; then\n    ARCH=\"arm64\"\nfi\n\necho \"Installing kubectl\"\ncurl -LO \"https://dl.k8s.io/release/$(curl -L -s https://dl.k8s.io/release/stable.txt)/bin/linux/$${ARCH}/kubectl\"\nsudo install -o root -g root -m 0755 kubectl /usr/local/bin/kubectl\nmkdir -p ~/.kube\nrm kubectl\n\nif [ -n \"$${RKE_KUBE_CONFIG_FILE}\" ]; then\n    echo \"$${RKE_KUBE_CONFIG_FILE}\" > /home/$USER/.kube/config\nfi\n\necho $${PEM_FILE} | sudo base64 -d > /home/$${USER}/key.pem\necho \"$${IMPORT_COMMAND}\" > /home/$${USER}/import_command.txt\nIMPORT_COMMAND=$(cat /home/$USER/import_command.txt)\n\nPEM=/home/$${USER}/key.pem\nsudo chmod 600 $${PEM}\nsudo chown $${USER}:$${GROUP} $${PEM}\n\neval \"$IMPORT_COMMAND\"' > /tmp/import-nodes.sh", "chmod +x /tmp/import-nodes.sh"]
+  }
+  depends_on = [time_sleep.time_sleep-tfp-import_wins]
+}
+
+resource "null_resource" "tfp_import_cluster" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${aws_instance.tfp_server1.public_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["bash -c '/tmp/import-nodes.sh ${var.private_key_base64} ubuntu ubuntu ${aws_instance.tfp_server1.public_ip} \"${rancher2_cluster.tfp.cluster_registration_token[0].insecure_command}\"'"]
+  }
+  depends_on = [null_resource.tfp_copy_script]
+}
+
+variable "aws_access_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "aws_secret_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "private_key_base64" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_user_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "windows_2022_password" {
+  type      = string
+  sensitive = true
+}
+
@@ -0,0 +1,96 @@
+terraform {
+  required_providers {
+    rancher2 = {
+      source  = "rancher/rancher2"
+      version = "8.0.0"
+    }
+  }
+}
+
+provider "rancher2" {
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_token
+  insecure  = true
+}
+
+provider "rancher2" {
+  alias     = "admin_user"
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_user_token
+  insecure  = true
+}
+
+
+resource "rancher2_cloud_credential" "tfp" {
+  name = "tfp"
+  amazonec2_credential_config {
+    access_key = var.aws_access_key
+    secret_key = var.aws_secret_key
+  }
+}
+
+resource "rancher2_cluster" "tfp" {
+  name = "tfp"
+  eks_config_v2 {
+    cloud_credential_id = rancher2_cloud_credential.tfp.id
+    region              = "us-east-2"
+    kubernetes_version  = "v1.32.5+rke2r1"
+    subnets             = ["subnet-0123456789", "subnet-9876543210"]
+    security_groups     = ["sg-0123456789"]
+    private_access      = true
+    public_access       = false
+    node_groups {
+      name          = "tfp-pool0"
+      disk_size     = 100
+      instance_type = "t3.large"
+      desired_size  = 1
+      max_size      = 2
+      min_size      = 1
+    }
+    node_groups {
+      name          = "tfp-pool1"
+      disk_size     = 100
+      instance_type = "t3.large"
+      desired_size  = 1
+      max_size      = 2
+      min_size      = 1
+    }
+    node_groups {
+      name         = "workers"
+      disk_size    = 100
+      desired_size = 1
+      max_size     = 2
+      min_size     = 1
+      labels = {
+        "tfp.rancher.io/pool" = "workers"
+      }
+      request_spot_instances = true
+      spot_instance_types    = ["t3.large"]
+      launch_template {
+        id      = "lt-0123456789"
+        version = 2
+      }
+    }
+  }
+}
+
+variable "aws_access_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "aws_secret_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_user_token" {
+  type      = string
+  sensitive = true
+}
+
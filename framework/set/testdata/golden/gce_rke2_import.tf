@@ -0,0 +1,273 @@
+terraform {
+  required_providers {
+    rancher2 = {
+      source  = "rancher/rancher2"
+      version = "8.0.0"
+    }
+    google = {
+      source  = "hashicorp/google"
+      version = "5.95.0"
+    }
+    local = {
+      source  = "hashicorp/local"
+      version = "2.5.2"
+    }
+  }
+}
+
+provider "google" {
+  credentials = var.google_auth_encoded_json
+  project     = "tfp-project"
+  region      = "us-central1"
+  zone        = "us-central1-c"
+}
+
+provider "local" {
+}
+
+provider "rancher2" {
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_token
+  insecure  = true
+}
+
+provider "rancher2" {
+  alias     = "admin_user"
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_user_token
+  insecure  = true
+}
+
+
+resource "rancher2_cluster" "tfp" {
+  name        = "tfp"
+  description = "tfp-automation imported cluster"
+}
+
+resource "google_compute_instance" "tfp_server1" {
+  name         = "tfp-tfp-server1"
+  machine_type = "e2-standard-4"
+  zone         = "us-central1-c"
+  tags         = ["tfp-nodes"]
+  metadata     = { ssh-keys = "ubuntu:${file("testdata/id_rsa.pub")}" }
+
+  boot_disk {
+    initialize_params {
+      image = "ubuntu-os-cloud/ubuntu-2204-lts"
+      size  = 100
+      type  = "pd-standard"
+    }
+  }
+
+  network_interface {
+    network    = "default"
+    subnetwork = "default"
+    access_config {
+    }
+  }
+
+  connection {
+    type        = "ssh"
+    user        = "ubuntu"
+    host        = self.network_interface[0].access_config[0].nat_ip
+    private_key = file("testdata/id_rsa")
+  }
+
+  provisioner "remote-exec" {
+    inline = ["echo Connected!!!"]
+  }
+}
+
+resource "google_compute_instance" "tfp_server2" {
+  name         = "tfp-tfp-server2"
+  machine_type = "e2-standard-4"
+  zone         = "us-central1-c"
+  tags         = ["tfp-nodes"]
+  metadata     = { ssh-keys = "ubuntu:${file("testdata/id_rsa.pub")}" }
+
+  boot_disk {
+    initialize_params {
+      image = "ubuntu-os-cloud/ubuntu-2204-lts"
+      size  = 100
+      type  = "pd-standard"
+    }
+  }
+
+  network_interface {
+    network    = "default"
+    subnetwork = "default"
+    access_config {
+    }
+  }
+
+  connection {
+    type        = "ssh"
+    user        = "ubuntu"
+    host        = self.network_interface[0].access_config[0].nat_ip
+    private_key = file("testdata/id_rsa")
+  }
+
+  provisioner "remote-exec" {
+    inline = ["echo Connected!!!"]
+  }
+}
+
+resource "google_compute_instance" "tfp_server3" {
+  name         = "tfp-tfp-server3"
+  machine_type = "e2-standard-4"
+  zone         = "us-central1-c"
+  tags         = ["tfp-nodes"]
+  metadata     = { ssh-keys = "ubuntu:${file("testdata/id_rsa.pub")}" }
+
+  boot_disk {
+    initialize_params {
+      image = "ubuntu-os-cloud/ubuntu-2204-lts"
+      size  = 100
+      type  = "pd-standard"
+    }
+  }
+
+  network_interface {
+    network    = "default"
+    subnetwork = "default"
+    access_config {
+    }
+  }
+
+  connection {
+    type        = "ssh"
+    user        = "ubuntu"
+    host        = self.network_interface[0].access_config[0].nat_ip
+    private_key = file("testdata/id_rsa")
+  }
+
+  provisioner "remote-exec" {
+    inline = ["echo Connected!!!"]
+  }
+}
+
+resource "null_resource" "tfp_copy_script_server1" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${google_compute_instance.tfp_server1.network_interface[0].access_config[0].nat_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["echo '#!/bin/bash\n\nUSER=$1\nGROUP=$2\nK8S_VERSION=$3\nRKE2_SERVER_IP=$4\nRKE2_TOKEN=$5\nCNI=$6\nREGISTRY_USERNAME=$7\nREGISTRY_PASSWORD=$8\n\nset -e\n\nsudo hostnamectl set-hostname $${RKE2_SERVER_IP}\n\nARCH=$(uname -m)\nif [[ $ARCH == \"x86_64\" ]]; then\n    ARCH=\"amd64\"\nelif [[ $ARCH == \"arm64\" || $ARCH == \"aarch64\" ]]; then\n    ARCH=\"arm64\"\nfi\n\nwget https://github.com/rancher/rke2/releases/download/$${K8S_VERSION}+rke2r1/rke2.linux-$${ARCH}.tar.gz\nwget https://github.com/rancher/rke2/releases/download/$${K8S_VERSION}+rke2r1/rke2-images.linux-$${ARCH}.tar.zst\nwget https://github.com/rancher/rke2/releases/download/$${K8S_VERSION}+rke2r1/sha256sum-$${ARCH}.txt\n\nif [[ \"$${USER}\" == \"root\" ]]; then\n  mkdir -p /home/root\n  mv rke2.linux-$${ARCH}.tar.gz /home/root/\n  mv rke2-images.linux-$${ARCH}.tar.zst /home/root/\n  mv sha256sum-$${ARCH}.txt /home/root/\nfi\n\nsudo mkdir -p /etc/rancher/rke2\nsudo touch /etc/rancher/rke2/config.yaml\n\necho \"cni: $${CNI}\ntoken: $${RKE2_TOKEN}\ntls-san:\n  - $${RKE2_SERVER_IP}\" | sudo tee /etc/rancher/rke2/config.yaml > /dev/null\n\necho \"mirrors:\n  docker.io:\n    endpoint:\n    - \"https://registry-1.docker.io\"\nconfigs:\n  \"registry-1.docker.io\":\n    auth:\n      username: \"$${REGISTRY_USERNAME}\"\n      password: \"$${REGISTRY_PASSWORD}\"\n  \"docker.io\":\n    auth:\n      username: \"$${REGISTRY_USERNAME}\"\n      password: \"$${REGISTRY_PASSWORD}\"\" | sudo tee -a /etc/rancher/rke2/registries.yaml > /dev/null\n\ncurl -sfL https://get.rke2.io --output install.sh\nchmod +x install.sh\n\nsudo INSTALL_RKE2_ARTIFACT_PATH=/home/$${USER} sh install.sh\nsudo systemctl enable rke2-server\nsudo systemctl start rke2-server\n\nif [[ \"$${USER}\" == \"root\" ]]; then\n  sudo mkdir -p /root/.kube\n  sudo cp /etc/rancher/rke2/rke2.yaml /root/.kube/config\nelse\n  sudo mkdir -p /home/$${USER}/.kube\n  sudo cp /etc/rancher/rke2/rke2.yaml /home/$${USER}/.kube/config\n  sudo chown -R $${USER}:$${GROUP} /home/$${USER}/.kube\nfi' > /tmp/init-server.sh", "chmod +x /tmp/init-server.sh"]
+  }
+  depends_on = [google_compute_instance.tfp_server1, google_compute_instance.tfp_server2, google_compute_instance.tfp_server3]
+}
+
+resource "null_resource" "tfp_create_cluster" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${google_compute_instance.tfp_server1.network_interface[0].access_config[0].nat_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["bash -c '/tmp/init-server.sh ubuntu ubuntu v1.32.5+rke2r1 ${google_compute_instance.tfp_server1.network_interface[0].network_ip} auto-import-xxxxx calico' || true"]
+  }
+  depends_on = [null_resource.tfp_copy_script_server1]
+}
+
+resource "null_resource" "tfp_server2" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${google_compute_instance.tfp_server2.network_interface[0].access_config[0].nat_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["echo '#!/bin/bash\n\nUSER=$1\nK8S_VERSION=$2\nRKE2_SERVER_ONE_IP=$3\nRKE2_NEW_SERVER_IP=$4\nRKE2_TOKEN=$5\nCNI=$6\nREGISTRY_USERNAME=$7\nREGISTRY_PASSWORD=$8\n\nset -e\n\nsudo hostnamectl set-hostname $${RKE2_NEW_SERVER_IP}\n\nARCH=$(uname -m)\nif [[ $ARCH == \"x86_64\" ]]; then\n    ARCH=\"amd64\"\nelif [[ $ARCH == \"arm64\" || $ARCH == \"aarch64\" ]]; then\n    ARCH=\"arm64\"\nfi\n\nwget https://github.com/rancher/rke2/releases/download/$${K8S_VERSION}+rke2r1/rke2.linux-$${ARCH}.tar.gz\nwget https://github.com/rancher/rke2/releases/download/$${K8S_VERSION}+rke2r1/rke2-images.linux-$${ARCH}.tar.zst\nwget https://github.com/rancher/rke2/releases/download/$${K8S_VERSION}+rke2r1/sha256sum-$${ARCH}.txt\n\nif [[ \"$${USER}\" == \"root\" ]]; then\n  mkdir -p /home/root\n  mv rke2.linux-$${ARCH}.tar.gz /home/root/\n  mv rke2-images.linux-$${ARCH}.tar.zst /home/root/\n  mv sha256sum-$${ARCH}.txt /home/root/\nfi\n\nsudo mkdir -p /etc/rancher/rke2\nsudo touch /etc/rancher/rke2/config.yaml\n\necho \"server: https://$${RKE2_SERVER_ONE_IP}:9345\ncni: $${CNI}\ntoken: $${RKE2_TOKEN}\ntls-san:\n  - $${RKE2_SERVER_ONE_IP}\" | sudo tee /etc/rancher/rke2/config.yaml > /dev/null\n\necho \"mirrors:\n  docker.io:\n    endpoint:\n      - \"https://registry-1.docker.io\"\nconfigs:\n  \"registry-1.docker.io\":\n    auth:\n      username: \"$${REGISTRY_USERNAME}\"\n      password: \"$${REGISTRY_PASSWORD}\"\n  \"docker.io\":\n    auth:\n      username: \"$${REGISTRY_USERNAME}\"\n      password: \"$${REGISTRY_PASSWORD}\"\" | sudo tee -a /etc/rancher/rke2/registries.yaml > /dev/null\n\ncurl -sfL https://get.rke2.io --output install.sh\nchmod +x install.sh\n\nsudo INSTALL_RKE2_ARTIFACT_PATH=/home/$${USER} sh install.sh\nsudo systemctl enable rke2-server\nsudo systemctl start rke2-server' > /tmp/add-servers.sh", "chmod +x /tmp/add-servers.sh"]
+  }
+  depends_on = [null_resource.tfp_create_cluster]
+}
+
+resource "null_resource" "add_server_tfp_server2" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${google_compute_instance.tfp_server2.network_interface[0].access_config[0].nat_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["bash -c '/tmp/add-servers.sh ubuntu v1.32.5+rke2r1 ${google_compute_instance.tfp_server1.network_interface[0].network_ip} ${google_compute_instance.tfp_server2.network_interface[0].access_config[0].nat_ip} auto-import-xxxxx calico' || true"]
+  }
+  depends_on = [null_resource.tfp_server2]
+}
+
+resource "null_resource" "tfp_server3" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${google_compute_instance.tfp_server3.network_interface[0].access_config[0].nat_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["echo '#!/bin/bash\n\nUSER=$1\nK8S_VERSION=$2\nRKE2_SERVER_ONE_IP=$3\nRKE2_NEW_SERVER_IP=$4\nRKE2_TOKEN=$5\nCNI=$6\nREGISTRY_USERNAME=$7\nREGISTRY_PASSWORD=$8\n\nset -e\n\nsudo hostnamectl set-hostname $${RKE2_NEW_SERVER_IP}\n\nARCH=$(uname -m)\nif [[ $ARCH == \"x86_64\" ]]; then\n    ARCH=\"amd64\"\nelif [[ $ARCH == \"arm64\" || $ARCH == \"aarch64\" ]]; then\n    ARCH=\"arm64\"\nfi\n\nwget https://github.com/rancher/rke2/releases/download/$${K8S_VERSION}+rke2r1/rke2.linux-$${ARCH}.tar.gz\nwget https://github.com/rancher/rke2/releases/download/$${K8S_VERSION}+rke2r1/rke2-images.linux-$${ARCH}.tar.zst\nwget https://github.com/rancher/rke2/releases/download/$${K8S_VERSION}+rke2r1/sha256sum-$${ARCH}.txt\n\nif [[ \"$${USER}\" == \"root\" ]]; then\n  mkdir -p /home/root\n  mv rke2.linux-$${ARCH}.tar.gz /home/root/\n  mv rke2-images.linux-$${ARCH}.tar.zst /home/root/\n  mv sha256sum-$${ARCH}.txt /home/root/\nfi\n\nsudo mkdir -p /etc/rancher/rke2\nsudo touch /etc/rancher/rke2/config.yaml\n\necho \"server: https://$${RKE2_SERVER_ONE_IP}:9345\ncni: $${CNI}\ntoken: $${RKE2_TOKEN}\ntls-san:\n  - $${RKE2_SERVER_ONE_IP}\" | sudo tee /etc/rancher/rke2/config.yaml > /dev/null\n\necho \"mirrors:\n  docker.io:\n    endpoint:\n      - \"https://registry-1.docker.io\"\nconfigs:\n  \"registry-1.docker.io\":\n    auth:\n      username: \"$${REGISTRY_USERNAME}\"\n      password: \"$${REGISTRY_PASSWORD}\"\n  \"docker.io\":\n    auth:\n      username: \"$${REGISTRY_USERNAME}\"\n      password: \"$${REGISTRY_PASSWORD}\"\" | sudo tee -a /etc/rancher/rke2/registries.yaml > /dev/null\n\ncurl -sfL https://get.rke2.io --output install.sh\nchmod +x install.sh\n\nsudo INSTALL_RKE2_ARTIFACT_PATH=/home/$${USER} sh install.sh\nsudo systemctl enable rke2-server\nsudo systemctl start rke2-server' > /tmp/add-servers.sh", "chmod +x /tmp/add-servers.sh"]
+  }
+  depends_on = [null_resource.tfp_create_cluster]
+}
+
+resource "null_resource" "add_server_tfp_server3" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${google_compute_instance.tfp_server3.network_interface[0].access_config[0].nat_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["bash -c '/tmp/add-servers.sh ubuntu v1.32.5+rke2r1 ${google_compute_instance.tfp_server1.network_interface[0].network_ip} ${google_compute_instance.tfp_server3.network_interface[0].access_config[0].nat_ip} auto-import-xxxxx calico' || true"]
+  }
+  depends_on = [null_resource.tfp_server3]
+}
+
+
+resource "null_resource" "tfp_copy_script" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${google_compute_instance.tfp_server1.network_interface[0].access_config[0].nat_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["echo '#!/bin/bash\n\nPEM_FILE=$1\nUSER=$2\nGROUP=$3\nNODE_ONE_PUBLIC_DNS=$4\nIMPORT_COMMAND=$5\nRKE_KUBE_CONFIG_FILE=$${6}\n\nset -ex\n\nARCH=$(uname -m)\nif [[ $ARCH == \"x86_64\" ]]; then\n    ARCH=\"amd64\"\nelif [[ $ARCH == \"arm64\" || $ARCH == \"aarch64\" ]]; then\n    ARCH=\"arm64\"\nfi\n\necho \"Installing kubectl\"\ncurl -LO \"https://dl.k8s.io/release/$(curl -L -s https://dl.k8s.io/release/stable.txt)/bin/linux/$${ARCH}/kubectl\"\nsudo install -o root -g root -m 0755 kubectl /usr/local/bin/kubectl\nmkdir -p ~/.kube\nrm kubectl\n\nif [ -n \"$${RKE_KUBE_CONFIG_FILE}\" ]; then\n    echo \"$${RKE_KUBE_CONFIG_FILE}\" > /home/$USER/.kube/config\nfi\n\necho $${PEM_FILE} | sudo base64 -d > /home/$${USER}/key.pem\necho \"$${IMPORT_COMMAND}\" > /home/$${USER}/import_command.txt\nIMPORT_COMMAND=$(cat /home/$USER/import_command.txt)\n\nPEM=/home/$${USER}/key.pem\nsudo chmod 600 $${PEM}\nsudo chown $${USER}:$${GROUP} $${PEM}\n\neval \"$IMPORT_COMMAND\"' > /tmp/import-nodes.sh", "chmod +x /tmp/import-nodes.sh"]
+  }
+  depends_on = [null_resource.add_server_tfp_server2, null_resource.add_server_tfp_server3]
+}
+
+resource "null_resource" "tfp_import_cluster" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${google_compute_instance.tfp_server1.network_interface[0].access_config[0].nat_ip}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["bash -c '/tmp/import-nodes.sh ${var.private_key_base64} ubuntu ubuntu ${google_compute_instance.tfp_server1.network_interface[0].access_config[0].nat_ip} \"${rancher2_cluster.tfp.cluster_registration_token[0].insecure_command}\"'"]
+  }
+  depends_on = [null_resource.tfp_copy_script]
+}
+
+variable "google_auth_encoded_json" {
+  type      = string
+  sensitive = true
+}
+
+variable "private_key_base64" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_user_token" {
+  type      = string
+  sensitive = true
+}
+
@@ -0,0 +1,66 @@
+terraform {
+  required_providers {
+    rancher2 = {
+      source  = "rancher/rancher2"
+      version = "8.0.0"
+    }
+    local = {
+      source  = "hashicorp/local"
+      version = "2.5.2"
+    }
+  }
+}
+
+provider "rancher2" {
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_token
+  insecure  = true
+}
+
+provider "rancher2" {
+  alias     = "admin_user"
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_user_token
+  insecure  = true
+}
+
+
+resource "rancher2_cloud_credential" "tfp" {
+  name = "tfp"
+  google_credential_config {
+    auth_encoded_json = var.google_auth_encoded_json
+  }
+}
+
+resource "rancher2_cluster" "tfp" {
+  name = "tfp"
+  gke_config_v2 {
+    name                     = "existing-cluster"
+    google_credential_secret = rancher2_cloud_credential.tfp.id
+    region                   = "us-central1-c"
+    project_id               = "tfp-project"
+    imported                 = true
+    kubernetes_version       = "1.32.4-gke.1415000"
+    node_pools {
+      name               = "existing-pool"
+      initial_node_count = 3
+      version            = "1.32.4-gke.1415000"
+    }
+  }
+}
+
+variable "google_auth_encoded_json" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_user_token" {
+  type      = string
+  sensitive = true
+}
+
@@ -0,0 +1,104 @@
+terraform {
+  required_providers {
+    rancher2 = {
+      source  = "rancher/rancher2"
+      version = "8.0.0"
+    }
+  }
+}
+
+provider "rancher2" {
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_token
+  insecure  = true
+}
+
+provider "rancher2" {
+  alias     = "admin_user"
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_user_token
+  insecure  = true
+}
+
+
+resource "rancher2_cloud_credential" "tfp" {
+  name = "tfp"
+  google_credential_config {
+    auth_encoded_json = var.google_auth_encoded_json
+  }
+}
+
+resource "rancher2_cluster" "tfp" {
+  name = "tfp"
+  gke_config_v2 {
+    name                     = "tfp"
+    google_credential_secret = rancher2_cloud_credential.tfp.id
+    region                   = "us-central1-c"
+    project_id               = "tfp-project"
+    kubernetes_version       = "v1.32.5+rke2r1"
+    network                  = "default"
+    subnetwork               = "default"
+    ip_allocation_policy {
+      use_ip_aliases = true
+    }
+    private_cluster_config {
+      enable_private_endpoint = true
+      enable_private_nodes    = true
+      master_ipv4_cidr_block  = "172.16.0.0/28"
+    }
+    node_pools {
+      initial_node_count  = 1
+      max_pods_constraint = 110
+      name                = "tfp-pool0"
+      version             = "v1.32.5+rke2r1"
+    }
+    node_pools {
+      initial_node_count  = 1
+      max_pods_constraint = 110
+      name                = "tfp-pool1"
+      version             = "v1.32.5+rke2r1"
+    }
+    node_pools {
+      initial_node_count  = 1
+      max_pods_constraint = 110
+      name                = "workers"
+      version             = "v1.32.5+rke2r1"
+      autoscaling {
+        enabled        = true
+        min_node_count = 1
+        max_node_count = 3
+      }
+      config {
+        labels = {
+          "tfp.rancher.io/pool" = "workers"
+        }
+        preemptible = true
+        taints {
+          key    = "dedicated"
+          value  = "workers"
+          effect = "NO_SCHEDULE"
+        }
+      }
+      management {
+        auto_repair  = true
+        auto_upgrade = false
+      }
+    }
+  }
+}
+
+variable "google_auth_encoded_json" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_user_token" {
+  type      = string
+  sensitive = true
+}
+
@@ -0,0 +1,141 @@
+terraform {
+  required_providers {
+    rancher2 = {
+      source  = "rancher/rancher2"
+      version = "8.0.0"
+    }
+  }
+}
+
+provider "rancher2" {
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_token
+  insecure  = true
+}
+
+provider "rancher2" {
+  alias     = "admin_user"
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_user_token
+  insecure  = true
+}
+
+
+resource "rancher2_node_template" "tfp" {
+  name                     = "tfp"
+  engine_insecure_registry = ["registry.example.com"]
+  engine_install_url       = "https://releases.rancher.com/install-docker/26.1.sh"
+  cloud_credential_id      = rancher2_cloud_credential.tfp.id
+  harvester_config {
+    user_data    = <<EOT
+#cloud-config
+package_update: true
+packages:
+  - qemu-guest-agent
+runcmd:
+  - - systemctl
+    - enable
+    - '--now'
+    - qemu-guest-agent.service
+EOT
+    network_info = <<EOF
+{
+	"interfaces": [{
+		"networkName": "default/vlan1"
+	}]
+}
+EOF
+    disk_info    = <<EOF
+{
+	"disks": [{
+		"imageName": "default/image-ubuntu",
+		"size": 40,
+		"bootOrder": 1 
+	}]
+}
+EOF
+    cpu_count    = "2"
+    memory_size  = "8"
+    ssh_user     = "ubuntu"
+    vm_namespace = "default"
+  }
+}
+resource "rancher2_cloud_credential" "tfp" {
+  name = "tfp"
+  harvester_credential_config {
+    cluster_id         = "c-m-abcdefgh"
+    cluster_type       = "imported"
+    kubeconfig_content = var.harvester_kubeconfig
+  }
+}
+
+resource "rancher2_cluster" "tfp" {
+  depends_on                                                 = [rancher2_node_template.tfp]
+  name                                                       = "tfp"
+  default_pod_security_admission_configuration_template_name = ""
+  rke_config {
+    kubernetes_version = "v1.32.5+rke2r1"
+    network {
+      plugin = "calico"
+    }
+  }
+}
+
+resource "rancher2_node_pool" "tfpnode-pool0" {
+  depends_on       = [rancher2_cluster.tfp]
+  cluster_id       = rancher2_cluster.tfp.id
+  name             = "tfp0"
+  hostname_prefix  = "tfp-pool0"
+  node_template_id = rancher2_node_template.tfp.id
+  quantity         = 1
+  control_plane    = false
+  etcd             = true
+  worker           = false
+}
+
+resource "rancher2_node_pool" "tfpnode-pool1" {
+  depends_on       = [rancher2_cluster.tfp]
+  cluster_id       = rancher2_cluster.tfp.id
+  name             = "tfp1"
+  hostname_prefix  = "tfp-pool1"
+  node_template_id = rancher2_node_template.tfp.id
+  quantity         = 1
+  control_plane    = true
+  etcd             = false
+  worker           = false
+}
+
+resource "rancher2_node_pool" "tfpnode-pool2" {
+  depends_on       = [rancher2_cluster.tfp]
+  cluster_id       = rancher2_cluster.tfp.id
+  name             = "tfp2"
+  hostname_prefix  = "tfp-pool2"
+  node_template_id = rancher2_node_template.tfp.id
+  quantity         = 1
+  control_plane    = false
+  etcd             = false
+  worker           = true
+}
+
+resource "rancher2_cluster_sync" "tfp" {
+  cluster_id    = rancher2_cluster.tfp.id
+  node_pool_ids = []
+  state_confirm = 2
+}
+
+
+variable "harvester_kubeconfig" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_user_token" {
+  type      = string
+  sensitive = true
+}
+
@@ -0,0 +1,145 @@
+terraform {
+  required_providers {
+    rancher2 = {
+      source  = "rancher/rancher2"
+      version = "8.0.0"
+    }
+  }
+}
+
+provider "rancher2" {
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_token
+  insecure  = true
+}
+
+provider "rancher2" {
+  alias     = "admin_user"
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_user_token
+  insecure  = true
+}
+
+
+resource "rancher2_cloud_credential" "tfp" {
+  name = "tfp"
+  linode_credential_config {
+    token = var.linode_token
+  }
+}
+
+resource "rancher2_cloud_credential" "tfp-s3" {
+  name = "tfp-s3"
+  s3_credential_config {
+    access_key       = var.etcd_s3_access_key
+    secret_key       = var.etcd_s3_secret_key
+    default_bucket   = "etcd-snapshots"
+    default_endpoint = "10.0.0.10:30900"
+    default_region   = "us-east-1"
+  }
+}
+
+resource "rancher2_machine_config_v2" "tfp" {
+  generate_name = "tfp"
+  linode_config {
+    image         = "linode/ubuntu22.04"
+    instance_type = "g6-standard-8"
+    region        = "us-west"
+    root_pass     = var.linode_root_pass
+  }
+}
+
+resource "rancher2_cluster_v2" "tfp" {
+  name                                                       = "tfp"
+  kubernetes_version                                         = "v1.32.5+rke2r1"
+  enable_network_policy                                      = false
+  default_pod_security_admission_configuration_template_name = ""
+  default_cluster_role_for_project_members                   = "true"
+  rke_config {
+    machine_global_config = <<EOF
+cni: calico
+disable-kube-proxy: 
+EOF
+    machine_pools {
+      name                         = "tfp0"
+      cloud_credential_secret_name = rancher2_cloud_credential.tfp.id
+      control_plane_role           = false
+      etcd_role                    = true
+      worker_role                  = false
+      quantity                     = 1
+      machine_config {
+        kind = rancher2_machine_config_v2.tfp.kind
+        name = rancher2_machine_config_v2.tfp.name
+      }
+    }
+    machine_pools {
+      name                         = "tfp1"
+      cloud_credential_secret_name = rancher2_cloud_credential.tfp.id
+      control_plane_role           = true
+      etcd_role                    = false
+      worker_role                  = false
+      quantity                     = 1
+      machine_config {
+        kind = rancher2_machine_config_v2.tfp.kind
+        name = rancher2_machine_config_v2.tfp.name
+      }
+    }
+    machine_pools {
+      name                         = "tfp2"
+      cloud_credential_secret_name = rancher2_cloud_credential.tfp.id
+      control_plane_role           = false
+      etcd_role                    = false
+      worker_role                  = true
+      quantity                     = 1
+      machine_config {
+        kind = rancher2_machine_config_v2.tfp.kind
+        name = rancher2_machine_config_v2.tfp.name
+      }
+    }
+    etcd {
+      disable_snapshots      = false
+      snapshot_schedule_cron = "*/10 * * * *"
+      snapshot_retention     = 3
+      s3_config {
+        bucket                = "etcd-snapshots"
+        endpoint              = "10.0.0.10:30900"
+        cloud_credential_name = rancher2_cloud_credential.tfp-s3.id
+        endpoint_ca           = "-----BEGIN CERTIFICATE-----"
+        folder                = "tfp"
+        region                = "us-east-1"
+        skip_ssl_verify       = false
+      }
+    }
+  }
+}
+
+variable "etcd_s3_access_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "etcd_s3_secret_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "linode_root_pass" {
+  type      = string
+  sensitive = true
+}
+
+variable "linode_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_user_token" {
+  type      = string
+  sensitive = true
+}
+
@@ -0,0 +1,283 @@
+terraform {
+  required_providers {
+    rancher2 = {
+      source  = "rancher/rancher2"
+      version = "8.0.0"
+    }
+    local = {
+      source  = "hashicorp/local"
+      version = "2.5.2"
+    }
+  }
+}
+
+provider "rancher2" {
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_token
+  insecure  = true
+}
+
+provider "rancher2" {
+  alias     = "admin_user"
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_user_token
+  insecure  = true
+}
+
+
+resource "rancher2_cloud_credential" "tfp-eks" {
+  name = "tfp-eks"
+  amazonec2_credential_config {
+    access_key = var.aws_access_key
+    secret_key = var.aws_secret_key
+  }
+}
+
+resource "rancher2_cluster" "tfp-eks" {
+  name = "tfp-eks"
+  eks_config_v2 {
+    cloud_credential_id = rancher2_cloud_credential.tfp-eks.id
+    region              = "us-east-2"
+    kubernetes_version  = "v1.32.5+rke2r1"
+    subnets             = ["subnet-0123456789", "subnet-9876543210"]
+    security_groups     = ["sg-0123456789"]
+    private_access      = true
+    public_access       = true
+    node_groups {
+      name          = "tfp-eks-pool0"
+      disk_size     = 100
+      instance_type = "t3.large"
+      desired_size  = 1
+      max_size      = 2
+      min_size      = 1
+    }
+    node_groups {
+      name          = "tfp-eks-pool1"
+      disk_size     = 100
+      instance_type = "t3.large"
+      desired_size  = 1
+      max_size      = 2
+      min_size      = 1
+    }
+    node_groups {
+      name          = "tfp-eks-pool2"
+      disk_size     = 100
+      instance_type = "t3.large"
+      desired_size  = 1
+      max_size      = 2
+      min_size      = 1
+    }
+  }
+}
+
+resource "rancher2_cloud_credential" "tfp-aks" {
+  name = "tfp-aks"
+  azure_credential_config {
+    client_id       = "azure-client-id"
+    client_secret   = var.azure_client_secret
+    subscription_id = "azure-subscription-id"
+    tenant_id       = "azure-tenant-id"
+  }
+}
+
+resource "rancher2_cluster" "tfp-aks" {
+  name = "tfp-aks"
+  aks_config_v2 {
+    cloud_credential_id        = rancher2_cloud_credential.tfp-aks.id
+    outbound_type              = "LoadBalancer"
+    resource_group             = "tfp-resource-group"
+    resource_location          = "westus"
+    dns_prefix                 = "tfp-aks"
+    kubernetes_version         = "v1.32.5+rke2r1"
+    network_plugin             = "azure"
+    virtual_network            = "tfp-vnet"
+    subnet                     = "tfp-subnet"
+    network_dns_service_ip     = "10.0.0.10"
+    network_docker_bridge_cidr = "172.17.0.1/16"
+    network_service_cidr       = "10.0.0.0/16"
+    node_pools {
+      availability_zones   = ["1", "2", "3"]
+      mode                 = "System"
+      name                 = "agentpool"
+      count                = 1
+      orchestrator_version = "v1.32.5+rke2r1"
+      os_disk_size_gb      = 128
+      vm_size              = "Standard_DS2_v2"
+      taints               = ["none:PreferNoSchedule"]
+    }
+    node_pools {
+      availability_zones   = ["1", "2", "3"]
+      mode                 = "System"
+      name                 = "agentpool1"
+      count                = 1
+      orchestrator_version = "v1.32.5+rke2r1"
+      os_disk_size_gb      = 128
+      vm_size              = "Standard_DS2_v2"
+      taints               = ["none:PreferNoSchedule"]
+    }
+    node_pools {
+      availability_zones   = ["1", "2", "3"]
+      mode                 = "System"
+      name                 = "agentpool2"
+      count                = 1
+      orchestrator_version = "v1.32.5+rke2r1"
+      os_disk_size_gb      = 128
+      vm_size              = "Standard_DS2_v2"
+      taints               = ["none:PreferNoSchedule"]
+    }
+  }
+}
+
+resource "rancher2_cloud_credential" "tfp-gke" {
+  name = "tfp-gke"
+  google_credential_config {
+    auth_encoded_json = var.google_auth_encoded_json
+  }
+}
+
+resource "rancher2_cluster" "tfp-gke" {
+  name = "tfp-gke"
+  gke_config_v2 {
+    name                     = "tfp-gke"
+    google_credential_secret = rancher2_cloud_credential.tfp-gke.id
+    region                   = "us-central1-c"
+    project_id               = "tfp-project"
+    kubernetes_version       = "v1.32.5+rke2r1"
+    network                  = "default"
+    subnetwork               = "default"
+    node_pools {
+      initial_node_count  = 1
+      max_pods_constraint = 110
+      name                = "tfp-gke-pool0"
+      version             = "v1.32.5+rke2r1"
+    }
+    node_pools {
+      initial_node_count  = 1
+      max_pods_constraint = 110
+      name                = "tfp-gke-pool1"
+      version             = "v1.32.5+rke2r1"
+    }
+    node_pools {
+      initial_node_count  = 1
+      max_pods_constraint = 110
+      name                = "tfp-gke-pool2"
+      version             = "v1.32.5+rke2r1"
+    }
+  }
+}
+
+resource "rancher2_node_template" "tfp-rke1" {
+  name                     = "tfp-rke1"
+  engine_insecure_registry = ["registry.example.com"]
+  amazonec2_config {
+    access_key     = var.aws_access_key
+    secret_key     = var.aws_secret_key
+    region         = "us-east-2"
+    ami            = "ami-0123456789"
+    instance_type  = "t3a.xlarge"
+    ssh_user       = "ubuntu"
+    volume_type    = "gp3"
+    root_size      = 100
+    security_group = ["tfp-sg"]
+    subnet_id      = "subnet-0123456789"
+    vpc_id         = "vpc-0123456789"
+    zone           = "a"
+  }
+}
+
+resource "rancher2_cluster" "tfp-rke1" {
+  depends_on                                                 = [rancher2_node_template.tfp-rke1]
+  name                                                       = "tfp-rke1"
+  default_pod_security_admission_configuration_template_name = ""
+  rke_config {
+    kubernetes_version = "v1.32.5+rke2r1"
+    network {
+      plugin = "calico"
+    }
+    private_registries {
+      url      = "registry.example.com"
+      user     = "registry-user"
+      password = var.registry_password
+    }
+  }
+}
+
+
+resource "rancher2_node_pool" "tfp-rke1node-pool0" {
+  depends_on       = [rancher2_cluster.tfp-rke1]
+  cluster_id       = rancher2_cluster.tfp-rke1.id
+  name             = "tfp-rke10"
+  hostname_prefix  = "tfp-rke1-pool0"
+  node_template_id = rancher2_node_template.tfp-rke1.id
+  quantity         = 1
+  control_plane    = false
+  etcd             = true
+  worker           = false
+}
+
+resource "rancher2_node_pool" "tfp-rke1node-pool1" {
+  depends_on       = [rancher2_cluster.tfp-rke1]
+  cluster_id       = rancher2_cluster.tfp-rke1.id
+  name             = "tfp-rke11"
+  hostname_prefix  = "tfp-rke1-pool1"
+  node_template_id = rancher2_node_template.tfp-rke1.id
+  quantity         = 1
+  control_plane    = true
+  etcd             = false
+  worker           = false
+}
+
+resource "rancher2_node_pool" "tfp-rke1node-pool2" {
+  depends_on       = [rancher2_cluster.tfp-rke1]
+  cluster_id       = rancher2_cluster.tfp-rke1.id
+  name             = "tfp-rke12"
+  hostname_prefix  = "tfp-rke1-pool2"
+  node_template_id = rancher2_node_template.tfp-rke1.id
+  quantity         = 1
+  control_plane    = false
+  etcd             = false
+  worker           = true
+}
+
+resource "rancher2_cluster_sync" "tfp-rke1" {
+  cluster_id    = rancher2_cluster.tfp-rke1.id
+  node_pool_ids = []
+  state_confirm = 2
+}
+
+
+variable "aws_access_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "aws_secret_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "azure_client_secret" {
+  type      = string
+  sensitive = true
+}
+
+variable "google_auth_encoded_json" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_user_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "registry_password" {
+  type      = string
+  sensitive = true
+}
+
@@ -0,0 +1,169 @@
+terraform {
+  required_providers {
+    rancher2 = {
+      source  = "rancher/rancher2"
+      version = "8.0.0"
+    }
+  }
+}
+
+provider "rancher2" {
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_token
+  insecure  = true
+}
+
+provider "rancher2" {
+  alias     = "admin_user"
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_user_token
+  insecure  = true
+}
+
+
+resource "rancher2_cloud_credential" "tfp" {
+  name = "tfp"
+  vsphere_credential_config {
+    password     = var.vsphere_password
+    username     = "vsphere-user"
+    vcenter      = "vcenter.example.com"
+    vcenter_port = "443"
+  }
+}
+
+resource "rancher2_machine_config_v2" "tfp" {
+  generate_name = "tfp"
+  vsphere_config {
+    boot2docker_url   = "https://releases.rancher.com/os/latest/rancheros-vmware.iso"
+    cfgparam          = ["disk.enableUUID=TRUE"]
+    clone_from        = "tfp-template"
+    cloud_config      = ""
+    cloudinit         = ""
+    content_library   = ""
+    cpu_count         = "4"
+    creation_type     = "template"
+    datacenter        = "/tfp-datacenter"
+    datastore         = "/tfp-datacenter/datastore/tfp-datastore"
+    datastore_cluster = ""
+    disk_size         = "40000"
+    folder            = "/tfp-datacenter/vm/tfp"
+    hostsystem        = "/tfp-datacenter/host/tfp-cluster"
+    memory_size       = "8192"
+    network           = ["/tfp-datacenter/network/VM Network"]
+    pool              = "/tfp-datacenter/host/tfp-cluster/Resources"
+    ssh_password      = var.vsphere_ssh_password
+    ssh_port          = "22"
+    ssh_user          = "docker"
+    ssh_user_group    = "staff"
+  }
+}
+
+resource "rancher2_machine_config_v2" "tfp-2" {
+  generate_name = "tfp-2"
+  vsphere_config {
+    boot2docker_url   = "https://releases.rancher.com/os/latest/rancheros-vmware.iso"
+    cfgparam          = ["disk.enableUUID=TRUE"]
+    clone_from        = "tfp-template"
+    cloud_config      = ""
+    cloudinit         = ""
+    content_library   = ""
+    cpu_count         = "8"
+    creation_type     = "template"
+    datacenter        = "/tfp-datacenter"
+    datastore         = "/tfp-datacenter/datastore/tfp-datastore"
+    datastore_cluster = ""
+    disk_size         = "80000"
+    folder            = "/tfp-datacenter/vm/tfp"
+    hostsystem        = "/tfp-datacenter/host/tfp-cluster"
+    memory_size       = "16384"
+    network           = ["/tfp-datacenter/network/VM Network"]
+    pool              = "/tfp-datacenter/host/tfp-cluster/Resources"
+    ssh_password      = var.vsphere_ssh_password
+    ssh_port          = "22"
+    ssh_user          = "docker"
+    ssh_user_group    = "staff"
+  }
+}
+
+resource "rancher2_cluster_v2" "tfp" {
+  name                                                       = "tfp"
+  kubernetes_version                                         = "v1.32.5+rke2r1"
+  enable_network_policy                                      = false
+  default_pod_security_admission_configuration_template_name = ""
+  default_cluster_role_for_project_members                   = "true"
+  rke_config {
+    machine_global_config = <<EOF
+cni: calico
+disable-kube-proxy: 
+EOF
+    machine_pools {
+      name                         = "tfp0"
+      cloud_credential_secret_name = rancher2_cloud_credential.tfp.id
+      control_plane_role           = false
+      etcd_role                    = true
+      worker_role                  = false
+      quantity                     = 1
+      machine_config {
+        kind = rancher2_machine_config_v2.tfp.kind
+        name = rancher2_machine_config_v2.tfp.name
+      }
+    }
+    machine_pools {
+      name                         = "tfp1"
+      cloud_credential_secret_name = rancher2_cloud_credential.tfp.id
+      control_plane_role           = true
+      etcd_role                    = false
+      worker_role                  = false
+      quantity                     = 1
+      machine_config {
+        kind = rancher2_machine_config_v2.tfp.kind
+        name = rancher2_machine_config_v2.tfp.name
+      }
+    }
+    machine_pools {
+      name                         = "tfp2"
+      cloud_credential_secret_name = rancher2_cloud_credential.tfp.id
+      control_plane_role           = false
+      etcd_role                    = false
+      worker_role                  = true
+      quantity                     = 1
+      machine_config {
+        kind = rancher2_machine_config_v2.tfp-2.kind
+        name = rancher2_machine_config_v2.tfp-2.name
+      }
+    }
+    machine_pools {
+      name                         = "tfp3"
+      cloud_credential_secret_name = rancher2_cloud_credential.tfp.id
+      control_plane_role           = false
+      etcd_role                    = false
+      worker_role                  = true
+      quantity                     = 2
+      machine_config {
+        kind = rancher2_machine_config_v2.tfp-2.kind
+        name = rancher2_machine_config_v2.tfp-2.name
+      }
+    }
+  }
+}
+
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_user_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "vsphere_password" {
+  type      = string
+  sensitive = true
+}
+
+variable "vsphere_ssh_password" {
+  type      = string
+  sensitive = true
+}
+
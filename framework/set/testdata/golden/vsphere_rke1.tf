@@ -0,0 +1,130 @@
+terraform {
+  required_providers {
+    rancher2 = {
+      source  = "rancher/rancher2"
+      version = "8.0.0"
+    }
+  }
+}
+
+provider "rancher2" {
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_token
+  insecure  = true
+}
+
+provider "rancher2" {
+  alias     = "admin_user"
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_user_token
+  insecure  = true
+}
+
+
+resource "rancher2_node_template" "tfp" {
+  name                     = "tfp"
+  engine_insecure_registry = ["registry.example.com"]
+  vsphere_config {
+    boot2docker_url   = "https://releases.rancher.com/os/latest/rancheros-vmware.iso"
+    cfgparam          = ["disk.enableUUID=TRUE"]
+    clone_from        = "tfp-template"
+    cloud_config      = ""
+    cloudinit         = ""
+    content_library   = ""
+    cpu_count         = "4"
+    creation_type     = "template"
+    datacenter        = "/tfp-datacenter"
+    datastore         = "/tfp-datacenter/datastore/tfp-datastore"
+    datastore_cluster = ""
+    disk_size         = "40000"
+    folder            = "/tfp-datacenter/vm/tfp"
+    hostsystem        = "/tfp-datacenter/host/tfp-cluster"
+    memory_size       = "8192"
+    network           = ["/tfp-datacenter/network/VM Network"]
+    password          = var.vsphere_password
+    pool              = "/tfp-datacenter/host/tfp-cluster/Resources"
+    ssh_password      = var.vsphere_ssh_password
+    ssh_port          = "22"
+    ssh_user          = "docker"
+    ssh_user_group    = "staff"
+    username          = "vsphere-user"
+    vcenter           = "vcenter.example.com"
+    vcenter_port      = "443"
+  }
+}
+
+resource "rancher2_cluster" "tfp" {
+  depends_on                                                 = [rancher2_node_template.tfp]
+  name                                                       = "tfp"
+  default_pod_security_admission_configuration_template_name = ""
+  rke_config {
+    kubernetes_version = "v1.32.5+rke2r1"
+    network {
+      plugin = "calico"
+    }
+  }
+}
+
+resource "rancher2_node_pool" "tfpnode-pool0" {
+  depends_on       = [rancher2_cluster.tfp]
+  cluster_id       = rancher2_cluster.tfp.id
+  name             = "tfp0"
+  hostname_prefix  = "tfp-pool0"
+  node_template_id = rancher2_node_template.tfp.id
+  quantity         = 1
+  control_plane    = false
+  etcd             = true
+  worker           = false
+}
+
+resource "rancher2_node_pool" "tfpnode-pool1" {
+  depends_on       = [rancher2_cluster.tfp]
+  cluster_id       = rancher2_cluster.tfp.id
+  name             = "tfp1"
+  hostname_prefix  = "tfp-pool1"
+  node_template_id = rancher2_node_template.tfp.id
+  quantity         = 1
+  control_plane    = true
+  etcd             = false
+  worker           = false
+}
+
+resource "rancher2_node_pool" "tfpnode-pool2" {
+  depends_on       = [rancher2_cluster.tfp]
+  cluster_id       = rancher2_cluster.tfp.id
+  name             = "tfp2"
+  hostname_prefix  = "tfp-pool2"
+  node_template_id = rancher2_node_template.tfp.id
+  quantity         = 1
+  control_plane    = false
+  etcd             = false
+  worker           = true
+}
+
+resource "rancher2_cluster_sync" "tfp" {
+  cluster_id    = rancher2_cluster.tfp.id
+  node_pool_ids = []
+  state_confirm = 2
+}
+
+
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_user_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "vsphere_password" {
+  type      = string
+  sensitive = true
+}
+
+variable "vsphere_ssh_password" {
+  type      = string
+  sensitive = true
+}
+
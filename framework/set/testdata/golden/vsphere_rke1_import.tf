@@ -0,0 +1,251 @@
+terraform {
+  required_providers {
+    rancher2 = {
+      source  = "rancher/rancher2"
+      version = "8.0.0"
+    }
+    vsphere = {
+      source  = "vmware/vsphere"
+      version = "5.95.0"
+    }
+    local = {
+      source  = "hashicorp/local"
+      version = "2.5.2"
+    }
+    rke = {
+      source  = "rancher/rke"
+      version = "1.7.7"
+    }
+  }
+}
+
+provider "vsphere" {
+  user                 = "vsphere-user"
+  password             = var.vsphere_password
+  vsphere_server       = "vcenter.example.com"
+  allow_unverified_ssl = true
+}
+
+provider "local" {
+}
+
+provider "rancher2" {
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_token
+  insecure  = true
+}
+
+provider "rancher2" {
+  alias     = "admin_user"
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_user_token
+  insecure  = true
+}
+
+
+resource "rancher2_cluster" "tfp" {
+  name        = "tfp"
+  description = "tfp-automation imported cluster"
+}
+
+data "vsphere_datacenter" "vsphere_datacenter" {
+  name = "/tfp-datacenter"
+}
+
+data "vsphere_compute_cluster" "vsphere_compute_cluster" {
+  name          = "/tfp-datacenter/host/tfp-cluster"
+  datacenter_id = data.vsphere_datacenter.vsphere_datacenter.id
+}
+
+data "vsphere_network" "vsphere_network" {
+  name          = "VM Network"
+  datacenter_id = data.vsphere_datacenter.vsphere_datacenter.id
+}
+
+data "vsphere_datastore" "vsphere_datastore" {
+  name          = "/tfp-datacenter/datastore/tfp-datastore"
+  datacenter_id = data.vsphere_datacenter.vsphere_datacenter.id
+}
+
+data "vsphere_virtual_machine" "vsphere_virtual_machine_template" {
+  name          = "tfp-template"
+  datacenter_id = data.vsphere_datacenter.vsphere_datacenter.id
+}
+
+resource "vsphere_virtual_machine" "tfp_server1" {
+  name             = "tfp_server1"
+  resource_pool_id = data.vsphere_compute_cluster.vsphere_compute_cluster.resource_pool_id
+  datastore_id     = data.vsphere_datastore.vsphere_datastore.id
+  folder           = "/tfp-datacenter/vm/tfp"
+  num_cpus         = 4
+  memory           = 8192
+  guest_id         = ""
+
+  cdrom {
+    client_device = true
+  }
+
+  vapp {
+    properties = {
+      public-keys = "testdata/id_rsa"
+    }
+  }
+  network_interface {
+    network_id = data.vsphere_network.vsphere_network.id
+  }
+
+  disk {
+    label = "tfp_server1"
+    size  = 40000
+  }
+
+  clone {
+    template_uuid = data.vsphere_virtual_machine.vsphere_virtual_machine_template.id
+  }
+
+  extra_config = {
+    disk_enable_uuid = true
+  }
+}
+
+resource "vsphere_virtual_machine" "tfp_server2" {
+  name             = "tfp_server2"
+  resource_pool_id = data.vsphere_compute_cluster.vsphere_compute_cluster.resource_pool_id
+  datastore_id     = data.vsphere_datastore.vsphere_datastore.id
+  folder           = "/tfp-datacenter/vm/tfp"
+  num_cpus         = 4
+  memory           = 8192
+  guest_id         = ""
+
+  cdrom {
+    client_device = true
+  }
+
+  vapp {
+    properties = {
+      public-keys = "testdata/id_rsa"
+    }
+  }
+  network_interface {
+    network_id = data.vsphere_network.vsphere_network.id
+  }
+
+  disk {
+    label = "tfp_server2"
+    size  = 40000
+  }
+
+  clone {
+    template_uuid = data.vsphere_virtual_machine.vsphere_virtual_machine_template.id
+  }
+
+  extra_config = {
+    disk_enable_uuid = true
+  }
+}
+
+resource "vsphere_virtual_machine" "tfp_server3" {
+  name             = "tfp_server3"
+  resource_pool_id = data.vsphere_compute_cluster.vsphere_compute_cluster.resource_pool_id
+  datastore_id     = data.vsphere_datastore.vsphere_datastore.id
+  folder           = "/tfp-datacenter/vm/tfp"
+  num_cpus         = 4
+  memory           = 8192
+  guest_id         = ""
+
+  cdrom {
+    client_device = true
+  }
+
+  vapp {
+    properties = {
+      public-keys = "testdata/id_rsa"
+    }
+  }
+  network_interface {
+    network_id = data.vsphere_network.vsphere_network.id
+  }
+
+  disk {
+    label = "tfp_server3"
+    size  = 40000
+  }
+
+  clone {
+    template_uuid = data.vsphere_virtual_machine.vsphere_virtual_machine_template.id
+  }
+
+  extra_config = {
+    disk_enable_uuid = true
+  }
+}
+
+resource "rke_cluster" "tfp" {
+  nodes {
+    address = "${vsphere_virtual_machine.tfp_server1.default_ip_address}"
+    user    = "ubuntu"
+    role    = ["controlplane", "etcd", "worker"]
+    ssh_key = file("testdata/id_rsa")
+  }
+  nodes {
+    address = "${vsphere_virtual_machine.tfp_server2.default_ip_address}"
+    user    = "ubuntu"
+    role    = ["controlplane", "etcd", "worker"]
+    ssh_key = file("testdata/id_rsa")
+  }
+  nodes {
+    address = "${vsphere_virtual_machine.tfp_server3.default_ip_address}"
+    user    = "ubuntu"
+    role    = ["controlplane", "etcd", "worker"]
+    ssh_key = file("testdata/id_rsa")
+  }
+  enable_cri_dockerd = true
+  depends_on         = [vsphere_virtual_machine.tfp_server1, vsphere_virtual_machine.tfp_server2, vsphere_virtual_machine.tfp_server3]
+}
+
+resource "null_resource" "tfp_copy_script" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${vsphere_virtual_machine.tfp_server1.default_ip_address}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["echo '#!/bin/bash\n\nPEM_FILE=$1\nUSER=$2\nGROUP=$3\nNODE_ONE_PUBLIC_DNS=$4\nIMPORT_COMMAND=$5\nRKE_KUBE_CONFIG_FILE=$${6}\n\nset -ex\n\nARCH=$(uname -m)\nif [[ $ARCH == \"x86_64\" ]]; then\n    ARCH=\"amd64\"\nelif [[ $ARCH == \"arm64\" || $ARCH == \"aarch64\" ]]; then\n    ARCH=\"arm64\"\nfi\n\necho \"Installing kubectl\"\ncurl -LO \"https://dl.k8s.io/release/$(curl -L -s https://dl.k8s.io/release/stable.txt)/bin/linux/$${ARCH}/kubectl\"\nsudo install -o root -g root -m 0755 kubectl /usr/local/bin/kubectl\nmkdir -p ~/.kube\nrm kubectl\n\nif [ -n \"$${RKE_KUBE_CONFIG_FILE}\" ]; then\n    echo \"$${RKE_KUBE_CONFIG_FILE}\" > /home/$USER/.kube/config\nfi\n\necho $${PEM_FILE} | sudo base64 -d > /home/$${USER}/key.pem\necho \"$${IMPORT_COMMAND}\" > /home/$${USER}/import_command.txt\nIMPORT_COMMAND=$(cat /home/$USER/import_command.txt)\n\nPEM=/home/$${USER}/key.pem\nsudo chmod 600 $${PEM}\nsudo chown $${USER}:$${GROUP} $${PEM}\n\neval \"$IMPORT_COMMAND\"' > /tmp/import-nodes.sh", "chmod +x /tmp/import-nodes.sh"]
+  }
+  depends_on = [rke_cluster.tfp]
+}
+
+resource "null_resource" "tfp_import_cluster" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "${vsphere_virtual_machine.tfp_server1.default_ip_address}"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["bash -c '/tmp/import-nodes.sh ${var.private_key_base64} ubuntu ubuntu ${vsphere_virtual_machine.tfp_server1.default_ip_address} \"${rancher2_cluster.tfp.cluster_registration_token[0].insecure_command}\" \"${rke_cluster.tfp.kube_config_yaml}\"'"]
+  }
+  depends_on = [null_resource.tfp_copy_script]
+}
+
+variable "private_key_base64" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_user_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "vsphere_password" {
+  type      = string
+  sensitive = true
+}
+
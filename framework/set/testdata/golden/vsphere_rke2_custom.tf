@@ -0,0 +1,185 @@
+terraform {
+  required_providers {
+    rancher2 = {
+      source  = "rancher/rancher2"
+      version = "8.0.0"
+    }
+    vsphere = {
+      source  = "vmware/vsphere"
+      version = "5.95.0"
+    }
+    local = {
+      source  = "hashicorp/local"
+      version = "2.5.2"
+    }
+  }
+}
+
+provider "vsphere" {
+  user                 = "vsphere-user"
+  password             = var.vsphere_password
+  vsphere_server       = "vcenter.example.com"
+  allow_unverified_ssl = true
+}
+
+provider "local" {
+}
+
+provider "rancher2" {
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_token
+  insecure  = true
+}
+
+provider "rancher2" {
+  alias     = "admin_user"
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_user_token
+  insecure  = true
+}
+
+
+data "vsphere_datacenter" "vsphere_datacenter" {
+  name = "/tfp-datacenter"
+}
+
+data "vsphere_compute_cluster" "vsphere_compute_cluster" {
+  name          = "/tfp-datacenter/host/tfp-cluster"
+  datacenter_id = data.vsphere_datacenter.vsphere_datacenter.id
+}
+
+data "vsphere_network" "vsphere_network" {
+  name          = "VM Network"
+  datacenter_id = data.vsphere_datacenter.vsphere_datacenter.id
+}
+
+data "vsphere_datastore" "vsphere_datastore" {
+  name          = "/tfp-datacenter/datastore/tfp-datastore"
+  datacenter_id = data.vsphere_datacenter.vsphere_datacenter.id
+}
+
+data "vsphere_virtual_machine" "vsphere_virtual_machine_template" {
+  name          = "tfp-template"
+  datacenter_id = data.vsphere_datacenter.vsphere_datacenter.id
+}
+
+resource "vsphere_virtual_machine" "tfp" {
+  count            = 3
+  name             = "tfp-${count.index}"
+  resource_pool_id = data.vsphere_compute_cluster.vsphere_compute_cluster.resource_pool_id
+  datastore_id     = data.vsphere_datastore.vsphere_datastore.id
+  folder           = "/tfp-datacenter/vm/tfp"
+  num_cpus         = 4
+  memory           = 8192
+  guest_id         = ""
+
+  cdrom {
+    client_device = true
+  }
+
+  vapp {
+    properties = {
+      public-keys = "testdata/id_rsa"
+    }
+  }
+  network_interface {
+    network_id = data.vsphere_network.vsphere_network.id
+  }
+
+  disk {
+    label = "tfp-${count.index}"
+    size  = 40000
+  }
+
+  clone {
+    template_uuid = data.vsphere_virtual_machine.vsphere_virtual_machine_template.id
+  }
+
+  extra_config = {
+    disk_enable_uuid = true
+  }
+}
+
+resource "rancher2_cluster_v2" "tfp" {
+  name               = "tfp"
+  kubernetes_version = "v1.32.5+rke2r1"
+  rke_config {
+    machine_global_config = <<EOF
+cni: calico
+EOF
+    machine_selector_config {
+      config = <<EOF
+system-default-registry: registry.example.com
+EOF
+    }
+    registries {
+      configs {
+        hostname                = "registry.example.com"
+        auth_config_secret_name = "registry-auth-tfp"
+        tls_secret_name         = ""
+        ca_bundle               = ""
+        insecure                = true
+      }
+      mirrors {
+        hostname  = "docker.io"
+        endpoints = ["https://registry.example.com"]
+      }
+    }
+  }
+}
+
+resource "rancher2_secret_v2" "tfp" {
+  provider   = rancher2.admin_user
+  cluster_id = "local"
+  name       = "registry-auth-tfp"
+  namespace  = "fleet-default"
+  type       = "kubernetes.io/basic-auth"
+  data = {
+    password = var.registry_password
+    username = "registry-user"
+  }
+}
+
+resource "null_resource" "register_nodes-tfp" {
+  count = length(vsphere_virtual_machine.tfp)
+  provisioner "remote-exec" {
+    inline = ["${local.tfp_insecure_node_command} ${local.role_flags[count.index]} --node-name ${local.resource_prefix[count.index]}"]
+    connection {
+      type        = "ssh"
+      user        = "ubuntu"
+      host        = "${vsphere_virtual_machine.tfp[count.index].default_ip_address}"
+      private_key = file("testdata/id_rsa")
+    }
+  }
+  depends_on = [rancher2_cluster_v2.tfp]
+}
+
+locals {
+  role_flags                        = ["--etcd", "--controlplane", "--worker"]
+  resource_prefix                   = [for i in range(3) : "tfp-${i}"]
+  tfp_original_node_command         = rancher2_cluster_v2.tfp.cluster_registration_token[0].node_command
+  tfp_windows_original_node_command = rancher2_cluster_v2.tfp.cluster_registration_token[0].windows_node_command
+  tfp_insecure_node_command         = "${replace(local.tfp_original_node_command, "curl", "curl --insecure")}"
+  tfp_insecure_windows_node_command = "${replace(local.tfp_windows_original_node_command, "curl.exe", "curl.exe --insecure")}"
+}
+
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_user_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "registry_password" {
+  type      = string
+  sensitive = true
+}
+
+variable "vsphere_password" {
+  type      = string
+  sensitive = true
+}
+
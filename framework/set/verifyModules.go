@@ -0,0 +1,73 @@
+package set
+
+import (
+	"fmt"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	management "github.com/rancher/shepherd/clients/rancher/generated/management/v3"
+)
+
+// verifyAKSNodeCount is a function that will verify the node count of an AKS cluster against its first node pool.
+func verifyAKSNodeCount(client *rancher.Client, clusterID string, nodeCount int64) error {
+	cluster, err := client.Management.Cluster.ByID(clusterID)
+	if err != nil {
+		return err
+	}
+
+	if cluster.AKSConfig == nil || cluster.AKSConfig.NodePools == nil || len(*cluster.AKSConfig.NodePools) == 0 {
+		return fmt.Errorf("cluster %s has no AKS node pools", cluster.Name)
+	}
+
+	return compareNodeCount(cluster, (*cluster.AKSConfig.NodePools)[0].Count)
+}
+
+// verifyEKSNodeCount is a function that will verify the node count of an EKS cluster against its first node group.
+func verifyEKSNodeCount(client *rancher.Client, clusterID string, nodeCount int64) error {
+	cluster, err := client.Management.Cluster.ByID(clusterID)
+	if err != nil {
+		return err
+	}
+
+	if cluster.EKSConfig == nil || cluster.EKSConfig.NodeGroups == nil || len(*cluster.EKSConfig.NodeGroups) == 0 {
+		return fmt.Errorf("cluster %s has no EKS node groups", cluster.Name)
+	}
+
+	return compareNodeCount(cluster, (*cluster.EKSConfig.NodeGroups)[0].DesiredSize)
+}
+
+// verifyGKENodeCount is a function that will verify the node count of a GKE cluster against its first node pool.
+func verifyGKENodeCount(client *rancher.Client, clusterID string, nodeCount int64) error {
+	cluster, err := client.Management.Cluster.ByID(clusterID)
+	if err != nil {
+		return err
+	}
+
+	if cluster.GKEConfig == nil || cluster.GKEConfig.NodePools == nil || len(*cluster.GKEConfig.NodePools) == 0 {
+		return fmt.Errorf("cluster %s has no GKE node pools", cluster.Name)
+	}
+
+	return compareNodeCount(cluster, (*cluster.GKEConfig.NodePools)[0].InitialNodeCount)
+}
+
+// verifyNodeCount is a function that will verify the node count of an RKE1, RKE2 or K3s cluster.
+func verifyNodeCount(client *rancher.Client, clusterID string, nodeCount int64) error {
+	cluster, err := client.Management.Cluster.ByID(clusterID)
+	if err != nil {
+		return err
+	}
+
+	return compareNodeCount(cluster, &nodeCount)
+}
+
+// compareNodeCount is a helper function that will compare the node count of a cluster against the expected node count.
+func compareNodeCount(cluster *management.Cluster, expected *int64) error {
+	if expected == nil {
+		return fmt.Errorf("cluster %s has no expected node count", cluster.Name)
+	}
+
+	if cluster.NodeCount != *expected {
+		return fmt.Errorf("cluster %s expected %d nodes, found %d", cluster.Name, *expected, cluster.NodeCount)
+	}
+
+	return nil
+}
@@ -15,6 +15,11 @@ import (
 func Setup(t *testing.T, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, keyPath string) *terraform.Options {
 	var terratestLogger logger.Logger
 
+	err := ValidateEngine(Engine(terratestConfig))
+	if err != nil {
+		t.Fatalf("Invalid terratest configuration. Error: %v", err)
+	}
+
 	if strings.Contains(keyPath, keypath.RancherKeyPath) {
 		terratestLogger = getLogger(terratestConfig.TFLogging)
 	} else {
@@ -22,9 +27,10 @@ func Setup(t *testing.T, terraformConfig *config.TerraformConfig, terratestConfi
 	}
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
-		TerraformDir: keyPath,
-		NoColor:      true,
-		Logger:       &terratestLogger,
+		TerraformDir:    keyPath,
+		TerraformBinary: Engine(terratestConfig),
+		NoColor:         true,
+		Logger:          &terratestLogger,
 	})
 
 	return terraformOptions
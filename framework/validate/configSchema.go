@@ -0,0 +1,347 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/authproviders"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/defaults/engines"
+	"github.com/rancher/tfp-automation/defaults/providers"
+	"github.com/rancher/tfp-automation/framework/set"
+	"github.com/rancher/tfp-automation/framework/set/backend"
+)
+
+const (
+	jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+	modulePath      = "github.com/rancher/tfp-automation"
+
+	// ConfigSchemaFile is the path of the generated JSON Schema, relative to the root of the repo.
+	ConfigSchemaFile = "config/cattle-config.schema.json"
+)
+
+var (
+	_, callerFilePath, _, _ = runtime.Caller(0)
+	basepath                = filepath.Join(filepath.Dir(callerFilePath), "..", "..")
+)
+
+// JSONSchema is the subset of JSON Schema that is generated for the cattle config.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	AdditionalProperties any                    `json:"additionalProperties,omitempty"`
+}
+
+// configEnums are the values allowed for fields of the config structs, keyed by struct and then by YAML key.
+func configEnums() map[reflect.Type]map[string][]string {
+	return map[reflect.Type]map[string][]string{
+		reflect.TypeOf(config.TerraformConfig{}): {
+			"module":       set.SupportedModules(),
+			"provider":     {providers.AWS, providers.Azure, providers.Google, providers.Harvester, providers.Linode, providers.Vsphere},
+			"cni":          {"calico", "canal", "cilium", "flannel", "none"},
+			"authProvider": {authproviders.AD, authproviders.AzureAD, authproviders.GitHub, authproviders.Okta, authproviders.OpenLDAP},
+		},
+		reflect.TypeOf(config.TerratestConfig{}): {
+			"psact":  {string(config.RancherPrivileged), string(config.RancherRestricted)},
+			"engine": {engines.Terraform, engines.Tofu},
+		},
+		reflect.TypeOf(config.Snapshots{}): {
+			"snapshotRestore": {config.RestoreNone, config.RestoreKubernetesVersion, config.RestoreAll},
+		},
+		reflect.TypeOf(config.Standalone{}): {
+			"certType": {"self-signed", "lets-encrypt"},
+		},
+		reflect.TypeOf(config.Taint{}): {
+			"effect": {"NoSchedule", "PreferNoSchedule", "NoExecute"},
+		},
+		reflect.TypeOf(config.Backend{}): {
+			"type": {backend.S3, backend.GCS, backend.AzureRM, backend.HTTP, backend.Local},
+		},
+	}
+}
+
+// ConfigJSONSchema is a function that will generate a JSON Schema for cattle-config.yaml from the config structs. Field descriptions
+// are taken from the comments on the struct fields, and fields with a fixed set of values, such as terraform.module, are enums.
+// Top level sections other than rancher, terraform and terratest belong to shepherd and are allowed as is.
+func ConfigJSONSchema() (*JSONSchema, error) {
+	comments, err := fieldComments()
+	if err != nil {
+		return nil, err
+	}
+
+	generator := &schemaGenerator{
+		comments: comments,
+		enums:    configEnums(),
+		visiting: map[reflect.Type]bool{},
+	}
+
+	schema := &JSONSchema{
+		Schema:      jsonSchemaDraft,
+		Title:       "cattle-config.yaml",
+		Description: "Configuration of the tfp-automation test suites.",
+		Type:        "object",
+		Properties: map[string]*JSONSchema{
+			configs.Rancher:                      generator.schema(reflect.TypeOf(rancher.Config{})),
+			config.TerraformConfigurationFileKey: generator.schema(reflect.TypeOf(config.TerraformConfig{})),
+			config.TerratestConfigurationFileKey: generator.schema(reflect.TypeOf(config.TerratestConfig{})),
+		},
+		AdditionalProperties: true,
+	}
+
+	return schema, nil
+}
+
+// MarshalConfigJSONSchema is a function that will generate the JSON Schema of the cattle config as indented JSON.
+func MarshalConfigJSONSchema() ([]byte, error) {
+	schema, err := ConfigJSONSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, '\n'), nil
+}
+
+// ConfigMarkdown is a function that will render the terraform and terratest sections of the schema as Markdown tables, one per
+// nested object, so that the configuration reference in the README can be generated from the same schema.
+func ConfigMarkdown(schema *JSONSchema) string {
+	var builder strings.Builder
+
+	for _, section := range []string{config.TerraformConfigurationFileKey, config.TerratestConfigurationFileKey} {
+		writeMarkdownTable(&builder, section, schema.Properties[section])
+	}
+
+	return builder.String()
+}
+
+// writeMarkdownTable is a helper function that will write the table of an object and then the tables of its nested objects.
+func writeMarkdownTable(builder *strings.Builder, path string, schema *JSONSchema) {
+	if schema.Type == "array" && schema.Items != nil {
+		schema = schema.Items
+	}
+
+	if len(schema.Properties) == 0 {
+		return
+	}
+
+	fmt.Fprintf(builder, "#### %s\n\n| Field | Type | Description |\n| --- | --- | --- |\n", path)
+
+	keys := sortedKeys(schema.Properties)
+	for _, key := range keys {
+		property := schema.Properties[key]
+
+		description := property.Description
+		if len(property.Enum) > 0 {
+			description = strings.TrimSpace(description + " One of `" + strings.Join(property.Enum, "`, `") + "`.")
+		}
+
+		fmt.Fprintf(builder, "| `%s` | %s | %s |\n", key, markdownType(property), strings.ReplaceAll(description, "|", "\\|"))
+	}
+
+	builder.WriteString("\n")
+
+	for _, key := range keys {
+		writeMarkdownTable(builder, path+"."+key, schema.Properties[key])
+	}
+}
+
+// markdownType is a helper function that will describe the type of a property for the Markdown tables.
+func markdownType(schema *JSONSchema) string {
+	switch {
+	case schema.Type == "array" && schema.Items != nil && schema.Items.Type != "":
+		return schema.Items.Type + "[]"
+	case schema.Type == "":
+		return "any"
+	default:
+		return schema.Type
+	}
+}
+
+type schemaGenerator struct {
+	comments map[string]map[string]string
+	enums    map[reflect.Type]map[string][]string
+	visiting map[reflect.Type]bool
+}
+
+// schema is a helper function that will generate the schema of a type the way it is decoded from YAML.
+func (g *schemaGenerator) schema(typ reflect.Type) *JSONSchema {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	// Types with their own decoding, such as resource quantities and durations, are not constrained.
+	if reflect.PointerTo(typ).Implements(jsonUnmarshalerType) || reflect.PointerTo(typ).Implements(textUnmarshalerType) {
+		return &JSONSchema{}
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		if g.visiting[typ] {
+			return &JSONSchema{Type: "object"}
+		}
+
+		g.visiting[typ] = true
+		defer delete(g.visiting, typ)
+
+		schema := &JSONSchema{Type: "object", Properties: map[string]*JSONSchema{}, AdditionalProperties: false}
+		g.structProperties(typ, schema.Properties)
+
+		return schema
+	case reflect.Map:
+		return &JSONSchema{Type: "object", AdditionalProperties: g.schema(typ.Elem())}
+	case reflect.Slice, reflect.Array:
+		return &JSONSchema{Type: "array", Items: g.schema(typ.Elem())}
+	case reflect.String:
+		return &JSONSchema{Type: "string"}
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JSONSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	default:
+		return &JSONSchema{}
+	}
+}
+
+// structProperties is a helper function that will add a property for every field of a struct, including the fields of embedded
+// structs, which are decoded inline.
+func (g *schemaGenerator) structProperties(typ reflect.Type, properties map[string]*JSONSchema) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+
+			if embedded.Kind() == reflect.Struct {
+				g.structProperties(embedded, properties)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		if _, ok := properties[name]; ok {
+			continue
+		}
+
+		property := g.schema(field.Type)
+		property.Description = g.comments[typ.PkgPath()+"."+typ.Name()][field.Name]
+
+		if enum, ok := g.enums[typ][name]; ok {
+			values := append([]string(nil), enum...)
+			sort.Strings(values)
+
+			if property.Type == "array" && property.Items != nil {
+				property.Items.Enum = values
+			} else {
+				property.Enum = values
+			}
+		}
+
+		properties[name] = property
+	}
+}
+
+// fieldComments is a helper function that will parse the config packages of this repo and return the comment of every struct field,
+// keyed by the package path and name of the struct and then by the field name.
+func fieldComments() (map[string]map[string]string, error) {
+	comments := map[string]map[string]string{}
+
+	nodeProviderDirs, err := filepath.Glob(filepath.Join(basepath, "config", "nodeproviders", "*"))
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := append([]string{filepath.Join(basepath, "config"), filepath.Join(basepath, "config", "authproviders")}, nodeProviderDirs...)
+
+	for _, dir := range dirs {
+		rel, err := filepath.Rel(basepath, dir)
+		if err != nil {
+			return nil, err
+		}
+
+		pkgPath := modulePath + "/" + filepath.ToSlash(rel)
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+				continue
+			}
+
+			file, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, entry.Name()), nil, parser.ParseComments)
+			if err != nil {
+				return nil, err
+			}
+
+			ast.Inspect(file, func(node ast.Node) bool {
+				typeSpec, ok := node.(*ast.TypeSpec)
+				if !ok {
+					return true
+				}
+
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok {
+					return false
+				}
+
+				fields := map[string]string{}
+				for _, field := range structType.Fields.List {
+					comment := field.Doc.Text()
+					if comment == "" {
+						comment = field.Comment.Text()
+					}
+
+					for _, name := range field.Names {
+						fields[name.Name] = strings.Join(strings.Fields(comment), " ")
+					}
+				}
+
+				comments[pkgPath+"."+typeSpec.Name.Name] = fields
+
+				return false
+			})
+		}
+	}
+
+	return comments, nil
+}
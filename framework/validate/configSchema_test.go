@@ -0,0 +1,40 @@
+package validate
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the generated config schema")
+
+func TestConfigJSONSchema(t *testing.T) {
+	schema, err := ConfigJSONSchema()
+	require.NoError(t, err)
+
+	terraform := schema.Properties["terraform"]
+	require.Equal(t, false, terraform.AdditionalProperties)
+	require.Contains(t, terraform.Properties["module"].Enum, "ec2_rke2")
+	require.Equal(t, []string{"rancher-privileged", "rancher-restricted"}, schema.Properties["terratest"].Properties["psact"].Enum)
+	require.Equal(t, "integer", schema.Properties["terratest"].Properties["nodepools"].Items.Properties["quantity"].Type)
+	require.Equal(t, "array", terraform.Properties["awsConfig"].Properties["awsSecurityGroups"].Type)
+	require.NotEmpty(t, terraform.Properties["module"].Description)
+	require.Contains(t, ConfigMarkdown(schema), "| `module` | string |")
+
+	data, err := MarshalConfigJSONSchema()
+	require.NoError(t, err)
+	require.True(t, json.Valid(data))
+
+	schemaFile := filepath.Join(basepath, ConfigSchemaFile)
+	if *update {
+		require.NoError(t, os.WriteFile(schemaFile, data, 0644))
+	}
+
+	expected, err := os.ReadFile(schemaFile)
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(data), "%s is out of date, run `go run ./pipeline/schema`", ConfigSchemaFile)
+}
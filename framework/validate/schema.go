@@ -0,0 +1,104 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// ProviderSchemas is the output of `terraform providers schema -json`.
+type ProviderSchemas struct {
+	FormatVersion   string                    `json:"format_version"`
+	ProviderSchemas map[string]ProviderSchema `json:"provider_schemas"`
+}
+
+// ProviderSchema is the schema of a single provider, keyed by its source address in ProviderSchemas.
+type ProviderSchema struct {
+	Provider          Schema            `json:"provider"`
+	ResourceSchemas   map[string]Schema `json:"resource_schemas"`
+	DataSourceSchemas map[string]Schema `json:"data_source_schemas"`
+}
+
+// Schema is the schema of a provider, resource or data source.
+type Schema struct {
+	Version int64 `json:"version"`
+	Block   Block `json:"block"`
+}
+
+// Block is the schema of a block body, made up of attributes and nested blocks.
+type Block struct {
+	Attributes map[string]Attribute   `json:"attributes"`
+	BlockTypes map[string]NestedBlock `json:"block_types"`
+}
+
+// Attribute is the schema of a single attribute.
+type Attribute struct {
+	Type     json.RawMessage `json:"type"`
+	Required bool            `json:"required"`
+	Optional bool            `json:"optional"`
+	Computed bool            `json:"computed"`
+}
+
+// NestedBlock is the schema of a nested block type.
+type NestedBlock struct {
+	NestingMode string `json:"nesting_mode"`
+	Block       Block  `json:"block"`
+	MinItems    int    `json:"min_items"`
+	MaxItems    int    `json:"max_items"`
+}
+
+// LoadProviderSchemas is a function that will load a cached copy of the `terraform providers schema -json` output.
+func LoadProviderSchemas(path string) (*ProviderSchemas, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseProviderSchemas(data)
+}
+
+// ParseProviderSchemas is a function that will parse the `terraform providers schema -json` output.
+func ParseProviderSchemas(data []byte) (*ProviderSchemas, error) {
+	schemas := new(ProviderSchemas)
+
+	err := json.Unmarshal(data, schemas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse provider schemas: %w", err)
+	}
+
+	if len(schemas.ProviderSchemas) == 0 {
+		return nil, fmt.Errorf("provider schemas are empty")
+	}
+
+	return schemas, nil
+}
+
+// GetProviderSchemas is a function that will return the provider schemas from the cached copy at schemaPath. If schemaPath is empty,
+// the schemas are read from `terraform providers schema -json`, which requires the Terraform directory to be initialized.
+func GetProviderSchemas(t *testing.T, terraformOptions *terraform.Options, schemaPath string) (*ProviderSchemas, error) {
+	if schemaPath != "" {
+		return LoadProviderSchemas(schemaPath)
+	}
+
+	output, err := terraform.RunTerraformCommandAndGetStdoutE(t, terraformOptions, "providers", "schema", "-json")
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseProviderSchemas([]byte(output))
+}
+
+// provider returns the schema of the provider with the given local name, e.g. rancher2.
+func (s *ProviderSchemas) provider(name string) (ProviderSchema, bool) {
+	for source, schema := range s.ProviderSchemas {
+		if source == name || strings.HasSuffix(source, "/"+name) {
+			return schema, true
+		}
+	}
+
+	return ProviderSchema{}, false
+}
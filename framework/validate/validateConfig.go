@@ -0,0 +1,668 @@
+package validate
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/certificates"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/defaults/providers"
+	"github.com/rancher/tfp-automation/framework"
+	"github.com/rancher/tfp-automation/framework/set"
+	"github.com/rancher/tfp-automation/framework/set/backend"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/yaml"
+)
+
+// maxSuggestionDistance is the largest edit distance between an unknown key and a known field that is still suggested.
+const maxSuggestionDistance = 3
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// ConfigProblem is a single problem found in the cattle config, along with the YAML path of the offending key.
+type ConfigProblem struct {
+	Path    string
+	Message string
+}
+
+func (p ConfigProblem) Error() string {
+	return p.Path + ": " + p.Message
+}
+
+// ValidateConfigFile is a function that will compose the cattle config at path with the overlays set in CATTLE_TEST_CONFIG_OVERLAYS
+// and validate the effective config with ValidateConfig.
+func ValidateConfigFile(path string) error {
+	cattleConfig, err := config.LoadEffectiveConfig(path, config.OverlaysFromEnvironment())
+	if err != nil {
+		return err
+	}
+
+	return ValidateConfig(cattleConfig)
+}
+
+// ValidateConfig is a function that will strictly decode the rancher, terraform and terratest sections of the cattle config and then
+// run the semantic checks of the configured module. Unlike config.LoadTFPConfigs, unknown keys and values of the wrong type are
+// reported instead of being dropped. The returned error lists every problem found along with its YAML path, one per line.
+func ValidateConfig(cattleConfig map[string]any) error {
+	problems := append(DecodeConfigProblems(cattleConfig), ModuleConfigProblems(cattleConfig)...)
+
+	var errs []error
+	for _, problem := range problems {
+		errs = append(errs, problem)
+	}
+
+	return errors.Join(errs...)
+}
+
+// DecodeConfigProblems is a function that will return every unknown key and every value of the wrong type in the rancher, terraform
+// and terratest sections of the cattle config. Other top level sections belong to shepherd and are not checked.
+func DecodeConfigProblems(cattleConfig map[string]any) []ConfigProblem {
+	sections := []struct {
+		key    string
+		object any
+	}{
+		{configs.Rancher, rancher.Config{}},
+		{config.TerraformConfigurationFileKey, config.TerraformConfig{}},
+		{config.TerratestConfigurationFileKey, config.TerratestConfig{}},
+	}
+
+	var problems []ConfigProblem
+	for _, section := range sections {
+		value, ok := cattleConfig[section.key]
+		if !ok {
+			continue
+		}
+
+		normalized, err := normalize(value)
+		if err != nil {
+			problems = append(problems, ConfigProblem{Path: section.key, Message: err.Error()})
+			continue
+		}
+
+		problems = append(problems, decodeProblems(section.key, normalized, reflect.TypeOf(section.object))...)
+	}
+
+	return problems
+}
+
+// ModuleConfigProblems is a function that will return the settings that the configured module requires but that are not set, e.g.
+// the AMI, region and subnet of EC2 modules or the credentials of hosted modules. Configs without a module are not checked, and neither
+// are configs that cannot be decoded, since DecodeConfigProblems already reports why.
+func ModuleConfigProblems(cattleConfig map[string]any) []ConfigProblem {
+	terraformConfig := new(config.TerraformConfig)
+	terratestConfig := new(config.TerratestConfig)
+
+	err := decodeSection(cattleConfig, config.TerraformConfigurationFileKey, terraformConfig)
+	if err != nil {
+		return nil
+	}
+
+	err = decodeSection(cattleConfig, config.TerratestConfigurationFileKey, terratestConfig)
+	if err != nil {
+		return nil
+	}
+
+	var problems []ConfigProblem
+
+	err = framework.ValidateEngine(framework.Engine(terratestConfig))
+	if err != nil {
+		problems = append(problems, ConfigProblem{Path: "terratest.engine", Message: err.Error()})
+	}
+
+	err = backend.ValidateBackend(terratestConfig.Backend)
+	if err != nil {
+		problems = append(problems, ConfigProblem{Path: "terratest.backend", Message: err.Error()})
+	}
+
+	if terraformConfig.Module == "" {
+		return problems
+	}
+
+	module, err := set.LookupModule(terraformConfig.Module)
+	if err != nil {
+		return append(problems, ConfigProblem{Path: "terraform.module", Message: err.Error()})
+	}
+
+	required := func(path, value string) {
+		if value == "" {
+			problems = append(problems, ConfigProblem{Path: path, Message: "required by module " + module.Name})
+		}
+	}
+
+	aws := terraformConfig.AWSConfig
+	azure := terraformConfig.AzureCredentials
+
+	switch module.Provider {
+	case providers.AWS:
+		required("terraform.awsCredentials.awsAccessKey", terraformConfig.AWSCredentials.AWSAccessKey)
+		required("terraform.awsCredentials.awsSecretKey", terraformConfig.AWSCredentials.AWSSecretKey)
+
+		if module.Mode != set.HostedMode && module.Mode != set.HostedImport {
+			required("terraform.awsConfig.ami", aws.AMI)
+			required("terraform.awsConfig.region", aws.Region)
+			required("terraform.awsConfig.awsSubnetID", aws.AWSSubnetID)
+		}
+
+		switch module.OS {
+		case set.Windows2019:
+			required("terraform.awsConfig.windows2019AMI", aws.Windows2019AMI)
+		case set.Windows2022:
+			required("terraform.awsConfig.windows2022AMI", aws.Windows2022AMI)
+		}
+	case providers.Azure:
+		required("terraform.azureCredentials.clientId", azure.ClientID)
+		required("terraform.azureCredentials.clientSecret", azure.ClientSecret)
+		required("terraform.azureCredentials.subscriptionId", azure.SubscriptionID)
+
+		if module.Mode == set.Custom || module.Mode == set.Import {
+			azureConfig := terraformConfig.AzureConfig
+
+			required("terraform.azureCredentials.tenantId", azure.TenantID)
+			required("terraform.azureConfig.resourceGroup", azureConfig.ResourceGroup)
+			required("terraform.azureConfig.vnet", azureConfig.Vnet)
+			required("terraform.azureConfig.subnet", azureConfig.Subnet)
+			required("terraform.azureConfig.nsg", azureConfig.NSG)
+			required("terraform.azureConfig.size", azureConfig.Size)
+			required("terraform.azureConfig.sshUser", azureConfig.SSHUser)
+			required("terraform.azureConfig.storageType", azureConfig.StorageType)
+
+			if _, err := strconv.ParseInt(azureConfig.DiskSize, 10, 64); err != nil {
+				problems = append(problems, ConfigProblem{Path: "terraform.azureConfig.diskSize", Message: "must be a number of GB, such as 100"})
+			}
+
+			if len(strings.Split(azureConfig.Image, ":")) != 4 {
+				problems = append(problems, ConfigProblem{Path: "terraform.azureConfig.image", Message: "must be in the publisher:offer:sku:version format"})
+			}
+		}
+	case providers.Google:
+		required("terraform.googleCredentials.authEncodedJson", terraformConfig.GoogleCredentials.AuthEncodedJSON)
+		required("terraform.googleConfig.projectID", terraformConfig.GoogleConfig.ProjectID)
+
+		if module.Mode != set.HostedMode && module.Mode != set.HostedImport {
+			googleConfig := terraformConfig.GoogleConfig
+
+			required("terraform.googleConfig.zone", googleConfig.Zone)
+			required("terraform.googleConfig.machineType", googleConfig.MachineType)
+			required("terraform.googleConfig.machineImage", googleConfig.MachineImage)
+			required("terraform.googleConfig.network", googleConfig.Network)
+
+			if module.Mode == set.Custom || module.Mode == set.Import {
+				required("terraform.googleConfig.username", googleConfig.Username)
+
+				if _, err := strconv.ParseInt(googleConfig.DiskSize, 10, 64); err != nil {
+					problems = append(problems, ConfigProblem{Path: "terraform.googleConfig.diskSize", Message: "must be a number of GB, such as 100"})
+				}
+			}
+		}
+	case providers.Linode:
+		required("terraform.linodeCredentials.linodeToken", terraformConfig.LinodeCredentials.LinodeToken)
+	case providers.Harvester:
+		required("terraform.harvesterCredentials.clusterID", terraformConfig.HarvesterCredentials.ClusterID)
+	case providers.Vsphere:
+		required("terraform.vsphereCredentials.username", terraformConfig.VsphereCredentials.Username)
+		required("terraform.vsphereCredentials.password", terraformConfig.VsphereCredentials.Password)
+		required("terraform.vsphereCredentials.vcenter", terraformConfig.VsphereCredentials.Vcenter)
+	}
+
+	switch module.Mode {
+	case set.Custom, set.Import, set.Airgap:
+		required("terraform.privateKeyPath", terraformConfig.PrivateKeyPath)
+
+		if module.IsWindows() {
+			required("terraform.windowsPrivateKeyPath", terraformConfig.WindowsPrivateKeyPath)
+		}
+	case set.HostedImport:
+		required("terraform.hostedClusterName", terraformConfig.HostedClusterName)
+
+		switch module.Provider {
+		case providers.AWS:
+			required("terraform.awsConfig.region", aws.Region)
+		case providers.Azure:
+			required("terraform.azureConfig.resourceGroup", terraformConfig.AzureConfig.ResourceGroup)
+			required("terraform.azureConfig.resourceLocation", terraformConfig.AzureConfig.ResourceLocation)
+		case providers.Google:
+			required("terraform.googleConfig.region", terraformConfig.GoogleConfig.Region)
+			required("terratest.kubernetesVersion", terratestConfig.KubernetesVersion)
+		}
+
+		for i, pool := range terratestConfig.Nodepools {
+			required(fmt.Sprintf("terratest.nodepools[%d].name", i), pool.Name)
+		}
+	}
+
+	if strategy := terraformConfig.UpgradeStrategy; strategy != nil {
+		if module.Mode != set.NodeDriver || module.Distro == set.RKE1 {
+			problems = append(problems, ConfigProblem{
+				Path:    "terraform.upgradeStrategy",
+				Message: "only supported by RKE2/K3s node driver modules, not " + module.Name,
+			})
+		}
+
+		concurrencies := []struct {
+			path  string
+			value string
+		}{
+			{"terraform.upgradeStrategy.controlPlaneConcurrency", strategy.ControlPlaneConcurrency},
+			{"terraform.upgradeStrategy.workerConcurrency", strategy.WorkerConcurrency},
+		}
+
+		for _, concurrency := range concurrencies {
+			if concurrency.value == "" {
+				continue
+			}
+
+			value := intstr.Parse(concurrency.value)
+			if _, err := intstr.GetScaledValueFromIntOrPercent(&value, 100, true); err != nil || value.IntValue() < 0 {
+				problems = append(problems, ConfigProblem{Path: concurrency.path, Message: "must be a number or a percentage, such as 1 or 10%"})
+			}
+		}
+	}
+
+	if snapshotS3 := terratestConfig.SnapshotInput.S3; snapshotS3 != nil {
+		if module.Mode != set.NodeDriver || module.Distro == set.RKE1 {
+			problems = append(problems, ConfigProblem{
+				Path:    "terratest.snapshotInput.s3",
+				Message: "only supported by RKE2/K3s node driver modules, not " + module.Name,
+			})
+		}
+
+		if !snapshotS3.MinIO {
+			required("terratest.snapshotInput.s3.accessKey", snapshotS3.AccessKey)
+			required("terratest.snapshotInput.s3.secretKey", snapshotS3.SecretKey)
+			required("terratest.snapshotInput.s3.bucket", snapshotS3.Bucket)
+			required("terratest.snapshotInput.s3.endpoint", snapshotS3.Endpoint)
+		}
+	}
+
+	if rotation := terratestConfig.RotateCertificates; rotation != nil {
+		var services []string
+
+		switch module.Distro {
+		case set.RKE1:
+			services = certificates.RKE1Services()
+		case set.RKE2:
+			services = certificates.RKE2Services()
+		case set.K3S:
+			services = certificates.K3sServices()
+		}
+
+		if module.Mode != set.NodeDriver {
+			problems = append(problems, ConfigProblem{
+				Path:    "terratest.rotateCertificates",
+				Message: "only supported by RKE1/RKE2/K3s node driver modules, not " + module.Name,
+			})
+		}
+
+		for i, service := range rotation.Services {
+			if !slices.Contains(services, service) {
+				problems = append(problems, ConfigProblem{
+					Path:    fmt.Sprintf("terratest.rotateCertificates.services[%d]", i),
+					Message: fmt.Sprintf("unknown %s service %q, must be one of %s", module.Distro, service, strings.Join(services, ", ")),
+				})
+			}
+		}
+
+		if rotation.CACertificates && module.Distro != set.RKE1 {
+			problems = append(problems, ConfigProblem{
+				Path:    "terratest.rotateCertificates.caCertificates",
+				Message: "only supported by RKE1 node driver modules, not " + module.Name,
+			})
+		}
+
+		if rotation.Generation != 0 && module.Distro == set.RKE1 {
+			problems = append(problems, ConfigProblem{
+				Path:    "terratest.rotateCertificates.generation",
+				Message: "only supported by RKE2/K3s node driver modules, not " + module.Name,
+			})
+		}
+	}
+
+	if module.Mode == set.HostedMode && module.Provider == providers.Google {
+		google := terraformConfig.GoogleConfig
+
+		if google.EnablePrivateEndpoint || google.EnablePrivateNodes {
+			required("terraform.googleConfig.masterIpv4CidrBlock", google.MasterIPv4CIDRBlock)
+		}
+
+		if google.EnablePrivateEndpoint && !google.EnablePrivateNodes {
+			problems = append(problems, ConfigProblem{Path: "terraform.googleConfig.enablePrivateEndpoint", Message: "requires enablePrivateNodes"})
+		}
+	}
+
+	for i, pool := range terratestConfig.Nodepools {
+		path := func(field string) string {
+			return fmt.Sprintf("terratest.nodepools[%d].%s", i, field)
+		}
+
+		unsupported := func(field string, set bool, modules string) {
+			if set {
+				problems = append(problems, ConfigProblem{
+					Path:    path(field),
+					Message: "only supported by " + modules + " modules, not " + module.Name,
+				})
+			}
+		}
+
+		rke2K3s := module.Mode == set.NodeDriver && module.Distro != set.RKE1
+		hosted := module.Mode == set.HostedMode || module.Mode == set.HostedImport
+		eks := hosted && module.Provider == providers.AWS
+		aks := hosted && module.Provider == providers.Azure
+		gke := hosted && module.Provider == providers.Google
+
+		unsupported("machineConfig", pool.MachineConfig != nil && !rke2K3s, "RKE2/K3s node driver")
+		unsupported("nodeStartupTimeoutSeconds", pool.NodeStartupTimeoutSeconds != 0 && !rke2K3s, "RKE2/K3s node driver")
+		unsupported("unhealthyNodeTimeoutSeconds", pool.UnhealthyNodeTimeoutSeconds != 0 && !rke2K3s, "RKE2/K3s node driver")
+		unsupported("maxUnhealthy", pool.MaxUnhealthy != "" && !rke2K3s, "RKE2/K3s node driver")
+		unsupported("labels", len(pool.Labels) > 0 && module.Mode != set.NodeDriver && !hosted, "RKE1/RKE2/K3s node driver, AKS, EKS and GKE")
+		unsupported("taints", len(pool.Taints) > 0 && module.Mode != set.NodeDriver && !aks && !gke, "RKE1/RKE2/K3s node driver, AKS and GKE")
+		unsupported("drainBeforeDelete", pool.DrainBeforeDelete && module.Mode != set.NodeDriver, "RKE1/RKE2/K3s node driver")
+		unsupported("mode", pool.Mode != "" && !aks, "AKS")
+		unsupported("autoscaling", pool.Autoscaling && !aks && !gke, "AKS and GKE")
+		unsupported("spot", pool.Spot && !eks && !gke, "EKS and GKE")
+		unsupported("launchTemplate", pool.LaunchTemplate != nil && !eks, "EKS")
+		unsupported("management", pool.Management != nil && !gke, "GKE")
+
+		for j, taint := range pool.Taints {
+			if taint.Key == "" {
+				problems = append(problems, ConfigProblem{Path: fmt.Sprintf("terratest.nodepools[%d].taints[%d].key", i, j), Message: "required"})
+			}
+		}
+
+		if pool.Mode != "" && pool.Mode != "System" && pool.Mode != "User" {
+			problems = append(problems, ConfigProblem{Path: path("mode"), Message: "must be System or User"})
+		}
+
+		if pool.Autoscaling && (pool.MaxSize == 0 || pool.MinSize > pool.MaxSize) {
+			problems = append(problems, ConfigProblem{Path: path("maxSize"), Message: "must be set and at least minSize when autoscaling"})
+		}
+
+		if pool.Spot && eks {
+			required(path("instanceType"), pool.InstanceType)
+		}
+
+		if pool.LaunchTemplate != nil {
+			required(path("launchTemplate.id"), pool.LaunchTemplate.ID)
+
+			if pool.LaunchTemplate.Version == 0 {
+				problems = append(problems, ConfigProblem{Path: path("launchTemplate.version"), Message: "required by module " + module.Name})
+			}
+		}
+	}
+
+	if module.Mode == set.Airgap {
+		if terraformConfig.Standalone == nil {
+			problems = append(problems, ConfigProblem{Path: "terraform.standalone", Message: "required by module " + module.Name})
+		}
+
+		if terraformConfig.PrivateRegistries == nil {
+			problems = append(problems, ConfigProblem{Path: "terraform.privateRegistries", Message: "required by module " + module.Name})
+		}
+	}
+
+	return problems
+}
+
+// decodeSection is a helper function that will decode a section of the cattle config the same way as config.LoadTFPConfigs, returning
+// an error instead of panicking when a value has the wrong type.
+func decodeSection(cattleConfig map[string]any, key string, object any) error {
+	data, err := yaml.Marshal(cattleConfig[key])
+	if err != nil {
+		return err
+	}
+
+	return yaml.Unmarshal(data, object)
+}
+
+// normalize is a helper function that will round trip the value through JSON, so that values set from Go, such as []config.Nodepool,
+// are checked the same way as values read from the YAML file.
+func normalize(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var normalized any
+
+	err = json.Unmarshal(data, &normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	return normalized, nil
+}
+
+// decodeProblems is a helper function that will walk the value against the type it is decoded into and return every problem found.
+func decodeProblems(path string, value any, typ reflect.Type) []ConfigProblem {
+	if value == nil {
+		return nil
+	}
+
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	// Types with their own decoding, such as resource quantities and durations, are left to the decoder.
+	if reflect.PointerTo(typ).Implements(jsonUnmarshalerType) || reflect.PointerTo(typ).Implements(textUnmarshalerType) {
+		return nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return []ConfigProblem{typeProblem(path, "a mapping", value)}
+		}
+
+		fields := structFields(typ)
+
+		var problems []ConfigProblem
+		for _, key := range sortedKeys(object) {
+			field, ok := lookupField(fields, key)
+			if !ok {
+				problems = append(problems, unknownFieldProblem(path+"."+key, key, fields))
+				continue
+			}
+
+			problems = append(problems, decodeProblems(path+"."+key, object[key], field)...)
+		}
+
+		return problems
+	case reflect.Map:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return []ConfigProblem{typeProblem(path, "a mapping", value)}
+		}
+
+		var problems []ConfigProblem
+		for _, key := range sortedKeys(object) {
+			problems = append(problems, decodeProblems(path+"."+key, object[key], typ.Elem())...)
+		}
+
+		return problems
+	case reflect.Slice, reflect.Array:
+		list, ok := value.([]any)
+		if !ok {
+			return []ConfigProblem{typeProblem(path, "a list", value)}
+		}
+
+		var problems []ConfigProblem
+		for i, item := range list {
+			problems = append(problems, decodeProblems(fmt.Sprintf("%s[%d]", path, i), item, typ.Elem())...)
+		}
+
+		return problems
+	case reflect.String:
+		if _, ok := value.(string); !ok {
+			return []ConfigProblem{typeProblem(path, "a string", value)}
+		}
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			return []ConfigProblem{typeProblem(path, "a boolean", value)}
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number, ok := value.(float64)
+		if !ok || number != math.Trunc(number) {
+			return []ConfigProblem{typeProblem(path, "an integer", value)}
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := value.(float64); !ok {
+			return []ConfigProblem{typeProblem(path, "a number", value)}
+		}
+	}
+
+	return nil
+}
+
+// structFields is a helper function that will return the types of the fields of a struct keyed by their JSON name, including the
+// fields of embedded structs, which are decoded inline.
+func structFields(typ reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+
+			if embedded.Kind() == reflect.Struct {
+				for embeddedName, embeddedType := range structFields(embedded) {
+					if _, ok := fields[embeddedName]; !ok {
+						fields[embeddedName] = embeddedType
+					}
+				}
+
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		fields[name] = field.Type
+	}
+
+	return fields
+}
+
+// lookupField is a helper function that will find the field for a key. Like the decoder, an exact match is preferred over a case
+// insensitive one.
+func lookupField(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if field, ok := fields[key]; ok {
+		return field, true
+	}
+
+	for name, field := range fields {
+		if strings.EqualFold(name, key) {
+			return field, true
+		}
+	}
+
+	return nil, false
+}
+
+// unknownFieldProblem is a helper function that will report an unknown key, suggesting the closest known field when there is one.
+func unknownFieldProblem(path, key string, fields map[string]reflect.Type) ConfigProblem {
+	suggestion := ""
+	distance := min(maxSuggestionDistance, len(key)/2) + 1
+
+	for _, name := range sortedKeys(fields) {
+		d := editDistance(strings.ToLower(key), strings.ToLower(name))
+		if d < distance {
+			suggestion, distance = name, d
+		}
+	}
+
+	if suggestion != "" {
+		return ConfigProblem{Path: path, Message: fmt.Sprintf("unknown field, did you mean %q?", suggestion)}
+	}
+
+	return ConfigProblem{Path: path, Message: "unknown field"}
+}
+
+// typeProblem is a helper function that will report a value of the wrong type.
+func typeProblem(path, expected string, value any) ConfigProblem {
+	return ConfigProblem{Path: path, Message: fmt.Sprintf("expected %s, got %s", expected, describe(value))}
+}
+
+// describe is a helper function that will describe a decoded YAML value for a problem message.
+func describe(value any) string {
+	switch v := value.(type) {
+	case string:
+		return fmt.Sprintf("string %q", v)
+	case bool:
+		return fmt.Sprintf("boolean %t", v)
+	case float64:
+		return fmt.Sprintf("number %v", v)
+	case []any:
+		return "a list"
+	case map[string]any:
+		return "a mapping"
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// editDistance is a helper function that will return the Levenshtein distance between two strings.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous = current
+	}
+
+	return previous[len(b)]
+}
+
+// sortedKeys is a helper function that will return the keys of a map in order, so that problems are reported deterministically.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,258 @@
+package validate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rancher/tfp-automation/config"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+const validConfig = `
+rancher:
+  host: rancher.example.com
+  adminToken: token
+  insecure: true
+terraform:
+  module: ec2_rke2_custom
+  privateKeyPath: /path/to/key.pem
+  awsCredentials:
+    awsAccessKey: access
+    awsSecretKey: secret
+  awsConfig:
+    ami: ami-123
+    region: us-east-2
+    awsSubnetID: subnet-123
+    awsSecurityGroups: [sg-123]
+    awsRootSize: 100
+  etcd:
+    snapshotRetention: 5
+terratest:
+  nodepools:
+    - quantity: 1
+      etcd: true
+shepherdOnly:
+  anything: goes
+`
+
+func loadConfig(t *testing.T, data string) map[string]any {
+	var cattleConfig map[string]any
+	require.NoError(t, yaml.Unmarshal([]byte(data), &cattleConfig))
+
+	return cattleConfig
+}
+
+func TestValidateConfig(t *testing.T) {
+	cattleConfig := loadConfig(t, validConfig)
+	require.NoError(t, ValidateConfig(cattleConfig))
+
+	cattleConfig[config.TerratestConfigurationFileKey] = map[string]any{
+		"nodepools": []config.Nodepool{config.EtcdNodePool},
+	}
+	require.NoError(t, ValidateConfig(cattleConfig))
+
+	path := filepath.Join(t.TempDir(), "cattle-config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(validConfig), 0600))
+	require.NoError(t, ValidateConfigFile(path))
+}
+
+func TestDecodeConfigProblems(t *testing.T) {
+	cattleConfig := loadConfig(t, `
+terraform:
+  module: ec2_rke2
+  awsConfig:
+    awsSecurityGroup: [sg-123]
+    awsRootSize: large
+  etcd:
+    snapshotRetention: "5"
+terratest:
+  nodepools:
+    - quantity: 1.5
+      controlplane: "yes"
+  zzz: true
+`)
+
+	require.Equal(t, []ConfigProblem{
+		{Path: "terraform.awsConfig.awsRootSize", Message: `expected an integer, got string "large"`},
+		{Path: "terraform.awsConfig.awsSecurityGroup", Message: `unknown field, did you mean "awsSecurityGroups"?`},
+		{Path: "terraform.etcd.snapshotRetention", Message: `expected an integer, got string "5"`},
+		{Path: "terratest.nodepools[0].controlplane", Message: `expected a boolean, got string "yes"`},
+		{Path: "terratest.nodepools[0].quantity", Message: "expected an integer, got number 1.5"},
+		{Path: "terratest.zzz", Message: "unknown field"},
+	}, DecodeConfigProblems(cattleConfig))
+}
+
+func TestModuleConfigProblems(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		expected []string
+	}{
+		{
+			name:     "no module",
+			config:   "terraform: {}",
+			expected: nil,
+		},
+		{
+			name:     "unknown module",
+			config:   "terraform: {module: ec2_rke3}",
+			expected: []string{"terraform.module"},
+		},
+		{
+			name:   "ec2 node driver",
+			config: "terraform: {module: ec2_rke2, awsConfig: {region: us-east-2}}",
+			expected: []string{
+				"terraform.awsCredentials.awsAccessKey",
+				"terraform.awsCredentials.awsSecretKey",
+				"terraform.awsConfig.ami",
+				"terraform.awsConfig.awsSubnetID",
+			},
+		},
+		{
+			name:   "airgap",
+			config: "terraform: {module: airgap_k3s, awsCredentials: {awsAccessKey: a, awsSecretKey: s}, awsConfig: {ami: a, region: r, awsSubnetID: s}}",
+			expected: []string{
+				"terraform.privateKeyPath",
+				"terraform.standalone",
+				"terraform.privateRegistries",
+			},
+		},
+		{
+			name: "azure custom",
+			config: "terraform: {module: azure_rke2_custom, privateKeyPath: k, azureCredentials: {clientId: c, clientSecret: s, subscriptionId: s}, " +
+				"azureConfig: {resourceGroup: rg, vnet: v, subnet: s, nsg: n, size: s, sshUser: u, storageType: t, diskSize: large, image: ubuntu}}",
+			expected: []string{
+				"terraform.azureCredentials.tenantId",
+				"terraform.azureConfig.diskSize",
+				"terraform.azureConfig.image",
+			},
+		},
+		{
+			name:   "gce node driver",
+			config: "terraform: {module: gce_rke2, googleCredentials: {authEncodedJson: j}, googleConfig: {projectID: p, zone: us-central1-c, network: default}}",
+			expected: []string{
+				"terraform.googleConfig.machineType",
+				"terraform.googleConfig.machineImage",
+			},
+		},
+		{
+			name: "gce import",
+			config: "terraform: {module: gce_k3s_import, privateKeyPath: k, googleCredentials: {authEncodedJson: j}, " +
+				"googleConfig: {projectID: p, zone: z, machineType: t, machineImage: i, network: n, diskSize: large}}",
+			expected: []string{
+				"terraform.googleConfig.username",
+				"terraform.googleConfig.diskSize",
+			},
+		},
+		{
+			name:   "hosted",
+			config: "terraform: {module: gke, googleConfig: {projectID: project}}",
+			expected: []string{
+				"terraform.googleCredentials.authEncodedJson",
+			},
+		},
+		{
+			name:   "hosted import",
+			config: "{terraform: {module: eks_import, awsCredentials: {awsAccessKey: a, awsSecretKey: s}}, terratest: {nodepools: [{desiredSize: 3}]}}",
+			expected: []string{
+				"terraform.hostedClusterName",
+				"terraform.awsConfig.region",
+				"terratest.nodepools[0].name",
+			},
+		},
+		{
+			name: "hosted nodepool settings",
+			config: "{terraform: {module: eks, awsCredentials: {awsAccessKey: a, awsSecretKey: s}}, terratest: {nodepools: [{desiredSize: 1, spot: true, " +
+				"autoscaling: true, taints: [{key: k}], launchTemplate: {name: lt}}]}}",
+			expected: []string{
+				"terratest.nodepools[0].taints",
+				"terratest.nodepools[0].autoscaling",
+				"terratest.nodepools[0].maxSize",
+				"terratest.nodepools[0].instanceType",
+				"terratest.nodepools[0].launchTemplate.id",
+				"terratest.nodepools[0].launchTemplate.version",
+			},
+		},
+		{
+			name:   "gke private cluster",
+			config: "{terraform: {module: gke, googleCredentials: {authEncodedJson: j}, googleConfig: {projectID: p, enablePrivateEndpoint: true}}, terratest: {nodepools: [{quantity: 1, mode: User, management: {autoRepair: true}}]}}",
+			expected: []string{
+				"terraform.googleConfig.masterIpv4CidrBlock",
+				"terraform.googleConfig.enablePrivateEndpoint",
+				"terratest.nodepools[0].mode",
+			},
+		},
+		{
+			name:   "machine config on rke1",
+			config: "{terraform: {module: linode_rke1, linodeCredentials: {linodeToken: t}}, terratest: {nodepools: [{quantity: 1, machineConfig: {instanceType: g6-standard-8}}]}}",
+			expected: []string{
+				"terratest.nodepools[0].machineConfig",
+			},
+		},
+		{
+			name:   "snapshot s3",
+			config: "{terraform: {module: linode_rke2, linodeCredentials: {linodeToken: t}}, terratest: {snapshotInput: {s3: {bucket: b, endpoint: e}}}}",
+			expected: []string{
+				"terratest.snapshotInput.s3.accessKey",
+				"terratest.snapshotInput.s3.secretKey",
+			},
+		},
+		{
+			name:     "snapshot minio",
+			config:   "{terraform: {module: linode_rke2, linodeCredentials: {linodeToken: t}}, terratest: {snapshotInput: {s3: {minio: true}}}}",
+			expected: nil,
+		},
+		{
+			name:   "upgrade strategy",
+			config: "{terraform: {module: linode_rke1, linodeCredentials: {linodeToken: t}, upgradeStrategy: {controlPlaneConcurrency: '10%', workerConcurrency: lots}}}",
+			expected: []string{
+				"terraform.upgradeStrategy",
+				"terraform.upgradeStrategy.workerConcurrency",
+			},
+		},
+		{
+			name:   "nodepool settings",
+			config: "{terraform: {module: linode_rke1, linodeCredentials: {linodeToken: t}}, terratest: {nodepools: [{quantity: 1, drainBeforeDelete: true, maxUnhealthy: '50%', taints: [{value: v}]}]}}",
+			expected: []string{
+				"terratest.nodepools[0].maxUnhealthy",
+				"terratest.nodepools[0].taints[0].key",
+			},
+		},
+		{
+			name:   "rotate certificates",
+			config: "{terraform: {module: linode_rke2, linodeCredentials: {linodeToken: t}}, terratest: {rotateCertificates: {generation: 2, caCertificates: true, services: [api-server, kube-apiserver]}}}",
+			expected: []string{
+				"terratest.rotateCertificates.services[1]",
+				"terratest.rotateCertificates.caCertificates",
+			},
+		},
+		{
+			name:   "rotate certificates on rke1",
+			config: "{terraform: {module: linode_rke1, linodeCredentials: {linodeToken: t}}, terratest: {rotateCertificates: {generation: 2, services: [kube-apiserver]}}}",
+			expected: []string{
+				"terratest.rotateCertificates.generation",
+			},
+		},
+		{
+			name:   "engine and backend",
+			config: "terratest: {engine: pulumi, backend: {type: s3}}",
+			expected: []string{
+				"terratest.engine",
+				"terratest.backend",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			for _, problem := range ModuleConfigProblems(loadConfig(t, tt.config)) {
+				paths = append(paths, problem.Path)
+			}
+
+			require.Equal(t, tt.expected, paths)
+		})
+	}
+}
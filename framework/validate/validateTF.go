@@ -0,0 +1,282 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+const (
+	resource = "resource"
+	data     = "data"
+	provider = "provider"
+	dynamic  = "dynamic"
+	content  = "content"
+)
+
+var (
+	resourceMetaArguments = []string{"count", "for_each", "depends_on", "provider"}
+	resourceMetaBlocks    = []string{"lifecycle", "provisioner", "connection"}
+	providerMetaArguments = []string{"alias", "version"}
+)
+
+// ValidateTFFile is a function that will validate the Terraform file at path against the provider schemas.
+func ValidateTFFile(path string, schemas *ProviderSchemas) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return ValidateTF(src, path, schemas)
+}
+
+// ValidateTF is a function that will parse the Terraform configuration and validate every provider, resource and data source
+// against the provider schemas. Resources of providers that are not part of the schemas are skipped. The returned error lists
+// every problem found along with its position in the file.
+func ValidateTF(src []byte, filename string, schemas *ProviderSchemas) error {
+	parser := hclparse.NewParser()
+
+	file, diags := parser.ParseHCL(src, filename)
+	if diags.HasErrors() {
+		return joinDiagnostics(diags)
+	}
+
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return fmt.Errorf("%s is not a native HCL file", filename)
+	}
+
+	for _, block := range body.Blocks {
+		switch block.Type {
+		case resource, data:
+			diags = append(diags, validateResource(block, schemas)...)
+		case provider:
+			diags = append(diags, validateProvider(block, schemas)...)
+		}
+	}
+
+	if diags.HasErrors() {
+		return joinDiagnostics(diags)
+	}
+
+	return nil
+}
+
+// joinDiagnostics is a helper function that will join every error diagnostic into a single error, one per line.
+func joinDiagnostics(diags hcl.Diagnostics) error {
+	var errs []error
+	for _, diag := range diags {
+		if diag.Severity == hcl.DiagError {
+			errs = append(errs, diag)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateResource is a helper function that will validate a resource or data source block against its schema.
+func validateResource(block *hclsyntax.Block, schemas *ProviderSchemas) hcl.Diagnostics {
+	if len(block.Labels) != 2 {
+		return nil
+	}
+
+	resourceType := block.Labels[0]
+	providerName, _, _ := strings.Cut(resourceType, "_")
+
+	providerSchema, ok := schemas.provider(providerName)
+	if !ok {
+		return nil
+	}
+
+	resourceSchemas := providerSchema.ResourceSchemas
+	kind := "resource type"
+	if block.Type == data {
+		resourceSchemas = providerSchema.DataSourceSchemas
+		kind = "data source"
+	}
+
+	schema, ok := resourceSchemas[resourceType]
+	if !ok {
+		return hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid " + kind,
+			Detail:   fmt.Sprintf("The provider %s does not support %s %q.", providerName, kind, resourceType),
+			Subject:  block.LabelRanges[0].Ptr(),
+		}}
+	}
+
+	return validateBody(block.Body, block.DefRange(), schema.Block, resourceMetaArguments, resourceMetaBlocks)
+}
+
+// validateProvider is a helper function that will validate a provider block against its schema.
+func validateProvider(block *hclsyntax.Block, schemas *ProviderSchemas) hcl.Diagnostics {
+	if len(block.Labels) != 1 {
+		return nil
+	}
+
+	providerSchema, ok := schemas.provider(block.Labels[0])
+	if !ok {
+		return nil
+	}
+
+	return validateBody(block.Body, block.DefRange(), providerSchema.Provider.Block, providerMetaArguments, nil)
+}
+
+// validateBody is a helper function that will validate the attributes and nested blocks of a body against a block schema.
+func validateBody(body *hclsyntax.Body, defRange hcl.Range, schema Block, metaArguments, metaBlocks []string) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	for _, name := range slices.Sorted(maps.Keys(body.Attributes)) {
+		attribute := body.Attributes[name]
+		if slices.Contains(metaArguments, name) {
+			continue
+		}
+
+		attributeSchema, ok := schema.Attributes[name]
+		if !ok {
+			detail := fmt.Sprintf("An argument named %q is not expected here.", name)
+			if _, isBlock := schema.BlockTypes[name]; isBlock {
+				detail = fmt.Sprintf("An argument named %q is not expected here. Did you mean to define a block of type %q?", name, name)
+			}
+
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Unsupported argument",
+				Detail:   detail,
+				Subject:  attribute.NameRange.Ptr(),
+			})
+
+			continue
+		}
+
+		if attributeSchema.Computed && !attributeSchema.Optional && !attributeSchema.Required {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid argument",
+				Detail:   fmt.Sprintf("The argument %q is read-only and cannot be set.", name),
+				Subject:  attribute.NameRange.Ptr(),
+			})
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(schema.Attributes)) {
+		attributeSchema := schema.Attributes[name]
+		if _, ok := body.Attributes[name]; !ok && attributeSchema.Required {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Missing required argument",
+				Detail:   fmt.Sprintf("The argument %q is required, but no definition was found.", name),
+				Subject:  defRange.Ptr(),
+			})
+		}
+	}
+
+	blockCounts := map[string]int{}
+
+	for _, block := range body.Blocks {
+		if slices.Contains(metaBlocks, block.Type) {
+			continue
+		}
+
+		blockType := block.Type
+		blockBody := block.Body
+
+		if blockType == dynamic && len(block.Labels) == 1 {
+			blockType = block.Labels[0]
+			blockBody = dynamicContent(block)
+
+			if blockBody == nil {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Missing content block",
+					Detail:   fmt.Sprintf("The dynamic block %q requires a content block.", blockType),
+					Subject:  block.DefRange().Ptr(),
+				})
+
+				continue
+			}
+		} else if len(block.Labels) != 0 {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Extraneous label",
+				Detail:   fmt.Sprintf("No labels are expected for %q blocks.", blockType),
+				Subject:  block.LabelRanges[0].Ptr(),
+			})
+		}
+
+		nestedSchema, ok := schema.BlockTypes[blockType]
+		if !ok {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Unsupported block type",
+				Detail:   fmt.Sprintf("Blocks of type %q are not expected here.", blockType),
+				Subject:  block.TypeRange.Ptr(),
+			})
+
+			continue
+		}
+
+		if block.Type != dynamic {
+			blockCounts[blockType]++
+		}
+
+		maxItems := nestedSchema.MaxItems
+		if nestedSchema.NestingMode == "single" || nestedSchema.NestingMode == "group" {
+			maxItems = 1
+		}
+
+		if maxItems > 0 && blockCounts[blockType] > maxItems {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Too many " + blockType + " blocks",
+				Detail:   fmt.Sprintf("No more than %d %q blocks are allowed.", maxItems, blockType),
+				Subject:  block.TypeRange.Ptr(),
+			})
+		}
+
+		diags = append(diags, validateBody(blockBody, block.DefRange(), nestedSchema.Block, nil, nil)...)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(schema.BlockTypes)) {
+		nestedSchema := schema.BlockTypes[name]
+		if nestedSchema.MinItems > 0 && blockCounts[name] < nestedSchema.MinItems && !hasDynamicBlock(body, name) {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Insufficient " + name + " blocks",
+				Detail:   fmt.Sprintf("At least %d %q blocks are required.", nestedSchema.MinItems, name),
+				Subject:  defRange.Ptr(),
+			})
+		}
+	}
+
+	return diags
+}
+
+// dynamicContent is a helper function that will return the content body of a dynamic block.
+func dynamicContent(block *hclsyntax.Block) *hclsyntax.Body {
+	for _, nested := range block.Body.Blocks {
+		if nested.Type == content {
+			return nested.Body
+		}
+	}
+
+	return nil
+}
+
+// hasDynamicBlock is a helper function that will check if the body has a dynamic block of the given type.
+func hasDynamicBlock(body *hclsyntax.Body, blockType string) bool {
+	for _, block := range body.Blocks {
+		if block.Type == dynamic && len(block.Labels) == 1 && block.Labels[0] == blockType {
+			return true
+		}
+	}
+
+	return false
+}
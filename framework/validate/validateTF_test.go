@@ -0,0 +1,177 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const validTF = `provider "rancher2" {
+  alias     = "admin"
+  api_url   = "https://rancher.example.com"
+  token_key = "token"
+}
+
+resource "aws_instance" "node" {
+  not_in_schema = true
+}
+
+data "rancher2_cluster_v2" "existing" {
+  name = "existing"
+}
+
+resource "rancher2_cluster_v2" "cluster" {
+  provider           = rancher2.admin
+  name               = "cluster"
+  kubernetes_version = "v1.32.4+rke2r1"
+
+  rke_config {
+    machine_pools {
+      name     = "pool1"
+      quantity = 1
+
+      machine_config {
+        kind = "Amazonec2Config"
+        name = "config"
+      }
+    }
+
+    dynamic "machine_pools" {
+      for_each = ["pool2"]
+      content {
+        name = machine_pools.value
+
+        machine_config {
+          kind = "Amazonec2Config"
+          name = "config"
+        }
+      }
+    }
+  }
+
+  lifecycle {
+    ignore_changes = [name]
+  }
+}
+`
+
+func TestValidateTF(t *testing.T) {
+	schemas, err := LoadProviderSchemas("testdata/schema.json")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		src    string
+		errors []string
+	}{
+		{
+			name: "Valid",
+			src:  validTF,
+		},
+		{
+			name: "Unsupported argument",
+			src: `resource "rancher2_cluster_v2" "cluster" {
+  name               = "cluster"
+  kubernetes_version = "v1.32.4+rke2r1"
+  kubernetes_versoin = "v1.32.4+rke2r1"
+}
+`,
+			errors: []string{`main.tf:4,3-21: Unsupported argument; An argument named "kubernetes_versoin" is not expected here.`},
+		},
+		{
+			name: "Read-only argument",
+			src: `resource "rancher2_cluster_v2" "cluster" {
+  name               = "cluster"
+  kubernetes_version = "v1.32.4+rke2r1"
+  cluster_v1_id      = "c-12345"
+}
+`,
+			errors: []string{`main.tf:4,3-16: Invalid argument; The argument "cluster_v1_id" is read-only and cannot be set.`},
+		},
+		{
+			name: "Missing required argument",
+			src: `resource "rancher2_cluster_v2" "cluster" {
+  name = "cluster"
+}
+`,
+			errors: []string{`main.tf:1,1-41: Missing required argument; The argument "kubernetes_version" is required, but no definition was found.`},
+		},
+		{
+			name: "Block used as argument",
+			src: `resource "rancher2_cluster_v2" "cluster" {
+  name               = "cluster"
+  kubernetes_version = "v1.32.4+rke2r1"
+  rke_config         = {}
+}
+`,
+			errors: []string{`main.tf:4,3-13: Unsupported argument; An argument named "rke_config" is not expected here. Did you mean to define a block of type "rke_config"?`},
+		},
+		{
+			name: "Wrong block nesting",
+			src: `resource "rancher2_cluster_v2" "cluster" {
+  name               = "cluster"
+  kubernetes_version = "v1.32.4+rke2r1"
+
+  machine_pools {
+    name = "pool1"
+  }
+}
+`,
+			errors: []string{`main.tf:5,3-16: Unsupported block type; Blocks of type "machine_pools" are not expected here.`},
+		},
+		{
+			name: "Too many and too few blocks",
+			src: `resource "rancher2_cluster_v2" "cluster" {
+  name               = "cluster"
+  kubernetes_version = "v1.32.4+rke2r1"
+
+  rke_config {
+    machine_pools {
+      name = "pool1"
+    }
+  }
+
+  rke_config {}
+}
+`,
+			errors: []string{
+				`main.tf:6,5-18: Insufficient machine_config blocks; At least 1 "machine_config" blocks are required.`,
+				`main.tf:11,3-13: Too many rke_config blocks; No more than 1 "rke_config" blocks are allowed.`,
+			},
+		},
+		{
+			name: "Unknown resource type",
+			src: `resource "rancher2_cluster_v3" "cluster" {
+  name = "cluster"
+}
+`,
+			errors: []string{`main.tf:1,10-31: Invalid resource type; The provider rancher2 does not support resource type "rancher2_cluster_v3".`},
+		},
+		{
+			name: "Provider block",
+			src: `provider "rancher2" {
+  url = "https://rancher.example.com"
+}
+`,
+			errors: []string{
+				`main.tf:2,3-6: Unsupported argument; An argument named "url" is not expected here.`,
+				`main.tf:1,1-20: Missing required argument; The argument "api_url" is required, but no definition was found.`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTF([]byte(tt.src), "main.tf", schemas)
+			if len(tt.errors) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			for _, expected := range tt.errors {
+				require.Contains(t, err.Error(), expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package framework
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	workspaceDir   = "tfp-automation"
+	pluginCacheDir = "plugin-cache"
+
+	tfPluginCacheDir              = "TF_PLUGIN_CACHE_DIR"
+	tfPluginCacheMayBreakLockFile = "TF_PLUGIN_CACHE_MAY_BREAK_DEPENDENCY_LOCK_FILE"
+)
+
+var invalidWorkspaceChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// SetupWorkspace is a function that will create an isolated Terraform working directory for the current test case and return the
+// Terraform options and path of the workspace. Every workspace shares the same provider plugin cache, so providers are only downloaded once.
+func SetupWorkspace(t *testing.T, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig) (*terraform.Options, string) {
+	err := os.MkdirAll(WorkspaceRoot(), 0755)
+	if err != nil {
+		t.Fatalf("Failed to create workspace root %s. Error: %v", WorkspaceRoot(), err)
+	}
+
+	name := invalidWorkspaceChars.ReplaceAllString(t.Name(), "_")
+	keyPath, err := os.MkdirTemp(WorkspaceRoot(), name+"-")
+	if err != nil {
+		t.Fatalf("Failed to create workspace for %s. Error: %v", t.Name(), err)
+	}
+
+	cacheDir := terratestConfig.PluginCacheDir
+	if cacheDir == "" {
+		cacheDir = filepath.Join(WorkspaceRoot(), pluginCacheDir)
+	}
+
+	err = os.MkdirAll(cacheDir, 0755)
+	if err != nil {
+		t.Fatalf("Failed to create plugin cache %s. Error: %v", cacheDir, err)
+	}
+
+	err = ValidateEngine(Engine(terratestConfig))
+	if err != nil {
+		t.Fatalf("Invalid terratest configuration. Error: %v", err)
+	}
+
+	logrus.Infof("Using Terraform workspace %s", keyPath)
+
+	terratestLogger := getLogger(terratestConfig.TFLogging)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir:    keyPath,
+		TerraformBinary: Engine(terratestConfig),
+		NoColor:         true,
+		Logger:          &terratestLogger,
+		EnvVars: map[string]string{
+			tfPluginCacheDir:              cacheDir,
+			tfPluginCacheMayBreakLockFile: "true",
+		},
+	})
+
+	return terraformOptions, keyPath
+}
+
+// WorkspaceRoot is a function that will return the directory that holds every Terraform workspace.
+func WorkspaceRoot() string {
+	return filepath.Join(os.TempDir(), workspaceDir)
+}
+
+// IsWorkspace is a function that will check if the keyPath is a Terraform workspace created by SetupWorkspace.
+func IsWorkspace(keyPath string) bool {
+	rel, err := filepath.Rel(WorkspaceRoot(), keyPath)
+	if err != nil {
+		return false
+	}
+
+	return rel != "." && rel != pluginCacheDir && !strings.HasPrefix(rel, "..") && !strings.Contains(rel, string(filepath.Separator))
+}
+
+// KeepWorkspace is a function that will check if the Terraform workspace of the test is kept for debugging, which is the case
+// when the test failed and keepOnFailure is set.
+func KeepWorkspace(t *testing.T, keepOnFailure bool) bool {
+	return keepOnFailure && t.Failed()
+}
+
+// RemoveWorkspace is a function that will remove the Terraform workspace. If the test failed and keepOnFailure is set, the
+// workspace is kept for debugging.
+func RemoveWorkspace(t *testing.T, keyPath string, keepOnFailure bool) error {
+	if KeepWorkspace(t, keepOnFailure) {
+		logrus.Infof("Test failed, keeping Terraform workspace %s. The generated configuration is in %s", keyPath, keyPath+configs.MainTF)
+		return nil
+	}
+
+	return os.RemoveAll(keyPath)
+}
@@ -0,0 +1,48 @@
+package framework
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rancher/tfp-automation/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetupWorkspace(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+
+	cacheDir := filepath.Join(t.TempDir(), "cache")
+	terratestConfig := &config.TerratestConfig{PluginCacheDir: cacheDir}
+
+	first, firstKeyPath := SetupWorkspace(t, &config.TerraformConfig{}, terratestConfig)
+	second, secondKeyPath := SetupWorkspace(t, &config.TerraformConfig{}, terratestConfig)
+
+	require.NotEqual(t, firstKeyPath, secondKeyPath)
+	require.Equal(t, firstKeyPath, first.TerraformDir)
+	require.Equal(t, secondKeyPath, second.TerraformDir)
+	require.Equal(t, cacheDir, first.EnvVars[tfPluginCacheDir])
+	require.Equal(t, first.EnvVars, second.EnvVars)
+	require.DirExists(t, cacheDir)
+
+	require.True(t, IsWorkspace(firstKeyPath))
+	require.False(t, IsWorkspace(WorkspaceRoot()))
+	require.False(t, IsWorkspace(filepath.Join(WorkspaceRoot(), pluginCacheDir)))
+	require.False(t, IsWorkspace(filepath.Join(firstKeyPath, "nested")))
+	require.False(t, IsWorkspace(t.TempDir()))
+
+	require.NoError(t, os.WriteFile(filepath.Join(firstKeyPath, "main.tf"), []byte{}, 0644))
+	require.NoError(t, RemoveWorkspace(t, firstKeyPath, true))
+	require.NoDirExists(t, firstKeyPath)
+	require.DirExists(t, secondKeyPath)
+}
+
+func TestKeepWorkspace(t *testing.T) {
+	require.False(t, KeepWorkspace(t, true))
+
+	failed := &testing.T{}
+	failed.Fail()
+
+	require.True(t, KeepWorkspace(failed, true))
+	require.False(t, KeepWorkspace(failed, false))
+}
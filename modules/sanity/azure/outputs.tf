@@ -0,0 +1,15 @@
+output "server1_public_ip" {
+  value = azurerm_linux_virtual_machine.server1.public_ip_address
+}
+
+output "server1_private_ip" {
+  value = azurerm_linux_virtual_machine.server1.private_ip_address
+}
+
+output "server2_public_ip" {
+  value = azurerm_linux_virtual_machine.server2.public_ip_address
+}
+
+output "server3_public_ip" {
+  value = azurerm_linux_virtual_machine.server3.public_ip_address
+}
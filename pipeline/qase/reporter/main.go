@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"slices"
 	"strconv"
@@ -15,6 +16,7 @@ import (
 	"github.com/rancher/tests/actions/qase"
 	qaseactions "github.com/rancher/tests/actions/qase"
 	"github.com/rancher/tests/actions/qase/testresult"
+	"github.com/rancher/tfp-automation/framework/plan"
 	"github.com/sirupsen/logrus"
 	upstream "go.qase.io/qase-api-client"
 	"gopkg.in/yaml.v2"
@@ -27,6 +29,7 @@ var (
 	_, callerFilePath, _, _ = runtime.Caller(0)
 	basepath                = filepath.Join(filepath.Dir(callerFilePath), "..", "..", "..")
 	validStatus             = []string{"passed", "failed", "skipped"}
+	goSourceLine            = regexp.MustCompile(`^\s+\S+\.go:\d+:`)
 )
 
 const (
@@ -257,12 +260,14 @@ func updateTestInRun(client *upstream.APIClient, testResult testresult.GoTestRes
 		status = "failed"
 	}
 
+	comment := getComment(testResult.StackTrace)
+
 	resultBody := upstream.ResultCreate{
 		CaseId:  qaseTestCase.Id,
 		Status:  status,
 		Time:    *upstream.NewNullableInt64(&elapsedTime),
 		Param:   resultParams,
-		Comment: *upstream.NewNullableString(&testResult.StackTrace),
+		Comment: *upstream.NewNullableString(&comment),
 	}
 
 	resultRequest := client.ResultsAPI.CreateResult(context.TODO(), projectIDEnvVar, testRunID)
@@ -276,6 +281,29 @@ func updateTestInRun(client *upstream.APIClient, testResult testresult.GoTestRes
 	return nil
 }
 
+// getComment builds the result comment from the test output. When the idempotency check found a non-empty plan, the offending
+// resources and attribute paths are moved to the top of the comment.
+func getComment(stackTrace string) string {
+	start := strings.Index(stackTrace, plan.NonEmptyPlan)
+	if start == -1 {
+		return stackTrace
+	}
+
+	lines := strings.Split(stackTrace[start:], "\n")
+	report := []string{lines[0]}
+
+	for _, line := range lines[1:] {
+		// The report ends where the next log entry or test status line starts.
+		if !strings.HasPrefix(line, " ") || goSourceLine.MatchString(line) {
+			break
+		}
+
+		report = append(report, strings.TrimSpace(line))
+	}
+
+	return strings.Join(report, "\n") + "\n\n" + stackTrace
+}
+
 // getAutomationTestName gets the custom test name field
 func getAutomationTestName(customFields []upstream.CustomFieldValue) string {
 	for _, field := range customFields {
@@ -6,6 +6,7 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/clustertypes"
 	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework"
 	upstream "go.qase.io/qase-api-client"
 )
 
@@ -21,6 +22,7 @@ func GetProvisioningSchemaParams(configMap map[string]any) []upstream.TestCasePa
 		getAMIParam(terraform),
 		getWindowsAMIParam(terraform),
 		getK8sParam(terratest),
+		getEngineParam(terratest),
 	)
 
 	return params
@@ -125,3 +127,15 @@ func getWindowsAMIParam(terraform *config.TerraformConfig) upstream.TestCasePara
 func getK8sParam(terratest *config.TerratestConfig) upstream.TestCaseParameterCreate {
 	return upstream.TestCaseParameterCreate{ParameterSingle: &upstream.ParameterSingle{Title: "K8sVersion", Values: []string{terratest.KubernetesVersion}}}
 }
+
+func getEngineParam(terratest *config.TerratestConfig) upstream.TestCaseParameterCreate {
+	engine := framework.Engine(terratest)
+
+	value := engine
+	version, err := framework.EngineVersion(engine)
+	if err == nil && version != "" {
+		value = engine + " " + version
+	}
+
+	return upstream.TestCaseParameterCreate{ParameterSingle: &upstream.ParameterSingle{Title: "Engine", Values: []string{value}}}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/rancher/tfp-automation/framework/validate"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	_, callerFilePath, _, _ = runtime.Caller(0)
+	basepath                = filepath.Join(filepath.Dir(callerFilePath), "..", "..")
+)
+
+// The schema generator writes the JSON Schema of cattle-config.yaml to config/cattle-config.schema.json. With -markdown, the
+// configuration reference tables are printed instead, e.g. `go run ./pipeline/schema -markdown`.
+func main() {
+	markdown := flag.Bool("markdown", false, "print the configuration reference as Markdown tables")
+	flag.Parse()
+
+	if *markdown {
+		schema, err := validate.ConfigJSONSchema()
+		if err != nil {
+			logrus.Fatalf("Failed to generate the config schema: %v", err)
+		}
+
+		fmt.Print(validate.ConfigMarkdown(schema))
+
+		return
+	}
+
+	data, err := validate.MarshalConfigJSONSchema()
+	if err != nil {
+		logrus.Fatalf("Failed to generate the config schema: %v", err)
+	}
+
+	schemaFile := filepath.Join(basepath, validate.ConfigSchemaFile)
+
+	err = os.WriteFile(schemaFile, data, 0644)
+	if err != nil {
+		logrus.Fatalf("Failed to write %s: %v", schemaFile, err)
+	}
+
+	logrus.Infof("Wrote %s", schemaFile)
+}
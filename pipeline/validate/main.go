@@ -0,0 +1,55 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	shepherdConfig "github.com/rancher/shepherd/pkg/config"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/validate"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+)
+
+// The config validator checks a cattle config before any test is run. The path of the config is read from the first argument,
+// defaulting to CATTLE_TEST_CONFIG, e.g. `go run ./pipeline/validate cattle-config.yaml`. The config is composed with its bases
+// and the overlays set in CATTLE_TEST_CONFIG_OVERLAYS first. With --print-effective-config, the composed config is printed with
+// its secrets redacted.
+func main() {
+	printEffectiveConfig := flag.Bool("print-effective-config", false, "print the composed config with its secrets redacted")
+	flag.Parse()
+
+	configPath := os.Getenv(shepherdConfig.ConfigEnvironmentKey)
+	if flag.NArg() > 0 {
+		configPath = flag.Arg(0)
+	}
+
+	if configPath == "" {
+		logrus.Fatalf("No config provided, pass its path or set %s", shepherdConfig.ConfigEnvironmentKey)
+	}
+
+	if *printEffectiveConfig {
+		cattleConfig, err := config.LoadEffectiveConfig(configPath, config.OverlaysFromEnvironment())
+		if err != nil {
+			logrus.Fatalf("Failed to compose %s: %v", configPath, err)
+		}
+
+		data, err := yaml.Marshal(config.RedactConfig(cattleConfig))
+		if err != nil {
+			logrus.Fatalf("Failed to print %s: %v", configPath, err)
+		}
+
+		fmt.Print(string(data))
+	}
+
+	logrus.Infof("Validating %s", configPath)
+
+	err := validate.ValidateConfigFile(configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	logrus.Infof("%s is valid", configPath)
+}
@@ -0,0 +1,144 @@
+package lifecycle
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/shepherd/clients/rancher"
+	timeouts "github.com/rancher/shepherd/extensions/defaults"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/framework"
+	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/plan"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	output     = "output"
+	value      = "value"
+	resourceID = "lifecycle_resource_id"
+	importCmd  = "import"
+)
+
+// TestCase is a rancher2 resource whose create, update, import and destroy are exercised by Run. The snippets declare the resource
+// under test and anything it depends on; the Terraform and rancher2 provider blocks are added by the harness.
+type TestCase struct {
+	Name          string
+	Address       string
+	Create        string
+	Update        string
+	ImportID      func(id string) string
+	Verify        func(client *rancher.Client, id string, updated bool) error
+	VerifyDeleted func(client *rancher.Client, id string) error
+}
+
+// Run is a function that will run the full lifecycle of the resource in its own workspace. The resource is created and verified,
+// updated and verified, checked for an empty plan, imported into a fresh state that must plan no changes, and finally destroyed.
+func Run(t *testing.T, client *rancher.Client, cattleConfig map[string]any, testCase TestCase) {
+	rancherConfig, terraformConfig, terratestConfig, _ := config.LoadTFPConfigs(cattleConfig)
+	configMap := []map[string]any{cattleConfig}
+
+	terraformOptions, keyPath := framework.SetupWorkspace(t, terraformConfig, terratestConfig)
+	defer cleanup.Cleanup(t, terraformOptions, keyPath)
+
+	logrus.Infof("Creating %s...", testCase.Address)
+	err := WriteMainTF(keyPath, rancherConfig, configMap, testCase.Address, testCase.Create)
+	require.NoError(t, err)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	id := terraform.Output(t, terraformOptions, resourceID)
+
+	err = testCase.Verify(client, id, false)
+	require.NoError(t, err)
+
+	logrus.Infof("Updating %s...", testCase.Address)
+	err = WriteMainTF(keyPath, rancherConfig, configMap, testCase.Address, testCase.Update)
+	require.NoError(t, err)
+
+	terraform.Apply(t, terraformOptions)
+
+	err = testCase.Verify(client, id, true)
+	require.NoError(t, err)
+
+	err = plan.CheckIdempotency(t, terraformOptions)
+	require.NoError(t, err)
+
+	importID := id
+	if testCase.ImportID != nil {
+		importID = testCase.ImportID(id)
+	}
+
+	logrus.Infof("Importing %s with ID %s into a fresh state...", testCase.Address, importID)
+	importOptions, importKeyPath := framework.SetupWorkspace(t, terraformConfig, terratestConfig)
+	defer framework.RemoveWorkspace(t, importKeyPath, terratestConfig.KeepWorkspaceOnFailure)
+
+	err = WriteMainTF(importKeyPath, rancherConfig, configMap, testCase.Address, testCase.Update)
+	require.NoError(t, err)
+
+	terraform.Init(t, importOptions)
+	terraform.RunTerraformCommand(t, importOptions, importCmd, "-input=false", "-no-color", testCase.Address, importID)
+
+	err = plan.CheckIdempotency(t, importOptions)
+	require.NoError(t, err)
+
+	logrus.Infof("Destroying %s...", testCase.Address)
+	terraform.Destroy(t, terraformOptions)
+
+	err = kwait.PollUntilContextTimeout(context.TODO(), timeouts.FiveSecondTimeout, timeouts.FiveMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		return testCase.VerifyDeleted(client, id) == nil, nil
+	})
+	if err != nil {
+		err = testCase.VerifyDeleted(client, id)
+	}
+
+	require.NoError(t, err)
+}
+
+// WriteMainTF is a function that will write the main.tf file of a lifecycle workspace. It holds the rancher2 provider, the snippet
+// and an output with the ID of the resource under test.
+func WriteMainTF(keyPath string, rancherConfig *rancher.Config, configMap []map[string]any, address, snippet string) error {
+	newFile := hclwrite.NewEmptyFile()
+	rootBody := newFile.Body()
+
+	newFile, rootBody = rancher2.SetRenderProvidersTF(rancherConfig, newFile, rootBody, configMap, false)
+
+	snippetFile, diags := hclwrite.ParseConfig([]byte(snippet), address+".tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		return diags
+	}
+
+	for _, block := range snippetFile.Body().Blocks() {
+		rootBody.AppendBlock(block)
+		rootBody.AppendNewline()
+	}
+
+	idTraversal, diags := hclsyntax.ParseTraversalAbs([]byte(address+".id"), address, hcl.InitialPos)
+	if diags.HasErrors() {
+		return diags
+	}
+
+	outputBlockBody := rootBody.AppendNewBlock(output, []string{resourceID}).Body()
+	outputBlockBody.SetAttributeTraversal(value, idTraversal)
+
+	rootBody.AppendNewline()
+
+	file, err := os.Create(keyPath + configs.MainTF)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	return secrets.WriteMainTF(file, newFile)
+}
@@ -0,0 +1,352 @@
+package minio
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/rancher/norman/clientbase"
+	"github.com/rancher/shepherd/clients/rancher"
+	steveV1 "github.com/rancher/shepherd/clients/rancher/v1"
+	timeouts "github.com/rancher/shepherd/extensions/defaults"
+	namegen "github.com/rancher/shepherd/pkg/namegenerator"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/stevetypes"
+	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	namespacePrefix = "tfp-minio-"
+
+	DefaultBucket = "etcd-snapshots"
+	DefaultRegion = "us-east-1"
+
+	name          = "minio"
+	bucketJobName = "minio-bucket"
+	certsName     = "minio-certs"
+	credsName     = "minio-credentials"
+	minioImage    = "quay.io/minio/minio:RELEASE.2025-04-22T22-12-26Z"
+	mcImage       = "quay.io/minio/mc:RELEASE.2025-04-16T18-13-26Z"
+	port          = 9000
+	certsDir      = "/certs"
+	dataDir       = "/data"
+	publicCert    = "public.crt"
+	privateKey    = "private.key"
+	rootUser      = "MINIO_ROOT_USER"
+	rootPassword  = "MINIO_ROOT_PASSWORD"
+	certValidity  = 7 * 24 * time.Hour
+	backoffLimit  = 6
+)
+
+// Deploy is a function that will deploy a MinIO server in the local cluster as a stand-in for S3, so that etcd snapshots can be
+// stored in S3 without an AWS account. The server is served over TLS on a node port of the local cluster, which the downstream nodes
+// must be able to reach, and the bucket of the snapshot config is created. The credentials, endpoint, CA and region of the snapshot
+// config are set to those of the server. Every server is deployed in its own namespace, which is returned to delete it.
+func Deploy(client *rancher.Client, snapshotS3 *config.SnapshotS3) (string, error) {
+	nodeIP, err := localNodeIP(client)
+	if err != nil {
+		return "", err
+	}
+
+	namespace := namespacePrefix + namegen.RandStringLower(5)
+
+	certPEM, keyPEM, err := selfSignedCert(nodeIP, namespace)
+	if err != nil {
+		return "", err
+	}
+
+	if snapshotS3.Bucket == "" {
+		snapshotS3.Bucket = DefaultBucket
+	}
+
+	if snapshotS3.Region == "" {
+		snapshotS3.Region = DefaultRegion
+	}
+
+	snapshotS3.AccessKey = namegen.RandStringLower(12)
+	snapshotS3.SecretKey = namegen.RandStringLower(24)
+
+	logrus.Infof("Deploying MinIO in namespace %s of the local cluster...", namespace)
+
+	labels := map[string]string{"app": name}
+
+	objects := []struct {
+		steveType string
+		object    any
+	}{
+		{stevetypes.Namespace, corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}},
+		{stevetypes.Secret, corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: certsName, Namespace: namespace},
+			StringData: map[string]string{publicCert: string(certPEM), privateKey: string(keyPEM)},
+		}},
+		{stevetypes.Secret, corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: credsName, Namespace: namespace},
+			StringData: map[string]string{rootUser: snapshotS3.AccessKey, rootPassword: snapshotS3.SecretKey},
+		}},
+		{stevetypes.Deployment, deployment(namespace, labels)},
+		{stevetypes.Service, corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: corev1.ServiceSpec{
+				Type:     corev1.ServiceTypeNodePort,
+				Selector: labels,
+				Ports:    []corev1.ServicePort{{Name: "s3", Port: port, TargetPort: intstr.FromInt32(port)}},
+			},
+		}},
+	}
+
+	var service *steveV1.SteveAPIObject
+	for _, object := range objects {
+		created, err := client.Steve.SteveType(object.steveType).Create(object.object)
+		if err != nil {
+			return namespace, fmt.Errorf("failed to create %s: %w", object.steveType, err)
+		}
+
+		if object.steveType == stevetypes.Service {
+			service = created
+		}
+	}
+
+	serviceSpec := corev1.ServiceSpec{}
+	err = steveV1.ConvertToK8sType(service.Spec, &serviceSpec)
+	if err != nil {
+		return namespace, err
+	}
+
+	if len(serviceSpec.Ports) == 0 || serviceSpec.Ports[0].NodePort == 0 {
+		return namespace, errors.New("MinIO service has no node port")
+	}
+
+	err = waitForDeployment(client, namespace)
+	if err != nil {
+		return namespace, err
+	}
+
+	_, err = client.Steve.SteveType(stevetypes.Job).Create(bucketJob(namespace, snapshotS3.Bucket))
+	if err != nil {
+		return namespace, fmt.Errorf("failed to create %s: %w", stevetypes.Job, err)
+	}
+
+	err = waitForBucketJob(client, namespace)
+	if err != nil {
+		return namespace, err
+	}
+
+	snapshotS3.Endpoint = net.JoinHostPort(nodeIP, strconv.Itoa(int(serviceSpec.Ports[0].NodePort)))
+	snapshotS3.EndpointCA = string(certPEM)
+
+	logrus.Infof("MinIO is serving bucket %s on %s", snapshotS3.Bucket, snapshotS3.Endpoint)
+
+	return namespace, nil
+}
+
+// Delete is a function that will delete the namespace of the MinIO server, and with it the server and its data, from the local
+// cluster. It waits until the namespace is gone.
+func Delete(client *rancher.Client, namespace string) error {
+	namespaceObject, err := client.Steve.SteveType(stevetypes.Namespace).ByID(namespace)
+	if err != nil {
+		return err
+	}
+
+	err = client.Steve.SteveType(stevetypes.Namespace).Delete(namespaceObject)
+	if err != nil {
+		return err
+	}
+
+	return kwait.PollUntilContextTimeout(context.TODO(), timeouts.FiveSecondTimeout, timeouts.FiveMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		_, err := client.Steve.SteveType(stevetypes.Namespace).ByID(namespace)
+		if clientbase.IsNotFound(err) {
+			return true, nil
+		}
+
+		return false, err
+	})
+}
+
+// deployment is a helper function that will return the deployment of the MinIO server.
+func deployment(namespace string, labels map[string]string) appsv1.Deployment {
+	replicas := int32(1)
+
+	return appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:    name,
+						Image:   minioImage,
+						Args:    []string{"server", dataDir, "--certs-dir", certsDir},
+						EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: credsName}}}},
+						Ports:   []corev1.ContainerPort{{ContainerPort: port}},
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "data", MountPath: dataDir},
+							{Name: "certs", MountPath: certsDir, ReadOnly: true},
+						},
+					}},
+					Volumes: []corev1.Volume{
+						{Name: "data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+						{Name: "certs", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: certsName}}},
+					},
+				},
+			},
+		},
+	}
+}
+
+// bucketJob is a helper function that will return the job that creates the bucket on the MinIO server.
+func bucketJob(namespace, bucket string) batchv1.Job {
+	limit := int32(backoffLimit)
+	script := fmt.Sprintf(`mc alias set tfp https://%s.%s.svc:%d "$%s" "$%s" --insecure && mc mb --ignore-existing --insecure tfp/%s`,
+		name, namespace, port, rootUser, rootPassword, bucket)
+
+	return batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: bucketJobName, Namespace: namespace},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &limit,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyOnFailure,
+					Containers: []corev1.Container{{
+						Name:    bucketJobName,
+						Image:   mcImage,
+						Command: []string{"/bin/sh", "-c", script},
+						EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: credsName}}}},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// waitForDeployment is a helper function that will wait for the MinIO server to be available.
+func waitForDeployment(client *rancher.Client, namespace string) error {
+	return kwait.PollUntilContextTimeout(context.TODO(), timeouts.FiveSecondTimeout, timeouts.FiveMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		deployment, err := client.Steve.SteveType(stevetypes.Deployment).ByID(namespace + "/" + name)
+		if err != nil {
+			return false, nil
+		}
+
+		status := appsv1.DeploymentStatus{}
+		err = steveV1.ConvertToK8sType(deployment.Status, &status)
+		if err != nil {
+			return false, err
+		}
+
+		return status.AvailableReplicas > 0, nil
+	})
+}
+
+// waitForBucketJob is a helper function that will wait for the job that creates the bucket to succeed.
+func waitForBucketJob(client *rancher.Client, namespace string) error {
+	return kwait.PollUntilContextTimeout(context.TODO(), timeouts.FiveSecondTimeout, timeouts.FiveMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		job, err := client.Steve.SteveType(stevetypes.Job).ByID(namespace + "/" + bucketJobName)
+		if err != nil {
+			return false, nil
+		}
+
+		status := batchv1.JobStatus{}
+		err = steveV1.ConvertToK8sType(job.Status, &status)
+		if err != nil {
+			return false, err
+		}
+
+		if status.Failed > backoffLimit {
+			return false, errors.New("failed to create the MinIO bucket")
+		}
+
+		return status.Succeeded > 0, nil
+	})
+}
+
+// localNodeIP is a helper function that will return the IP of a node of the local cluster, on which the node port of the MinIO
+// server is reachable. External IPs are preferred, as the downstream nodes may not share the network of the local cluster, and
+// the internal IP is used when no node has one.
+func localNodeIP(client *rancher.Client) (string, error) {
+	nodes, err := client.Steve.SteveType(stevetypes.Node).List(nil)
+	if err != nil {
+		return "", err
+	}
+
+	addresses := map[corev1.NodeAddressType]string{}
+	for _, node := range nodes.Data {
+		status := corev1.NodeStatus{}
+		err = steveV1.ConvertToK8sType(node.Status, &status)
+		if err != nil {
+			return "", err
+		}
+
+		for _, address := range status.Addresses {
+			if _, ok := addresses[address.Type]; !ok {
+				addresses[address.Type] = address.Address
+			}
+		}
+	}
+
+	for _, addressType := range []corev1.NodeAddressType{corev1.NodeExternalIP, corev1.NodeInternalIP} {
+		if address, ok := addresses[addressType]; ok {
+			return address, nil
+		}
+	}
+
+	return "", errors.New("no node of the local cluster has an external or internal IP")
+}
+
+// selfSignedCert is a helper function that will generate a self-signed certificate for the MinIO server, valid for the node IP and
+// the service name, which also serves as the CA of the endpoint.
+func selfSignedCert(nodeIP, namespace string) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(certValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.ParseIP(nodeIP)},
+		DNSNames:              []string{name, name + "." + namespace + ".svc"},
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return certPEM, keyPEM, nil
+}
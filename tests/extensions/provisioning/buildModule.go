@@ -10,6 +10,8 @@ import (
 	"github.com/rancher/tfp-automation/defaults/keypath"
 	framework "github.com/rancher/tfp-automation/framework/set"
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
+	"github.com/rancher/tfp-automation/framework/validate"
 	"github.com/sirupsen/logrus"
 )
 
@@ -17,14 +19,33 @@ import (
 func BuildModule(t *testing.T, rancherConfig *rancher.Config, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, configMap []map[string]any) error {
 	_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, terratestConfig.PathToRepo, "")
 
-	_, _, err := framework.ConfigTF(nil, rancherConfig, terratestConfig, "", "", "", configMap, nil, nil, nil, false, false, false, nil)
+	module, values, err := framework.RenderTF(rancherConfig, configMap, false)
 	if err != nil {
 		return err
 	}
 
-	module, err := os.ReadFile(keyPath + configs.MainTF)
+	if terratestConfig.ProviderSchema != "" {
+		schemas, err := validate.LoadProviderSchemas(terratestConfig.ProviderSchema)
+		if err != nil {
+			return err
+		}
+
+		err = validate.ValidateTF(module, keyPath+configs.MainTF, schemas)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = os.WriteFile(keyPath+configs.MainTF, module, 0644)
+	if err != nil {
+		logrus.Errorf("Failed to write configurations to main.tf file. Error: %v", err)
+
+		return err
+	}
+
+	err = secrets.WriteTFVars(keyPath, values)
 	if err != nil {
-		logrus.Errorf("Failed to read main.tf file contents. Error: %v", err)
+		logrus.Errorf("Failed to write sensitive values to %s file. Error: %v", configs.SecretsTFVars, err)
 
 		return err
 	}
@@ -1,25 +1,93 @@
 package provisioning
 
 import (
+	"errors"
+	"os"
 	"testing"
 
 	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/rancher/shepherd/clients/rancher"
+	shepherdConfig "github.com/rancher/shepherd/pkg/config"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/configs"
 	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework"
 	"github.com/rancher/tfp-automation/framework/cleanup"
+	set "github.com/rancher/tfp-automation/framework/set"
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/set/secrets"
+	"github.com/sirupsen/logrus"
 )
 
-// ForceCleanup is a function that will forcibly run terraform destroy and cleanup Terraform resources.
+// ForceCleanup is a function that will forcibly run terraform destroy and cleanup Terraform resources. When the main.tf file is
+// gone, such as on a fresh runner, it is rendered again from the cattle config, so the backend reads the remote state kept under
+// the resource prefix of the run that created the resources.
 func ForceCleanup(t *testing.T) error {
 	_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, "", "")
 
+	cattleConfig := shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	rancherConfig, _, terratestConfig, _ := config.LoadTFPConfigs(cattleConfig)
+
+	rendered, err := renderMainTF(rancherConfig, cattleConfig, keyPath)
+	if err != nil {
+		return err
+	}
+
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
-		TerraformDir: keyPath,
-		NoColor:      true,
+		TerraformDir:    keyPath,
+		TerraformBinary: framework.Engine(terratestConfig),
+		NoColor:         true,
 	})
 
+	// With a remote backend, the .terraform folder may be gone when the runner that created the resources died, so the
+	// backend is initialized again to read the remote state.
+	if rendered || (terratestConfig.Backend != nil && terratestConfig.Backend.Type != "") {
+		terraform.Init(t, terraformOptions)
+	}
+
 	terraform.Destroy(t, terraformOptions)
 	cleanup.TFFilesCleanup(keyPath)
 
 	return nil
 }
+
+// renderMainTF is a helper function that will render the main.tf file and its sensitive values in the key path when the main.tf
+// file does not exist. It returns whether the main.tf file was rendered.
+func renderMainTF(rancherConfig *rancher.Config, cattleConfig map[string]any, keyPath string) (bool, error) {
+	_, err := os.Stat(keyPath + configs.MainTF)
+	if err == nil {
+		return false, nil
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+
+	_, terraformConfig, _, _ := config.LoadTFPConfigs(cattleConfig)
+
+	module, err := set.LookupModule(terraformConfig.Module)
+	if err != nil {
+		return false, err
+	}
+
+	mainTF, values, err := set.RenderTF(rancherConfig, []map[string]any{cattleConfig}, module.IsWindows())
+	if err != nil {
+		return false, err
+	}
+
+	err = os.WriteFile(keyPath+configs.MainTF, mainTF, 0644)
+	if err != nil {
+		logrus.Errorf("Failed to write configurations to main.tf file. Error: %v", err)
+
+		return false, err
+	}
+
+	err = secrets.WriteTFVars(keyPath, values)
+	if err != nil {
+		logrus.Errorf("Failed to write sensitive values to %s file. Error: %v", configs.SecretsTFVars, err)
+
+		return false, err
+	}
+
+	return true, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/rancher/shepherd/clients/rancher"
 	clusterExtensions "github.com/rancher/shepherd/extensions/clusters"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/plan"
 	framework "github.com/rancher/tfp-automation/framework/set"
 	"github.com/stretchr/testify/require"
 )
@@ -28,7 +29,8 @@ func KubernetesUpgrade(t *testing.T, client, standardUserClient *rancher.Client,
 	clusterNames, customClusterNames, err = framework.ConfigTF(client, rancherConfig, terratestConfig, testUser, testPassword, "", configMap, newFile, rootBody, file, isWindows, persistClusters, containsCustomModule, customClusterNames)
 	require.NoError(t, err)
 
-	terraform.Apply(t, terraformOptions)
+	err = ValidateTF(t, terraformOptions, terratestConfig)
+	require.NoError(t, err)
 
 	for _, clusterName := range clusterNames {
 		clusterID, err := clusterExtensions.GetClusterIDByName(client, clusterName)
@@ -37,5 +39,21 @@ func KubernetesUpgrade(t *testing.T, client, standardUserClient *rancher.Client,
 		clusterIDs = append(clusterIDs, clusterID)
 	}
 
+	upgrade := func() {
+		err = plan.PlanAndApply(t, terraformOptions, plan.UpdateOnly)
+		require.NoError(t, err)
+	}
+
+	if terraformConfig.UpgradeStrategy != nil {
+		VerifyUpgradeStrategy(t, client, clusterIDs, terraformConfig.UpgradeStrategy, upgrade)
+	} else {
+		upgrade()
+	}
+
+	if terratestConfig.IdempotencyCheck {
+		err = plan.CheckIdempotency(t, terraformOptions)
+		require.NoError(t, err)
+	}
+
 	return clusterIDs, customClusterNames
 }
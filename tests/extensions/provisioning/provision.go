@@ -9,6 +9,7 @@ import (
 	"github.com/rancher/shepherd/clients/rancher"
 	clusterExtensions "github.com/rancher/shepherd/extensions/clusters"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/plan"
 	framework "github.com/rancher/tfp-automation/framework/set"
 	"github.com/stretchr/testify/require"
 )
@@ -22,13 +23,23 @@ func Provision(t *testing.T, client, standardUserClient *rancher.Client, rancher
 	var clusterNames []string
 	var clusterIDs []string
 
-	isSupported := SupportedModules(terraformOptions, configMap)
-	require.True(t, isSupported)
+	err = SupportedModules(terraformOptions, configMap)
+	require.NoError(t, err)
 
 	clusterNames, customClusterNames, err = framework.ConfigTF(standardUserClient, rancherConfig, terratestConfig, testUser, testPassword, "", configMap, newFile, rootBody, file, isWindows, persistClusters, containsCustomModule, customClusterNames)
 	require.NoError(t, err)
 
-	terraform.InitAndApply(t, terraformOptions)
+	terraform.Init(t, terraformOptions)
+
+	err = ValidateTF(t, terraformOptions, terratestConfig)
+	require.NoError(t, err)
+
+	terraform.Apply(t, terraformOptions)
+
+	if terratestConfig.IdempotencyCheck {
+		err = plan.CheckIdempotency(t, terraformOptions)
+		require.NoError(t, err)
+	}
 
 	for _, clusterName := range clusterNames {
 		clusterID, err := clusterExtensions.GetClusterIDByName(client, clusterName)
@@ -0,0 +1,51 @@
+package provisioning
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/shepherd/clients/rancher"
+	clusterExtensions "github.com/rancher/shepherd/extensions/clusters"
+	"github.com/rancher/shepherd/pkg/config/operations"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/rancher/tfp-automation/framework/plan"
+	framework "github.com/rancher/tfp-automation/framework/set"
+	"github.com/stretchr/testify/require"
+)
+
+// RotateCertificates is a function that will rotate the certificates of the given services of the provisioned cluster, or of all
+// services if none are given, and verify the certificates were renewed. The rotate_certificates generation of RKE2/K3s clusters is
+// bumped on every call, while RKE1 clusters only rotate when the services or CA setting change.
+func RotateCertificates(t *testing.T, client *rancher.Client, rancherConfig *rancher.Config, terraformConfig *config.TerraformConfig,
+	testUser, testPassword string, terraformOptions *terraform.Options, configMap []map[string]any, newFile *hclwrite.File,
+	rootBody *hclwrite.Body, file *os.File, services []string) {
+	_, _, terratestConfig, _ := config.LoadTFPConfigs(configMap[0])
+
+	rotation := terratestConfig.RotateCertificates
+	if rotation == nil {
+		rotation = &config.RotateCertificates{}
+	}
+
+	rotation.Services = services
+	if !strings.Contains(terraformConfig.Module, clustertypes.RKE1) {
+		rotation.Generation++
+	}
+
+	_, err := operations.ReplaceValue([]string{"terratest", "rotateCertificates"}, rotation, configMap[0])
+	require.NoError(t, err)
+
+	_, _, err = framework.ConfigTF(client, rancherConfig, terratestConfig, testUser, testPassword, "", configMap, newFile, rootBody, file, false, false, false, nil)
+	require.NoError(t, err)
+
+	clusterID, err := clusterExtensions.GetClusterIDByName(client, terraformConfig.ResourcePrefix)
+	require.NoError(t, err)
+
+	VerifyCertificateRotation(t, client, clusterID, services, func() {
+		err = plan.PlanAndApply(t, terraformOptions, plan.UpdateOnly)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,38 @@
+package provisioning
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/shepherd/pkg/config/operations"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/plan"
+	framework "github.com/rancher/tfp-automation/framework/set"
+	"github.com/stretchr/testify/require"
+)
+
+// ScaleNodepool is a function that will set the quantity of a node pool of the provisioned cluster and run terraform apply.
+func ScaleNodepool(t *testing.T, standardUserClient *rancher.Client, rancherConfig *rancher.Config, terratestConfig *config.TerratestConfig,
+	testUser, testPassword string, terraformOptions *terraform.Options, configMap []map[string]any, newFile *hclwrite.File,
+	rootBody *hclwrite.Body, file *os.File, poolIndex int, quantity int64) {
+	_, _, scaledTerratestConfig, _ := config.LoadTFPConfigs(configMap[0])
+	require.Less(t, poolIndex, len(scaledTerratestConfig.Nodepools))
+
+	nodepools := append([]config.Nodepool(nil), scaledTerratestConfig.Nodepools...)
+	nodepools[poolIndex].Quantity = quantity
+
+	_, err := operations.ReplaceValue([]string{config.TerratestConfigurationFileKey, "nodepools"}, nodepools, configMap[0])
+	require.NoError(t, err)
+
+	_, _, err = framework.ConfigTF(standardUserClient, rancherConfig, terratestConfig, testUser, testPassword, "", configMap, newFile, rootBody, file, false, false, false, nil)
+	require.NoError(t, err)
+
+	err = ValidateTF(t, terraformOptions, terratestConfig)
+	require.NoError(t, err)
+
+	err = plan.PlanAndApply(t, terraformOptions, plan.UpdateOnly)
+	require.NoError(t, err)
+}
@@ -1,69 +1,22 @@
 package provisioning
 
 import (
-	"slices"
-
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/rancher/shepherd/pkg/config/operations"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/modules"
+	framework "github.com/rancher/tfp-automation/framework/set"
 )
 
-// SupportedModules is a function that will check if the user-inputted module is supported.
-func SupportedModules(terraformOptions *terraform.Options, configMap []map[string]any) bool {
-	var isSupported bool
+// SupportedModules is a function that will check if every user-inputted module is supported.
+func SupportedModules(terraformOptions *terraform.Options, configMap []map[string]any) error {
 	for _, cattleConfig := range configMap {
 		tfConfig := new(config.TerraformConfig)
 		operations.LoadObjectFromMap(config.TerraformConfigurationFileKey, cattleConfig, tfConfig)
 
-		isSupported = verifyModule(tfConfig.Module)
-	}
-
-	return isSupported
-}
-
-func verifyModule(module string) bool {
-	supportedModules := []string{
-		modules.AKS,
-		modules.EKS,
-		modules.GKE,
-		modules.AzureRKE1,
-		modules.AzureRKE2,
-		modules.AzureK3s,
-		modules.EC2RKE1,
-		modules.EC2RKE2,
-		modules.EC2K3s,
-		modules.HarvesterRKE1,
-		modules.HarvesterRKE2,
-		modules.HarvesterK3s,
-		modules.LinodeRKE1,
-		modules.LinodeRKE2,
-		modules.LinodeK3s,
-		modules.VsphereRKE1,
-		modules.VsphereRKE2,
-		modules.VsphereK3s,
-		modules.CustomEC2RKE1,
-		modules.CustomEC2RKE2,
-		modules.CustomEC2RKE2Windows2019,
-		modules.CustomEC2RKE2Windows2022,
-		modules.CustomEC2K3s,
-		modules.CustomVsphereRKE1,
-		modules.CustomVsphereRKE2,
-		modules.CustomVsphereK3s,
-		modules.AirgapRKE1,
-		modules.AirgapRKE2,
-		modules.AirgapRKE2Windows2019,
-		modules.AirgapRKE2Windows2022,
-		modules.AirgapK3S,
-		modules.ImportEC2RKE1,
-		modules.ImportEC2RKE2,
-		modules.ImportEC2RKE2Windows2019,
-		modules.ImportEC2RKE2Windows2022,
-		modules.ImportEC2K3s,
-		modules.ImportVsphereRKE1,
-		modules.ImportVsphereRKE2,
-		modules.ImportVsphereK3s,
+		if _, err := framework.LookupModule(tfConfig.Module); err != nil {
+			return err
+		}
 	}
 
-	return slices.Contains(supportedModules, module)
+	return nil
 }
@@ -0,0 +1,73 @@
+package provisioning
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/shepherd/clients/rancher"
+	clusterExtensions "github.com/rancher/shepherd/extensions/clusters"
+	"github.com/rancher/shepherd/pkg/config/operations"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/plan"
+	framework "github.com/rancher/tfp-automation/framework/set"
+	"github.com/stretchr/testify/require"
+)
+
+// UpdateHostedNodepools is a function that will set the node pools of an imported hosted cluster, which are identified by name, and run
+// terraform apply. It verifies the change round trips to the upstream spec of the cluster and to its nodes. When no node pool shrinks,
+// the nodes of the cluster before the change must be kept.
+func UpdateHostedNodepools(t *testing.T, client *rancher.Client, rancherConfig *rancher.Config, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig, testUser, testPassword string, terraformOptions *terraform.Options, configMap []map[string]any,
+	newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File, nodepools []config.Nodepool) {
+	_, _, currentTerratestConfig, _ := config.LoadTFPConfigs(configMap[0])
+
+	module, err := framework.LookupModule(terraformConfig.Module)
+	require.NoError(t, err)
+
+	clusterID, err := clusterExtensions.GetClusterIDByName(client, terraformConfig.ResourcePrefix)
+	require.NoError(t, err)
+
+	var keptNodes []string
+	if !shrinks(module, currentTerratestConfig.Nodepools, nodepools) {
+		nodes, err := downstreamNodes(client, clusterID)
+		require.NoError(t, err)
+
+		for _, node := range nodes {
+			keptNodes = append(keptNodes, node.Name)
+		}
+	}
+
+	_, err = operations.ReplaceValue([]string{config.TerratestConfigurationFileKey, "nodepools"}, nodepools, configMap[0])
+	require.NoError(t, err)
+
+	_, _, err = framework.ConfigTF(client, rancherConfig, terratestConfig, testUser, testPassword, "", configMap, newFile, rootBody, file, false, false, false, nil)
+	require.NoError(t, err)
+
+	err = ValidateTF(t, terraformOptions, terratestConfig)
+	require.NoError(t, err)
+
+	err = plan.PlanAndApply(t, terraformOptions, plan.UpdateOnly)
+	require.NoError(t, err)
+
+	verifyHostedNodepools(t, client, clusterID, module, nodepools, keptNodes)
+	VerifyClustersState(t, client, []string{clusterID})
+}
+
+// shrinks is a helper function that will return whether any node pool is removed or made smaller by the change from current to updated.
+func shrinks(module framework.Module, current, updated []config.Nodepool) bool {
+	sizes := map[string]int64{}
+	for _, pool := range updated {
+		sizes[pool.Name] = hostedPoolSize(module, pool)
+	}
+
+	for _, pool := range current {
+		size, ok := sizes[pool.Name]
+		if !ok || size < hostedPoolSize(module, pool) {
+			return true
+		}
+	}
+
+	return false
+}
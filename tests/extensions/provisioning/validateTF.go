@@ -0,0 +1,24 @@
+package provisioning
+
+import (
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/framework/validate"
+	"github.com/sirupsen/logrus"
+)
+
+// ValidateTF is a function that will validate the generated main.tf file against the provider schemas before it is applied.
+// The cached schema set in terratest.providerSchema is used when present, otherwise the Terraform directory must be initialized.
+func ValidateTF(t *testing.T, terraformOptions *terraform.Options, terratestConfig *config.TerratestConfig) error {
+	schemas, err := validate.GetProviderSchemas(t, terraformOptions, terratestConfig.ProviderSchema)
+	if err != nil {
+		return err
+	}
+
+	logrus.Infof("Validating %s against the provider schemas...", terraformOptions.TerraformDir+configs.MainTF)
+
+	return validate.ValidateTFFile(terraformOptions.TerraformDir+configs.MainTF, schemas)
+}
@@ -1,7 +1,6 @@
 package provisioning
 
 import (
-	"strings"
 	"testing"
 
 	"github.com/gruntwork-io/terratest/modules/terraform"
@@ -16,8 +15,8 @@ import (
 	"github.com/rancher/tests/actions/workloads/deployment"
 	"github.com/rancher/tests/actions/workloads/statefulset"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/clustertypes"
 	"github.com/rancher/tfp-automation/framework/cleanup"
+	framework "github.com/rancher/tfp-automation/framework/set"
 	waitState "github.com/rancher/tfp-automation/framework/wait/state"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
@@ -127,34 +126,9 @@ func VerifyNodeCount(t *testing.T, client *rancher.Client, clusterName string, t
 	clusterID, err := clusterExtensions.GetClusterIDByName(client, clusterName)
 	require.NoError(t, err)
 
-	cluster, err := client.Management.Cluster.ByID(clusterID)
+	module, err := framework.LookupModule(terraformConfig.Module)
 	require.NoError(t, err)
 
-	var module string
-
-	if strings.Contains(terraformConfig.Module, clustertypes.RKE1) {
-		module = clustertypes.RKE1
-	} else if strings.Contains(terraformConfig.Module, clustertypes.RKE2) {
-		module = clustertypes.RKE2
-	} else if strings.Contains(terraformConfig.Module, clustertypes.K3S) {
-		module = clustertypes.K3S
-	} else {
-		module = terraformConfig.Module
-	}
-
-	switch module {
-	case clustertypes.AKS:
-		var aksConfig = cluster.AKSConfig
-		require.Equal(t, (*aksConfig.NodePools)[0].Count, cluster.NodeCount)
-	case clustertypes.EKS:
-		var eksConfig = cluster.EKSConfig
-		require.Equal(t, (*eksConfig.NodeGroups)[0].DesiredSize, cluster.NodeCount)
-	case clustertypes.GKE:
-		var gkeConfig = cluster.GKEConfig
-		require.Equal(t, (*gkeConfig.NodePools)[0].InitialNodeCount, cluster.NodeCount)
-	case clustertypes.RKE1, clustertypes.RKE2, clustertypes.K3S:
-		require.Equal(t, nodeCount, cluster.NodeCount)
-	default:
-		logrus.Errorf("Unsupported module: %v", module)
-	}
+	err = module.Verify(client, clusterID, nodeCount)
+	require.NoError(t, err)
 }
@@ -0,0 +1,196 @@
+package provisioning
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	timeouts "github.com/rancher/shepherd/extensions/defaults"
+	"github.com/rancher/tfp-automation/defaults/certificates"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	rke1ControlPlaneRoleLabel = "node-role.kubernetes.io/controlplane"
+
+	certDialTimeout = 10 * time.Second
+)
+
+// certEndpoint is an address on which a node serves the certificate of a service.
+type certEndpoint struct {
+	service string
+	node    string
+	address string
+}
+
+// VerifyCertificateRotation validates that rotate, such as a terraform apply with a bumped rotate_certificates generation, renews the
+// certificates of the given services, or of all services if none are given. The serving certificates of the kube-apiserver, kubelet
+// and etcd are read over TLS from the nodes before and after rotate, and each must have a new serial and a later notBefore once the
+// cluster is active again. Node driver nodes are reached with keys generated by Rancher rather than terraform.privateKeyPath, so
+// the certificates are read from their serving ports instead of over SSH. Endpoints that are not reachable from the test runner are
+// skipped, but at least one must be.
+func VerifyCertificateRotation(t *testing.T, client *rancher.Client, clusterID string, services []string, rotate func()) {
+	nodes, err := downstreamNodes(client, clusterID)
+	require.NoError(t, err)
+
+	endpoints := certEndpoints(nodes, services)
+	require.NotEmpty(t, endpoints, "none of the rotated services %v serve a certificate that can be verified", services)
+
+	before := map[certEndpoint]*x509.Certificate{}
+	for _, endpoint := range endpoints {
+		cert, err := servingCert(endpoint.address)
+		if err != nil {
+			logrus.Warnf("Skipping the %s certificate of node %s, %s is not reachable: %v", endpoint.service, endpoint.node, endpoint.address, err)
+			continue
+		}
+
+		before[endpoint] = cert
+	}
+
+	require.NotEmpty(t, before, "none of the serving certificates of the rotated services are reachable")
+
+	rotate()
+
+	VerifyClustersState(t, client, []string{clusterID})
+
+	after := map[certEndpoint]*x509.Certificate{}
+	err = kwait.PollUntilContextTimeout(context.TODO(), timeouts.FiveSecondTimeout, timeouts.FiveMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		for endpoint, oldCert := range before {
+			cert, err := servingCert(endpoint.address)
+			if err != nil {
+				return false, nil
+			}
+
+			after[endpoint] = cert
+			if cert.SerialNumber.Cmp(oldCert.SerialNumber) == 0 {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	})
+	if err != nil {
+		logrus.Warnf("Not every serving certificate was renewed within %s", timeouts.FiveMinuteTimeout)
+	}
+
+	for endpoint, oldCert := range before {
+		cert, ok := after[endpoint]
+		if !assert.Truef(t, ok, "the %s certificate of node %s was not reachable after the rotation", endpoint.service, endpoint.node) {
+			continue
+		}
+
+		assert.NotEqualf(t, oldCert.SerialNumber.String(), cert.SerialNumber.String(), "the %s certificate of node %s was not rotated", endpoint.service, endpoint.node)
+		assert.Truef(t, cert.NotBefore.After(oldCert.NotBefore), "the %s certificate of node %s is not newer, notBefore %s was %s",
+			endpoint.service, endpoint.node, cert.NotBefore.Format(time.RFC3339), oldCert.NotBefore.Format(time.RFC3339))
+
+		logrus.Infof("The %s certificate of node %s was rotated, notBefore is %s", endpoint.service, endpoint.node, cert.NotBefore.Format(time.RFC3339))
+	}
+}
+
+// certEndpoints is a helper function that will return the addresses on which the nodes serve the certificates of the given services.
+// The kube-apiserver is served by control plane nodes, etcd by etcd nodes and the kubelet by every node. The kubelets of RKE1 clusters
+// serve self-signed certificates that are not rotated, so they are left out.
+func certEndpoints(nodes []corev1.Node, services []string) []certEndpoint {
+	isRKE1 := slices.ContainsFunc(nodes, func(node corev1.Node) bool {
+		return node.Labels[rke1ControlPlaneRoleLabel] == "true"
+	})
+
+	rotated := func(names ...string) bool {
+		if len(services) == 0 {
+			return true
+		}
+
+		for _, name := range names {
+			if slices.Contains(services, name) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	var endpoints []certEndpoint
+	for _, node := range nodes {
+		address := nodeAddress(node)
+		if address == "" {
+			continue
+		}
+
+		isControlPlane := node.Labels[controlPlaneRoleLabel] == "true" || node.Labels[rke1ControlPlaneRoleLabel] == "true"
+
+		if isControlPlane && rotated(certificates.APIServer, certificates.KubeAPIServer) {
+			endpoints = append(endpoints, certEndpoint{certificates.KubeAPIServer, node.Name, net.JoinHostPort(address, strconv.Itoa(certificates.APIServerPort))})
+		}
+
+		if node.Labels[etcdRoleLabel] == "true" && rotated(certificates.Etcd) {
+			endpoints = append(endpoints, certEndpoint{certificates.Etcd, node.Name, net.JoinHostPort(address, strconv.Itoa(certificates.EtcdPort))})
+		}
+
+		if !isRKE1 && rotated(certificates.Kubelet) {
+			endpoints = append(endpoints, certEndpoint{certificates.Kubelet, node.Name, net.JoinHostPort(address, strconv.Itoa(certificates.KubeletPort))})
+		}
+	}
+
+	return endpoints
+}
+
+// nodeAddress is a helper function that will return the external IP of a node, or its internal IP if it has none.
+func nodeAddress(node corev1.Node) string {
+	var internalIP string
+	for _, address := range node.Status.Addresses {
+		switch address.Type {
+		case corev1.NodeExternalIP:
+			return address.Address
+		case corev1.NodeInternalIP:
+			internalIP = address.Address
+		}
+	}
+
+	return internalIP
+}
+
+// servingCert is a helper function that will return the certificate served on an address. The certificate is read during the TLS
+// handshake, so services that require a client certificate, such as etcd, still present theirs.
+func servingCert(address string) (*x509.Certificate, error) {
+	var cert *x509.Certificate
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return errors.New("no certificate was presented")
+			}
+
+			var err error
+			cert, err = x509.ParseCertificate(rawCerts[0])
+
+			return err
+		},
+	}
+
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: certDialTimeout}, "tcp", address, tlsConfig)
+	if conn != nil {
+		conn.Close()
+	}
+
+	if cert != nil {
+		return cert, nil
+	}
+
+	if err == nil {
+		err = errors.New("no certificate was presented")
+	}
+
+	return nil, err
+}
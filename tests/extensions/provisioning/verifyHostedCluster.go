@@ -0,0 +1,312 @@
+package provisioning
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	management "github.com/rancher/shepherd/clients/rancher/generated/management/v3"
+	timeouts "github.com/rancher/shepherd/extensions/defaults"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/providers"
+	framework "github.com/rancher/tfp-automation/framework/set"
+	"github.com/rancher/tfp-automation/framework/set/provisioning/hosted"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+// hostedSpecDiff collects the fields of the upstream spec of a hosted cluster that do not match the config.
+type hostedSpecDiff []string
+
+// compare is a helper function that will record a field whose upstream value does not match the expected value.
+func (d *hostedSpecDiff) compare(field string, actual, expected any) {
+	if !reflect.DeepEqual(actual, expected) {
+		*d = append(*d, fmt.Sprintf("%s is %v, expected %v", field, actual, expected))
+	}
+}
+
+// missing is a helper function that will record every expected value that is not part of the upstream values.
+func (d *hostedSpecDiff) missing(field string, actual, expected []string) {
+	for _, value := range expected {
+		if !slices.Contains(actual, value) {
+			*d = append(*d, fmt.Sprintf("%s %v does not contain %s", field, actual, value))
+		}
+	}
+}
+
+// labels is a helper function that will record every expected label that is not part of the upstream labels.
+func (d *hostedSpecDiff) labels(field string, actual, expected map[string]string) {
+	for key, value := range expected {
+		if actual[key] != value {
+			*d = append(*d, fmt.Sprintf("%s has %s=%s, expected %s=%s", field, key, actual[key], key, value))
+		}
+	}
+}
+
+// VerifyHostedCluster validates that the upstream spec of an EKS, AKS or GKE cluster, which Rancher reads back from the cloud provider,
+// matches the config field by field: the private endpoint of the cluster and the size, autoscaling, labels, taints, spot instances,
+// launch template and management of every node pool. The upstream spec is synced periodically, so it is polled until it matches.
+func VerifyHostedCluster(t *testing.T, client *rancher.Client, clusterID string, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig) {
+	module, err := framework.LookupModule(terraformConfig.Module)
+	require.NoError(t, err)
+
+	var diff hostedSpecDiff
+	err = kwait.PollUntilContextTimeout(context.TODO(), hostedPollInterval, timeouts.ThirtyMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		cluster, err := client.Management.Cluster.ByID(clusterID)
+		if err != nil {
+			return false, nil
+		}
+
+		switch module.Provider {
+		case providers.AWS:
+			diff = eksSpecDiff(cluster, module, terraformConfig, terratestConfig)
+		case providers.Azure:
+			diff = aksSpecDiff(cluster, module, terraformConfig, terratestConfig)
+		case providers.Google:
+			diff = gkeSpecDiff(cluster, module, terraformConfig, terratestConfig)
+		default:
+			return false, fmt.Errorf("module %s is not a hosted module", module.Name)
+		}
+
+		return len(diff) == 0, nil
+	})
+
+	assert.Emptyf(t, diff, "the upstream spec of cluster %s does not match the config:\n%s", clusterID, strings.Join(diff, "\n"))
+	require.NoError(t, err)
+
+	logrus.Infof("The upstream spec of cluster %s matches the config of its %d node pools", clusterID, len(terratestConfig.Nodepools))
+}
+
+// eksSpecDiff is a helper function that will compare the upstream spec of an EKS cluster against the config.
+func eksSpecDiff(cluster *management.Cluster, module framework.Module, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig) hostedSpecDiff {
+	if cluster.EKSStatus == nil || cluster.EKSStatus.UpstreamSpec == nil || cluster.EKSStatus.UpstreamSpec.NodeGroups == nil {
+		return hostedSpecDiff{"the upstream spec has no node groups yet"}
+	}
+
+	var diff hostedSpecDiff
+	spec := cluster.EKSStatus.UpstreamSpec
+
+	if module.Mode == framework.HostedMode {
+		diff.compare("privateAccess", value(spec.PrivateAccess), terraformConfig.AWSConfig.PrivateAccess)
+		diff.compare("publicAccess", value(spec.PublicAccess), terraformConfig.AWSConfig.PublicAccess)
+	}
+
+	for count, pool := range terratestConfig.Nodepools {
+		name := hosted.NodePoolName(terraformConfig, pool, count)
+
+		index := slices.IndexFunc(*spec.NodeGroups, func(nodeGroup management.NodeGroup) bool { return value(nodeGroup.NodegroupName) == name })
+		if index < 0 {
+			diff = append(diff, "node group "+name+" is missing")
+			continue
+		}
+
+		nodeGroup := (*spec.NodeGroups)[index]
+		path := func(field string) string { return "node group " + name + " " + field }
+
+		diff.compare(path("desiredSize"), value(nodeGroup.DesiredSize), pool.DesiredSize)
+
+		if pool.MaxSize != 0 {
+			diff.compare(path("maxSize"), value(nodeGroup.MaxSize), pool.MaxSize)
+		}
+
+		if pool.MinSize != 0 {
+			diff.compare(path("minSize"), value(nodeGroup.MinSize), pool.MinSize)
+		}
+
+		// The instance type and disk size of node groups with a launch template are the ones of the launch template.
+		if pool.LaunchTemplate == nil {
+			if pool.InstanceType != "" && !pool.Spot {
+				diff.compare(path("instanceType"), value(nodeGroup.InstanceType), pool.InstanceType)
+			}
+
+			if pool.DiskSize != 0 {
+				diff.compare(path("diskSize"), value(nodeGroup.DiskSize), pool.DiskSize)
+			}
+		}
+
+		diff.labels(path("labels"), value(nodeGroup.Labels), pool.Labels)
+
+		if pool.Spot {
+			diff.compare(path("requestSpotInstances"), value(nodeGroup.RequestSpotInstances), true)
+			diff.missing(path("spotInstanceTypes"), value(nodeGroup.SpotInstanceTypes), []string{pool.InstanceType})
+		}
+
+		if pool.LaunchTemplate != nil {
+			if nodeGroup.LaunchTemplate == nil {
+				diff = append(diff, path("launchTemplate is missing"))
+			} else {
+				diff.compare(path("launchTemplate.id"), value(nodeGroup.LaunchTemplate.ID), pool.LaunchTemplate.ID)
+				diff.compare(path("launchTemplate.version"), value(nodeGroup.LaunchTemplate.Version), pool.LaunchTemplate.Version)
+			}
+		}
+	}
+
+	return diff
+}
+
+// aksSpecDiff is a helper function that will compare the upstream spec of an AKS cluster against the config.
+func aksSpecDiff(cluster *management.Cluster, module framework.Module, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig) hostedSpecDiff {
+	if cluster.AKSStatus == nil || cluster.AKSStatus.UpstreamSpec == nil || cluster.AKSStatus.UpstreamSpec.NodePools == nil {
+		return hostedSpecDiff{"the upstream spec has no node pools yet"}
+	}
+
+	var diff hostedSpecDiff
+	spec := cluster.AKSStatus.UpstreamSpec
+
+	if module.Mode == framework.HostedMode {
+		diff.compare("privateCluster", value(spec.PrivateCluster), terraformConfig.AzureConfig.PrivateCluster)
+	}
+
+	for count, pool := range terratestConfig.Nodepools {
+		name := hosted.NodePoolName(terraformConfig, pool, count)
+
+		index := slices.IndexFunc(*spec.NodePools, func(nodePool management.AKSNodePool) bool { return value(nodePool.Name) == name })
+		if index < 0 {
+			diff = append(diff, "node pool "+name+" is missing")
+			continue
+		}
+
+		nodePool := (*spec.NodePools)[index]
+		path := func(field string) string { return "node pool " + name + " " + field }
+
+		// The node count of autoscaled node pools moves between their minimum and maximum count.
+		if pool.Autoscaling {
+			diff.compare(path("enableAutoScaling"), value(nodePool.EnableAutoScaling), true)
+			diff.compare(path("minCount"), value(nodePool.MinCount), pool.MinSize)
+			diff.compare(path("maxCount"), value(nodePool.MaxCount), pool.MaxSize)
+		} else {
+			diff.compare(path("count"), value(nodePool.Count), pool.Quantity)
+		}
+
+		if mode := hosted.AKSNodePoolMode(terraformConfig, pool); mode != "" {
+			diff.compare(path("mode"), nodePool.Mode, mode)
+		}
+
+		if terraformConfig.AzureConfig.VMSize != "" {
+			diff.compare(path("vmSize"), nodePool.VMSize, terraformConfig.AzureConfig.VMSize)
+		}
+
+		if terraformConfig.AzureConfig.OSDiskSizeGB != 0 {
+			diff.compare(path("osDiskSizeGB"), value(nodePool.OsDiskSizeGB), terraformConfig.AzureConfig.OSDiskSizeGB)
+		}
+
+		diff.labels(path("nodeLabels"), nodePool.NodeLabels, pool.Labels)
+
+		var taints []string
+		for _, taint := range pool.Taints {
+			taints = append(taints, hosted.AKSTaint(taint))
+		}
+
+		if len(taints) == 0 && module.Mode == framework.HostedMode {
+			taints = terraformConfig.AzureConfig.Taints
+		}
+
+		diff.missing(path("nodeTaints"), nodePool.NodeTaints, taints)
+	}
+
+	return diff
+}
+
+// gkeSpecDiff is a helper function that will compare the upstream spec of a GKE cluster against the config.
+func gkeSpecDiff(cluster *management.Cluster, module framework.Module, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig) hostedSpecDiff {
+	if cluster.GKEStatus == nil || cluster.GKEStatus.UpstreamSpec == nil || cluster.GKEStatus.UpstreamSpec.NodePools == nil {
+		return hostedSpecDiff{"the upstream spec has no node pools yet"}
+	}
+
+	var diff hostedSpecDiff
+	spec := cluster.GKEStatus.UpstreamSpec
+	google := terraformConfig.GoogleConfig
+
+	if module.Mode == framework.HostedMode && (google.EnablePrivateNodes || google.EnablePrivateEndpoint) {
+		if spec.PrivateClusterConfig == nil {
+			diff = append(diff, "privateClusterConfig is missing")
+		} else {
+			diff.compare("privateClusterConfig.enablePrivateEndpoint", spec.PrivateClusterConfig.EnablePrivateEndpoint, google.EnablePrivateEndpoint)
+			diff.compare("privateClusterConfig.enablePrivateNodes", spec.PrivateClusterConfig.EnablePrivateNodes, google.EnablePrivateNodes)
+			diff.compare("privateClusterConfig.masterIpv4CidrBlock", spec.PrivateClusterConfig.MasterIpv4CidrBlock, google.MasterIPv4CIDRBlock)
+		}
+	}
+
+	for count, pool := range terratestConfig.Nodepools {
+		name := hosted.NodePoolName(terraformConfig, pool, count)
+
+		index := slices.IndexFunc(*spec.NodePools, func(nodePool management.GKENodePoolConfig) bool { return value(nodePool.Name) == name })
+		if index < 0 {
+			diff = append(diff, "node pool "+name+" is missing")
+			continue
+		}
+
+		nodePool := (*spec.NodePools)[index]
+		path := func(field string) string { return "node pool " + name + " " + field }
+
+		if pool.Autoscaling {
+			if nodePool.Autoscaling == nil {
+				diff = append(diff, path("autoscaling is missing"))
+			} else {
+				diff.compare(path("autoscaling.enabled"), nodePool.Autoscaling.Enabled, true)
+				diff.compare(path("autoscaling.minNodeCount"), nodePool.Autoscaling.MinNodeCount, pool.MinSize)
+				diff.compare(path("autoscaling.maxNodeCount"), nodePool.Autoscaling.MaxNodeCount, pool.MaxSize)
+			}
+		} else {
+			diff.compare(path("initialNodeCount"), value(nodePool.InitialNodeCount), pool.Quantity)
+		}
+
+		if pool.MaxPodsConstraint != 0 {
+			diff.compare(path("maxPodsConstraint"), value(nodePool.MaxPodsConstraint), pool.MaxPodsConstraint)
+		}
+
+		if len(pool.Labels) > 0 || len(pool.Taints) > 0 || pool.Spot {
+			if nodePool.Config == nil {
+				diff = append(diff, path("config is missing"))
+			} else {
+				diff.labels(path("config.labels"), nodePool.Config.Labels, pool.Labels)
+
+				if pool.Spot {
+					diff.compare(path("config.preemptible"), nodePool.Config.Preemptible, true)
+				}
+
+				var actualTaints, expectedTaints []string
+				for _, taint := range nodePool.Config.Taints {
+					actualTaints = append(actualTaints, taint.Key+"="+taint.Value+":"+taint.Effect)
+				}
+
+				for _, taint := range pool.Taints {
+					expectedTaints = append(expectedTaints, taint.Key+"="+taint.Value+":"+hosted.GKETaintEffect(taint.Effect))
+				}
+
+				diff.missing(path("config.taints"), actualTaints, expectedTaints)
+			}
+		}
+
+		if pool.Management != nil {
+			if nodePool.Management == nil {
+				diff = append(diff, path("management is missing"))
+			} else {
+				diff.compare(path("management.autoRepair"), nodePool.Management.AutoRepair, pool.Management.AutoRepair)
+				diff.compare(path("management.autoUpgrade"), nodePool.Management.AutoUpgrade, pool.Management.AutoUpgrade)
+			}
+		}
+	}
+
+	return diff
+}
+
+// value is a helper function that will return the value of an upstream spec field, or its zero value when it is not set.
+func value[T any](pointer *T) T {
+	var zero T
+	if pointer == nil {
+		return zero
+	}
+
+	return *pointer
+}
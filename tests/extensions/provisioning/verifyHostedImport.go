@@ -0,0 +1,153 @@
+package provisioning
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	management "github.com/rancher/shepherd/clients/rancher/generated/management/v3"
+	timeouts "github.com/rancher/shepherd/extensions/defaults"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/providers"
+	framework "github.com/rancher/tfp-automation/framework/set"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+const hostedPollInterval = 30 * time.Second
+
+// VerifyHostedImport validates that Rancher adopted the node pools of an imported EKS, AKS or GKE cluster instead of recreating them.
+// Every configured node pool must be reported in the upstream spec of the cluster with its configured size, and every node of the
+// cluster must have been created before importStart.
+func VerifyHostedImport(t *testing.T, client *rancher.Client, clusterID, moduleName string, nodepools []config.Nodepool, importStart time.Time) {
+	module, err := framework.LookupModule(moduleName)
+	require.NoError(t, err)
+
+	cluster, err := client.Management.Cluster.ByID(clusterID)
+	require.NoError(t, err)
+
+	upstreamPools, err := upstreamNodePools(cluster)
+	require.NoError(t, err)
+
+	var expectedNodes int64
+	for _, pool := range nodepools {
+		size, ok := upstreamPools[pool.Name]
+		if assert.Truef(t, ok, "node pool %s was not adopted by cluster %s", pool.Name, cluster.Name) {
+			assert.Equalf(t, hostedPoolSize(module, pool), size, "size of node pool %s after the import", pool.Name)
+		}
+
+		expectedNodes += hostedPoolSize(module, pool)
+	}
+
+	nodes, err := downstreamNodes(client, clusterID)
+	require.NoError(t, err)
+	require.Lenf(t, nodes, int(expectedNodes), "cluster %s has %d nodes, its node pools have %d", cluster.Name, len(nodes), expectedNodes)
+
+	for _, node := range nodes {
+		assert.Truef(t, node.CreationTimestamp.Time.Before(importStart), "node %s was created at %s, after the import started",
+			node.Name, node.CreationTimestamp.Time.Format(time.RFC3339))
+	}
+
+	logrus.Infof("Cluster %s adopted %d node pools with %d existing nodes", cluster.Name, len(nodepools), len(nodes))
+}
+
+// verifyHostedNodepools is a helper function that will wait for the upstream spec of a hosted cluster to report the sizes of the
+// given node pools and for the cluster to have as many nodes as the node pools. When keptNodes is not empty, those nodes must still
+// be part of the cluster.
+func verifyHostedNodepools(t *testing.T, client *rancher.Client, clusterID string, module framework.Module, nodepools []config.Nodepool,
+	keptNodes []string) {
+	var expectedNodes int64
+	for _, pool := range nodepools {
+		expectedNodes += hostedPoolSize(module, pool)
+	}
+
+	var mismatch string
+	err := kwait.PollUntilContextTimeout(context.TODO(), hostedPollInterval, timeouts.ThirtyMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		cluster, err := client.Management.Cluster.ByID(clusterID)
+		if err != nil {
+			return false, nil
+		}
+
+		upstreamPools, err := upstreamNodePools(cluster)
+		if err != nil {
+			mismatch = err.Error()
+			return false, nil
+		}
+
+		for _, pool := range nodepools {
+			if size := upstreamPools[pool.Name]; size != hostedPoolSize(module, pool) {
+				mismatch = fmt.Sprintf("node pool %s has size %d upstream, expected %d", pool.Name, size, hostedPoolSize(module, pool))
+				return false, nil
+			}
+		}
+
+		nodes, err := downstreamNodes(client, clusterID)
+		if err != nil {
+			return false, nil
+		}
+
+		if int64(len(nodes)) != expectedNodes {
+			mismatch = fmt.Sprintf("cluster has %d nodes, expected %d", len(nodes), expectedNodes)
+			return false, nil
+		}
+
+		return true, nil
+	})
+	require.NoErrorf(t, err, "the node pool change did not round trip: %s", mismatch)
+
+	nodes, err := downstreamNodes(client, clusterID)
+	require.NoError(t, err)
+
+	nodeNames := map[string]bool{}
+	for _, node := range nodes {
+		nodeNames[node.Name] = true
+	}
+
+	for _, name := range keptNodes {
+		assert.Truef(t, nodeNames[name], "node %s was removed by the node pool change", name)
+	}
+}
+
+// upstreamNodePools is a helper function that will return the size of each node pool in the upstream spec of a hosted cluster.
+func upstreamNodePools(cluster *management.Cluster) (map[string]int64, error) {
+	pools := map[string]int64{}
+
+	switch {
+	case cluster.EKSStatus != nil && cluster.EKSStatus.UpstreamSpec != nil && cluster.EKSStatus.UpstreamSpec.NodeGroups != nil:
+		for _, nodeGroup := range *cluster.EKSStatus.UpstreamSpec.NodeGroups {
+			if nodeGroup.NodegroupName != nil && nodeGroup.DesiredSize != nil {
+				pools[*nodeGroup.NodegroupName] = *nodeGroup.DesiredSize
+			}
+		}
+	case cluster.AKSStatus != nil && cluster.AKSStatus.UpstreamSpec != nil && cluster.AKSStatus.UpstreamSpec.NodePools != nil:
+		for _, nodePool := range *cluster.AKSStatus.UpstreamSpec.NodePools {
+			if nodePool.Name != nil && nodePool.Count != nil {
+				pools[*nodePool.Name] = *nodePool.Count
+			}
+		}
+	case cluster.GKEStatus != nil && cluster.GKEStatus.UpstreamSpec != nil && cluster.GKEStatus.UpstreamSpec.NodePools != nil:
+		for _, nodePool := range *cluster.GKEStatus.UpstreamSpec.NodePools {
+			if nodePool.Name != nil && nodePool.InitialNodeCount != nil {
+				pools[*nodePool.Name] = *nodePool.InitialNodeCount
+			}
+		}
+	default:
+		return nil, fmt.Errorf("cluster %s has no upstream node pools", cluster.Name)
+	}
+
+	return pools, nil
+}
+
+// hostedPoolSize is a helper function that will return the configured size of a hosted node pool, which is the desired size of EKS
+// node groups and the quantity of AKS and GKE node pools.
+func hostedPoolSize(module framework.Module, pool config.Nodepool) int64 {
+	if module.Provider == providers.AWS {
+		return pool.DesiredSize
+	}
+
+	return pool.Quantity
+}
@@ -0,0 +1,226 @@
+package provisioning
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	steveV1 "github.com/rancher/shepherd/clients/rancher/v1"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/stevetypes"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	nodePollInterval = 2 * time.Second
+	drainTimeout     = 10 * time.Minute
+)
+
+// VerifyNodepoolSettings validates that the labels and taints of every node pool are set on the nodes of the downstream cluster.
+// The nodes of a pool are the nodes that have all of its labels and taints, so pools are expected to have distinct labels.
+func VerifyNodepoolSettings(t *testing.T, client *rancher.Client, clusterID string, nodepools []config.Nodepool) {
+	nodes, err := downstreamNodes(client, clusterID)
+	require.NoError(t, err)
+
+	for i, pool := range nodepools {
+		if len(pool.Labels) == 0 && len(pool.Taints) == 0 {
+			continue
+		}
+
+		matched := poolNodes(nodes, pool)
+
+		logrus.Infof("Found %v of %v nodes with the labels and taints of node pool %v", len(matched), pool.Quantity, i)
+		require.GreaterOrEqualf(t, int64(len(matched)), pool.Quantity, "node pool %v has %v nodes with its labels and taints, expected %v",
+			i, len(matched), pool.Quantity)
+	}
+}
+
+// VerifyPoolDrain validates that the nodes removed from a node pool by scaleDown, such as a terraform apply with a lower quantity,
+// were drained before they were deleted. The nodes of the pool are polled while scaleDown runs, and every node that is deleted must
+// have been cordoned first. The pool is identified by its labels, so it must have at least one label.
+func VerifyPoolDrain(t *testing.T, client *rancher.Client, clusterID string, pool config.Nodepool, scaleDown func()) {
+	require.NotEmpty(t, pool.Labels, "node pool must have labels to verify that it is drained")
+
+	nodes, err := downstreamNodes(client, clusterID)
+	require.NoError(t, err)
+
+	initialNodes := map[string]bool{}
+	for _, node := range poolNodes(nodes, pool) {
+		initialNodes[node.Name] = true
+	}
+
+	var mutex sync.Mutex
+	cordonedNodes := map[string]bool{}
+
+	sample := func(nodes []corev1.Node) map[string]bool {
+		currentNodes := map[string]bool{}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		for _, node := range poolNodes(nodes, pool) {
+			currentNodes[node.Name] = true
+
+			if node.Spec.Unschedulable {
+				cordonedNodes[node.Name] = true
+			}
+		}
+
+		return currentNodes
+	}
+
+	stop := pollNodes(client, clusterID, func(nodes []corev1.Node) { sample(nodes) })
+
+	scaleDown()
+
+	stop()
+
+	var removedNodes []string
+	deadline := time.Now().Add(drainTimeout)
+
+	for {
+		nodes, err := downstreamNodes(client, clusterID)
+		require.NoError(t, err)
+
+		currentNodes := sample(nodes)
+
+		removedNodes = nil
+		for name := range initialNodes {
+			if !currentNodes[name] {
+				removedNodes = append(removedNodes, name)
+			}
+		}
+
+		if int64(len(currentNodes)) <= pool.Quantity || time.Now().After(deadline) {
+			break
+		}
+
+		time.Sleep(nodePollInterval)
+	}
+
+	require.NotEmpty(t, removedNodes, "no node of the pool was removed")
+
+	for _, name := range removedNodes {
+		logrus.Infof("Verifying that node %s was drained before it was deleted...", name)
+		require.Truef(t, cordonedNodes[name], "node %s was deleted without being drained", name)
+	}
+}
+
+// pollNodes is a helper function that will call sample with the nodes of a downstream cluster every few seconds, until the returned
+// function is called. Failures to list the nodes are logged and retried, as the API may be unavailable while nodes are replaced.
+func pollNodes(client *rancher.Client, clusterID string, sample func(nodes []corev1.Node)) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(nodePollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				nodes, err := downstreamNodes(client, clusterID)
+				if err != nil {
+					logrus.Warnf("Failed to list the nodes of cluster %s: %v", clusterID, err)
+					continue
+				}
+
+				sample(nodes)
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// downstreamNodes is a helper function that will list the nodes of a downstream cluster through the steve proxy.
+func downstreamNodes(client *rancher.Client, clusterID string) ([]corev1.Node, error) {
+	steveClient, err := client.Steve.ProxyDownstream(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeList, err := steveClient.SteveType(stevetypes.Node).List(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []corev1.Node
+	for _, steveNode := range nodeList.Data {
+		node := corev1.Node{ObjectMeta: steveNode.ObjectMeta.ObjectMeta}
+
+		err = steveV1.ConvertToK8sType(steveNode.Spec, &node.Spec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert node %s: %w", steveNode.Name, err)
+		}
+
+		err = steveV1.ConvertToK8sType(steveNode.Status, &node.Status)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert node %s: %w", steveNode.Name, err)
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	return nodes, nil
+}
+
+// poolNodes is a helper function that will return the nodes that have all of the labels and taints of a node pool.
+func poolNodes(nodes []corev1.Node, pool config.Nodepool) []corev1.Node {
+	var matched []corev1.Node
+
+	for _, node := range nodes {
+		if hasLabels(node, pool.Labels) && hasTaints(node, pool.Taints) {
+			matched = append(matched, node)
+		}
+	}
+
+	return matched
+}
+
+// hasLabels is a helper function that will return whether a node has all of the labels.
+func hasLabels(node corev1.Node, labels map[string]string) bool {
+	for key, value := range labels {
+		if node.Labels[key] != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hasTaints is a helper function that will return whether a node has all of the taints. Taints without an effect default to
+// NoSchedule, the same as in the rancher2 provider.
+func hasTaints(node corev1.Node, taints []config.Taint) bool {
+	for _, taint := range taints {
+		effect := corev1.TaintEffect(taint.Effect)
+		if effect == "" {
+			effect = corev1.TaintEffectNoSchedule
+		}
+
+		found := false
+		for _, nodeTaint := range node.Spec.Taints {
+			if nodeTaint.Key == taint.Key && nodeTaint.Value == taint.Value && nodeTaint.Effect == effect {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
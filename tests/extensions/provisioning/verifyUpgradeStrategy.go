@@ -0,0 +1,179 @@
+package provisioning
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	controlPlaneRoleLabel = "node-role.kubernetes.io/control-plane"
+	etcdRoleLabel         = "node-role.kubernetes.io/etcd"
+	workerRoleLabel       = "node-role.kubernetes.io/worker"
+
+	defaultConcurrency = "1"
+)
+
+// upgradeSample is the most nodes of each tier of a cluster that were upgrading at once, the number of nodes that were upgraded and
+// the upgrade of each node.
+type upgradeSample struct {
+	controlPlanes    int
+	workers          int
+	maxControlPlanes int
+	maxWorkers       int
+	upgradedNodes    int
+	nodes            map[string]*nodeUpgrade
+}
+
+// nodeUpgrade is the kubelet version a node had before upgrade and whether the node is upgrading or was upgraded.
+type nodeUpgrade struct {
+	version   string
+	upgrading bool
+	upgraded  bool
+}
+
+// VerifyUpgradeStrategy validates that upgrade, such as a terraform apply with a new Kubernetes version, honours the upgrade
+// strategy. The nodes of every cluster are sampled while upgrade runs, and no more control plane or worker nodes than the configured
+// concurrency may be upgrading at once. Etcd nodes are part of the control plane tier. A node is upgrading from the first sample it
+// is cordoned, which happens when the drain options of its tier are enabled, or runs a new kubelet version, until it is Ready and
+// schedulable on the new version. At least one node of every cluster must be upgraded.
+func VerifyUpgradeStrategy(t *testing.T, client *rancher.Client, clusterIDs []string, strategy *config.UpgradeStrategy, upgrade func()) {
+	var mutex sync.Mutex
+	samples := map[string]*upgradeSample{}
+
+	var stops []func()
+	for _, clusterID := range clusterIDs {
+		sample := &upgradeSample{nodes: map[string]*nodeUpgrade{}}
+		samples[clusterID] = sample
+
+		nodes, err := downstreamNodes(client, clusterID)
+		require.NoError(t, err)
+
+		sampleUpgrade(clusterID, sample, nodes)
+
+		stops = append(stops, pollNodes(client, clusterID, func(nodes []corev1.Node) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			sampleUpgrade(clusterID, sample, nodes)
+		}))
+	}
+
+	upgrade()
+
+	for _, stop := range stops {
+		stop()
+	}
+
+	for _, clusterID := range clusterIDs {
+		sample := samples[clusterID]
+
+		controlPlaneConcurrency, err := concurrency(strategy.ControlPlaneConcurrency, sample.controlPlanes)
+		require.NoError(t, err)
+
+		workerConcurrency, err := concurrency(strategy.WorkerConcurrency, sample.workers)
+		require.NoError(t, err)
+
+		logrus.Infof("Cluster %s had at most %v of %v control plane nodes and %v of %v worker nodes upgrading at once", clusterID,
+			sample.maxControlPlanes, sample.controlPlanes, sample.maxWorkers, sample.workers)
+
+		require.Positivef(t, sample.upgradedNodes, "no node of cluster %s was seen upgrading", clusterID)
+		require.LessOrEqualf(t, sample.maxControlPlanes, controlPlaneConcurrency, "cluster %s upgraded more control plane nodes at once than allowed", clusterID)
+		require.LessOrEqualf(t, sample.maxWorkers, workerConcurrency, "cluster %s upgraded more worker nodes at once than allowed", clusterID)
+	}
+}
+
+// sampleUpgrade is a helper function that will update the upgrade of every node, count the upgrading nodes of each tier and log the
+// upgraded nodes. A node that started and finished its upgrade between two samples is counted as upgraded, but never as upgrading.
+func sampleUpgrade(clusterID string, sample *upgradeSample, nodes []corev1.Node) {
+	var controlPlanes, workers, upgradingControlPlanes, upgradingWorkers int
+
+	for _, node := range nodes {
+		isControlPlane := node.Labels[controlPlaneRoleLabel] == "true" || node.Labels[etcdRoleLabel] == "true"
+		isWorker := node.Labels[workerRoleLabel] == "true" && !isControlPlane
+
+		if isControlPlane {
+			controlPlanes++
+		}
+
+		if isWorker {
+			workers++
+		}
+
+		version := node.Status.NodeInfo.KubeletVersion
+
+		upgrade, ok := sample.nodes[node.Name]
+		if !ok {
+			upgrade = &nodeUpgrade{version: version}
+			sample.nodes[node.Name] = upgrade
+		}
+
+		if upgrade.upgraded {
+			continue
+		}
+
+		cordoned := node.Spec.Unschedulable
+		changed := version != upgrade.version
+
+		if changed && !cordoned && isNodeReady(node) {
+			logrus.Infof("Node %s of cluster %s was upgraded from %s to %s", node.Name, clusterID, upgrade.version, version)
+
+			upgrade.upgrading = false
+			upgrade.upgraded = true
+			sample.upgradedNodes++
+
+			continue
+		}
+
+		upgrade.upgrading = upgrade.upgrading || cordoned || changed
+		if !upgrade.upgrading {
+			continue
+		}
+
+		switch {
+		case isControlPlane:
+			upgradingControlPlanes++
+		case isWorker:
+			upgradingWorkers++
+		}
+	}
+
+	sample.controlPlanes = max(sample.controlPlanes, controlPlanes)
+	sample.workers = max(sample.workers, workers)
+	sample.maxControlPlanes = max(sample.maxControlPlanes, upgradingControlPlanes)
+	sample.maxWorkers = max(sample.maxWorkers, upgradingWorkers)
+}
+
+// isNodeReady is a helper function that will return whether the Ready condition of the node is true.
+func isNodeReady(node corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+// concurrency is a helper function that will resolve a concurrency, which is a number or a percentage of the nodes of a tier, the
+// same way as Rancher. At least one node is upgraded at once.
+func concurrency(value string, nodes int) (int, error) {
+	if value == "" {
+		value = defaultConcurrency
+	}
+
+	scaled := intstr.Parse(value)
+
+	resolved, err := intstr.GetScaledValueFromIntOrPercent(&scaled, nodes, true)
+	if err != nil {
+		return 0, err
+	}
+
+	return max(resolved, 1), nil
+}
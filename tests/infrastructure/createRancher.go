@@ -23,6 +23,8 @@ import (
 // SetupAirgapRancher sets up an airgapped Rancher server and returns the client, configuration, and Terraform options.
 func SetupAirgapRancher(t *testing.T, session *session.Session, moduleKeyPath string) (*rancher.Client, string, string, *terraform.Options,
 	*terraform.Options, map[string]any) {
+	require.NoError(t, config.ComposeCattleConfig(t))
+
 	cattleConfig := shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
 	rancherConfig, terraformConfig, terratestConfig, _ := config.LoadTFPConfigs(cattleConfig)
 
@@ -44,6 +46,8 @@ func SetupAirgapRancher(t *testing.T, session *session.Session, moduleKeyPath st
 // SetupProxyRancher sets up a proxy Rancher server and returns the client, configuration, and Terraform options.
 func SetupProxyRancher(t *testing.T, session *session.Session, moduleKeyPath string) (*rancher.Client, string, string,
 	*terraform.Options, *terraform.Options, map[string]any) {
+	require.NoError(t, config.ComposeCattleConfig(t))
+
 	cattleConfig := shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
 	rancherConfig, terraformConfig, terratestConfig, standaloneConfig := config.LoadTFPConfigs(cattleConfig)
 
@@ -69,6 +73,8 @@ func SetupProxyRancher(t *testing.T, session *session.Session, moduleKeyPath str
 // SetupRancher sets up a Rancher server and returns the client, configuration, and Terraform options.
 func SetupRancher(t *testing.T, session *session.Session, moduleKeyPath string) (*rancher.Client, string, *terraform.Options,
 	*terraform.Options, map[string]any) {
+	require.NoError(t, config.ComposeCattleConfig(t))
+
 	cattleConfig := shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
 	rancherConfig, terraformConfig, terratestConfig, standaloneConfig := config.LoadTFPConfigs(cattleConfig)
 
@@ -93,6 +99,8 @@ func SetupRancher(t *testing.T, session *session.Session, moduleKeyPath string)
 
 // SetupRegistryRancher sets up a registry-enabled Rancher server and returns the client, configuration, and Terraform options.
 func SetupRegistryRancher(t *testing.T, session *session.Session, moduleKeyPath string) (*rancher.Client, string, string, string, *terraform.Options, *terraform.Options, map[string]any) {
+	require.NoError(t, config.ComposeCattleConfig(t))
+
 	cattleConfig := shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
 	rancherConfig, terraformConfig, terratestConfig, standaloneConfig := config.LoadTFPConfigs(cattleConfig)
 
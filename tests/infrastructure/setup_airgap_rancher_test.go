@@ -13,6 +13,7 @@ import (
 	"github.com/rancher/tfp-automation/framework"
 	resources "github.com/rancher/tfp-automation/framework/set/resources/airgap"
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/validate"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
@@ -28,7 +29,10 @@ type AirgapRancherTestSuite struct {
 }
 
 func (i *AirgapRancherTestSuite) TestCreateAirgapRancher() {
+	require.NoError(i.T(), config.ComposeCattleConfig(i.T()))
+
 	i.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	require.NoError(i.T(), validate.ValidateConfig(i.cattleConfig))
 	i.rancherConfig, i.terraformConfig, i.terratestConfig, _ = config.LoadTFPConfigs(i.cattleConfig)
 
 	_, keyPath := rancher2.SetKeyPath(keypath.AirgapKeyPath, i.terratestConfig.PathToRepo, i.terraformConfig.Provider)
@@ -9,6 +9,7 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/keypath"
 	"github.com/rancher/tfp-automation/framework"
+	"github.com/rancher/tfp-automation/framework/plan"
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/rancher/tfp-automation/framework/set/resources/upgrade"
 	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
@@ -30,6 +31,11 @@ func UpgradeAirgapRancher(t *testing.T, client *rancher.Client, bastion, registr
 	err = upgrade.CreateMainTF(t, upgradeTerraformOptions, keyPath, rancherConfig, terraformConfig, terratestConfig, "", "", bastion, registry)
 	require.NoError(t, err)
 
+	if terratestConfig.IdempotencyCheck {
+		err = plan.CheckIdempotency(t, upgradeTerraformOptions)
+		require.NoError(t, err)
+	}
+
 	standaloneTerraformOptions := framework.Setup(t, terraformConfig, terratestConfig, keypath.AirgapKeyPath)
 	client, err = PostRancherSetup(t, standaloneTerraformOptions, rancherConfig, session, terraformConfig.Standalone.RancherHostname, keyPath, false, true)
 	require.NoError(t, err)
@@ -65,6 +71,11 @@ func UpgradeProxyRancher(t *testing.T, client *rancher.Client, proxyPrivateIP, p
 	err = upgrade.CreateMainTF(t, upgradeTerraformOptions, keyPath, rancherConfig, terraformConfig, terratestConfig, proxyPrivateIP, proxyBastion, "", "")
 	require.NoError(t, err)
 
+	if terratestConfig.IdempotencyCheck {
+		err = plan.CheckIdempotency(t, upgradeTerraformOptions)
+		require.NoError(t, err)
+	}
+
 	standaloneTerraformOptions := framework.Setup(t, terraformConfig, terratestConfig, keypath.ProxyKeyPath)
 	client, err = PostRancherSetup(t, standaloneTerraformOptions, rancherConfig, session, terraformConfig.Standalone.RancherHostname, keyPath, false, true)
 	require.NoError(t, err)
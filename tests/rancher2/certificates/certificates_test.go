@@ -0,0 +1,123 @@
+//go:build validation || recurring
+
+package certificates
+
+import (
+	"os"
+	"testing"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	shepherdConfig "github.com/rancher/shepherd/pkg/config"
+	"github.com/rancher/shepherd/pkg/config/operations"
+	"github.com/rancher/shepherd/pkg/session"
+	"github.com/rancher/tests/validation/provisioning/resources/standarduser"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/certificates"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework"
+	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/validate"
+	qase "github.com/rancher/tfp-automation/pipeline/qase/results"
+	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type CertificateRotationTestSuite struct {
+	suite.Suite
+	client             *rancher.Client
+	standardUserClient *rancher.Client
+	session            *session.Session
+	cattleConfig       map[string]any
+	rancherConfig      *rancher.Config
+	terraformConfig    *config.TerraformConfig
+	terratestConfig    *config.TerratestConfig
+}
+
+func (c *CertificateRotationTestSuite) SetupSuite() {
+	require.NoError(c.T(), config.ComposeCattleConfig(c.T()))
+
+	testSession := session.NewSession()
+	c.session = testSession
+
+	client, err := rancher.NewClient("", testSession)
+	require.NoError(c.T(), err)
+
+	c.client = client
+
+	c.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	require.NoError(c.T(), validate.ValidateConfig(c.cattleConfig))
+	c.rancherConfig, c.terraformConfig, c.terratestConfig, _ = config.LoadTFPConfigs(c.cattleConfig)
+}
+
+func (c *CertificateRotationTestSuite) TestTfpRotateCertificates() {
+	var err error
+	var testUser, testPassword string
+
+	c.standardUserClient, testUser, testPassword, err = standarduser.CreateStandardUser(c.client)
+	require.NoError(c.T(), err)
+
+	nodeRolesDedicated := []config.Nodepool{config.EtcdNodePool, config.ControlPlaneNodePool, config.WorkerNodePool}
+
+	tests := []struct {
+		name      string
+		module    string
+		nodeRoles []config.Nodepool
+		services  []string
+	}{
+		{"RKE2_Rotate_Certificates", modules.EC2RKE2, nodeRolesDedicated, []string{certificates.APIServer, certificates.Kubelet}},
+		{"K3S_Rotate_Certificates", modules.EC2K3s, nodeRolesDedicated, []string{certificates.APIServer, certificates.Kubelet}},
+		{"RKE1_Rotate_Certificates", modules.EC2RKE1, nodeRolesDedicated, []string{certificates.KubeAPIServer, certificates.Etcd}},
+	}
+
+	for _, tt := range tests {
+		configMap, err := provisioning.UniquifyTerraform([]map[string]any{c.cattleConfig})
+		require.NoError(c.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terraform", "module"}, tt.module, configMap[0])
+		require.NoError(c.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terratest", "nodepools"}, tt.nodeRoles, configMap[0])
+		require.NoError(c.T(), err)
+
+		// The cluster is provisioned without rotating its certificates, the configured rotation is only applied afterwards.
+		_, err = operations.ReplaceValue([]string{"terratest", "rotateCertificates"}, nil, configMap[0])
+		require.NoError(c.T(), err)
+
+		provisioning.GetK8sVersion(c.T(), c.client, c.terratestConfig, c.terraformConfig, configs.DefaultK8sVersion, configMap)
+
+		rancher, terraform, terratest, _ := config.LoadTFPConfigs(configMap[0])
+
+		c.Run(tt.name, func() {
+			terraformOptions, keyPath := framework.SetupWorkspace(c.T(), c.terraformConfig, c.terratestConfig)
+			defer cleanup.Cleanup(c.T(), terraformOptions, keyPath)
+
+			newFile, rootBody, file := rancher2.InitializeWorkspaceMainTF(keyPath)
+			defer file.Close()
+
+			adminClient, err := provisioning.FetchAdminClient(c.T(), c.client)
+			require.NoError(c.T(), err)
+
+			clusterIDs, _ := provisioning.Provision(c.T(), c.client, c.standardUserClient, rancher, terraform, terratest, testUser, testPassword, terraformOptions, configMap, newFile, rootBody, file, false, false, false, nil)
+			provisioning.VerifyClustersState(c.T(), adminClient, clusterIDs)
+
+			if c.terratestConfig.RotateCertificates != nil {
+				_, err = operations.ReplaceValue([]string{"terratest", "rotateCertificates"}, c.terratestConfig.RotateCertificates, configMap[0])
+				require.NoError(c.T(), err)
+			}
+
+			provisioning.RotateCertificates(c.T(), adminClient, rancher, terraform, testUser, testPassword, terraformOptions, configMap, newFile, rootBody, file, nil)
+			provisioning.RotateCertificates(c.T(), adminClient, rancher, terraform, testUser, testPassword, terraformOptions, configMap, newFile, rootBody, file, tt.services)
+		})
+	}
+
+	if c.terratestConfig.LocalQaseReporting {
+		qase.ReportTest(c.terratestConfig)
+	}
+}
+
+func TestTfpCertificateRotationTestSuite(t *testing.T) {
+	suite.Run(t, new(CertificateRotationTestSuite))
+}
@@ -0,0 +1,196 @@
+//go:build validation
+
+package lifecycle
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/shepherd/pkg/clientbase"
+	shepherdConfig "github.com/rancher/shepherd/pkg/config"
+	namegen "github.com/rancher/shepherd/pkg/namegenerator"
+	"github.com/rancher/shepherd/pkg/session"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/validate"
+	qase "github.com/rancher/tfp-automation/pipeline/qase/results"
+	"github.com/rancher/tfp-automation/tests/extensions/lifecycle"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	description        = "tfp-automation lifecycle"
+	updatedDescription = "tfp-automation lifecycle updated"
+)
+
+type LifecycleTestSuite struct {
+	suite.Suite
+	client          *rancher.Client
+	session         *session.Session
+	cattleConfig    map[string]any
+	rancherConfig   *rancher.Config
+	terraformConfig *config.TerraformConfig
+	terratestConfig *config.TerratestConfig
+}
+
+func (l *LifecycleTestSuite) SetupSuite() {
+	require.NoError(l.T(), config.ComposeCattleConfig(l.T()))
+
+	testSession := session.NewSession()
+	l.session = testSession
+
+	client, err := rancher.NewClient("", testSession)
+	require.NoError(l.T(), err)
+
+	l.client = client
+
+	l.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	require.NoError(l.T(), validate.ValidateConfig(l.cattleConfig))
+	l.rancherConfig, l.terraformConfig, l.terratestConfig, _ = config.LoadTFPConfigs(l.cattleConfig)
+}
+
+func (l *LifecycleTestSuite) TestTfpResourceLifecycle() {
+	projectName := namegen.AppendRandomString("tfp-project")
+	globalRoleName := namegen.AppendRandomString("tfp-global-role")
+	roleTemplateName := namegen.AppendRandomString("tfp-role-template")
+
+	tests := []lifecycle.TestCase{
+		{
+			Name:    "Project",
+			Address: "rancher2_project.tfp",
+			Create:  project(projectName, description),
+			Update:  project(projectName, updatedDescription),
+			Verify: func(client *rancher.Client, id string, updated bool) error {
+				project, err := client.Management.Project.ByID(id)
+				if err != nil {
+					return err
+				}
+
+				return verifyDescription(project.Name, project.Description, projectName, updated)
+			},
+			VerifyDeleted: func(client *rancher.Client, id string) error {
+				return verifyDeleted(id, func() error {
+					_, err := client.Management.Project.ByID(id)
+					return err
+				})
+			},
+		},
+		{
+			Name:    "Global_Role",
+			Address: "rancher2_global_role.tfp",
+			Create:  globalRole(globalRoleName, description),
+			Update:  globalRole(globalRoleName, updatedDescription),
+			Verify: func(client *rancher.Client, id string, updated bool) error {
+				globalRole, err := client.Management.GlobalRole.ByID(id)
+				if err != nil {
+					return err
+				}
+
+				return verifyDescription(globalRole.Name, globalRole.Description, globalRoleName, updated)
+			},
+			VerifyDeleted: func(client *rancher.Client, id string) error {
+				return verifyDeleted(id, func() error {
+					_, err := client.Management.GlobalRole.ByID(id)
+					return err
+				})
+			},
+		},
+		{
+			Name:    "Role_Template",
+			Address: "rancher2_role_template.tfp",
+			Create:  roleTemplate(roleTemplateName, description),
+			Update:  roleTemplate(roleTemplateName, updatedDescription),
+			Verify: func(client *rancher.Client, id string, updated bool) error {
+				roleTemplate, err := client.Management.RoleTemplate.ByID(id)
+				if err != nil {
+					return err
+				}
+
+				return verifyDescription(roleTemplate.Name, roleTemplate.Description, roleTemplateName, updated)
+			},
+			VerifyDeleted: func(client *rancher.Client, id string) error {
+				return verifyDeleted(id, func() error {
+					_, err := client.Management.RoleTemplate.ByID(id)
+					return err
+				})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		l.Run(tt.Name, func() {
+			lifecycle.Run(l.T(), l.client, l.cattleConfig, tt)
+		})
+	}
+
+	if l.terratestConfig.LocalQaseReporting {
+		qase.ReportTest(l.terratestConfig)
+	}
+}
+
+func project(name, description string) string {
+	return fmt.Sprintf(`resource "rancher2_project" "tfp" {
+  name        = %q
+  cluster_id  = "local"
+  description = %q
+}`, name, description)
+}
+
+func globalRole(name, description string) string {
+	return fmt.Sprintf(`resource "rancher2_global_role" "tfp" {
+  name        = %q
+  description = %q
+
+  rules {
+    api_groups = [""]
+    resources  = ["secrets"]
+    verbs      = ["get", "list"]
+  }
+}`, name, description)
+}
+
+func roleTemplate(name, description string) string {
+	return fmt.Sprintf(`resource "rancher2_role_template" "tfp" {
+  name        = %q
+  context     = "cluster"
+  description = %q
+
+  rules {
+    api_groups = [""]
+    resources  = ["configmaps"]
+    verbs      = ["get", "list"]
+  }
+}`, name, description)
+}
+
+func verifyDescription(name, actualDescription, expectedName string, updated bool) error {
+	expectedDescription := description
+	if updated {
+		expectedDescription = updatedDescription
+	}
+
+	if name != expectedName || actualDescription != expectedDescription {
+		return fmt.Errorf("expected %s with description %q, got %s with description %q", expectedName, expectedDescription, name, actualDescription)
+	}
+
+	return nil
+}
+
+func verifyDeleted(id string, get func() error) error {
+	err := get()
+	if err == nil {
+		return fmt.Errorf("%s still exists", id)
+	}
+
+	if !clientbase.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+func TestLifecycleTestSuite(t *testing.T) {
+	suite.Run(t, new(LifecycleTestSuite))
+}
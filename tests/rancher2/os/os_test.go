@@ -5,7 +5,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/rancher/shepherd/clients/rancher"
 	"github.com/rancher/shepherd/extensions/cloudcredentials"
 	clusterExtensions "github.com/rancher/shepherd/extensions/clusters"
@@ -21,10 +20,10 @@ import (
 	"github.com/rancher/tests/actions/workloads/statefulset"
 	"github.com/rancher/tests/validation/provisioning/resources/standarduser"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/keypath"
 	"github.com/rancher/tfp-automation/framework"
 	cleanup "github.com/rancher/tfp-automation/framework/cleanup"
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/validate"
 	tfpQase "github.com/rancher/tfp-automation/pipeline/qase"
 	"github.com/rancher/tfp-automation/pipeline/qase/results"
 	"github.com/rancher/tfp-automation/tests/extensions/permutationsdata"
@@ -42,13 +41,14 @@ type OSValidationTestSuite struct {
 	rancherConfig      *rancher.Config
 	terraformConfig    *config.TerraformConfig
 	terratestConfig    *config.TerratestConfig
-	terraformOptions   *terraform.Options
 	cattleConfig       map[string]any
 	permutedConfigs    []map[string]any
 	awsCredentials     cloudcredentials.AmazonEC2CredentialConfig
 }
 
 func (o *OSValidationTestSuite) SetupSuite() {
+	require.NoError(o.T(), config.ComposeCattleConfig(o.T()))
+
 	testSession := session.NewSession()
 	o.session = testSession
 
@@ -58,6 +58,7 @@ func (o *OSValidationTestSuite) SetupSuite() {
 	o.client = client
 
 	o.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	require.NoError(o.T(), validate.ValidateConfig(o.cattleConfig))
 
 	o.cattleConfig, err = config.LoadPackageDefaults(o.cattleConfig, "")
 	require.NoError(o.T(), err)
@@ -82,17 +83,13 @@ func (o *OSValidationTestSuite) SetupSuite() {
 	o.permutedConfigs, err = provisioning.UniquifyTerraform(permutedConfigs)
 	require.NoError(o.T(), err)
 
-	_, terraformConfig, terratestConfig, _ := config.LoadTFPConfigs(o.permutedConfigs[0])
+	o.rancherConfig, o.terraformConfig, o.terratestConfig, _ = config.LoadTFPConfigs(o.permutedConfigs[0])
 
 	o.awsCredentials = cloudcredentials.AmazonEC2CredentialConfig{
-		AccessKey:     terraformConfig.AWSCredentials.AWSAccessKey,
-		SecretKey:     terraformConfig.AWSCredentials.AWSSecretKey,
-		DefaultRegion: terraformConfig.AWSConfig.Region,
+		AccessKey:     o.terraformConfig.AWSCredentials.AWSAccessKey,
+		SecretKey:     o.terraformConfig.AWSCredentials.AWSSecretKey,
+		DefaultRegion: o.terraformConfig.AWSConfig.Region,
 	}
-
-	_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, o.terratestConfig.PathToRepo, "")
-	terraformOptions := framework.Setup(o.T(), terraformConfig, terratestConfig, keyPath)
-	o.terraformOptions = terraformOptions
 }
 
 func (o *OSValidationTestSuite) TestDynamicOSValidation() {
@@ -106,14 +103,14 @@ func (o *OSValidationTestSuite) TestDynamicOSValidation() {
 		configBatches[terraformConfig.AWSConfig.AMI] = append(configBatches[terraformConfig.AWSConfig.AMI], cattleConfig)
 	}
 
-	newFile, rootBody, file := rancher2.InitializeMainTF(o.terratestConfig)
-	defer file.Close()
-
 	customClusterNames := []string{}
 
 	for ami, batch := range configBatches {
-		_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, o.terratestConfig.PathToRepo, "")
-		defer cleanup.Cleanup(o.T(), o.terraformOptions, keyPath)
+		terraformOptions, keyPath := framework.SetupWorkspace(o.T(), o.terraformConfig, o.terratestConfig)
+		defer cleanup.Cleanup(o.T(), terraformOptions, keyPath)
+
+		newFile, rootBody, file := rancher2.InitializeWorkspaceMainTF(keyPath)
+		defer file.Close()
 
 		o.standardUserClient, testUser, testPassword, err = standarduser.CreateStandardUser(o.client)
 		require.NoError(o.T(), err)
@@ -134,7 +131,7 @@ func (o *OSValidationTestSuite) TestDynamicOSValidation() {
 				logrus.Infof("Provisioning Cluster Type: %s, "+"K8s Version: %s, "+"CNI: %s", terraformConfig.Module, terratestConfig.KubernetesVersion, terraformConfig.CNI)
 			}
 
-			clusterIDs, _ = provisioning.Provision(o.T(), o.client, o.standardUserClient, o.rancherConfig, o.terraformConfig, o.terratestConfig, testUser, testPassword, o.terraformOptions, batch, newFile, rootBody, file, false, false, true, customClusterNames)
+			clusterIDs, _ = provisioning.Provision(o.T(), o.client, o.standardUserClient, o.rancherConfig, o.terraformConfig, o.terratestConfig, testUser, testPassword, terraformOptions, batch, newFile, rootBody, file, false, false, true, customClusterNames)
 			time.Sleep(2 * time.Minute)
 			provisioning.VerifyClustersState(o.T(), o.client, clusterIDs)
 		})
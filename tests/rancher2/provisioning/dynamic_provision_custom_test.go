@@ -7,7 +7,6 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/rancher/shepherd/clients/rancher"
 	shepherdConfig "github.com/rancher/shepherd/pkg/config"
 	"github.com/rancher/shepherd/pkg/session"
@@ -16,10 +15,10 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/clustertypes"
 	"github.com/rancher/tfp-automation/defaults/configs"
-	"github.com/rancher/tfp-automation/defaults/keypath"
 	"github.com/rancher/tfp-automation/framework"
 	"github.com/rancher/tfp-automation/framework/cleanup"
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/validate"
 	tfpQase "github.com/rancher/tfp-automation/pipeline/qase"
 	"github.com/rancher/tfp-automation/pipeline/qase/results"
 	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
@@ -37,10 +36,11 @@ type DynamicProvisionCustomTestSuite struct {
 	rancherConfig      *rancher.Config
 	terraformConfig    *config.TerraformConfig
 	terratestConfig    *config.TerratestConfig
-	terraformOptions   *terraform.Options
 }
 
 func (p *DynamicProvisionCustomTestSuite) SetupSuite() {
+	require.NoError(p.T(), config.ComposeCattleConfig(p.T()))
+
 	testSession := session.NewSession()
 	p.session = testSession
 
@@ -50,11 +50,8 @@ func (p *DynamicProvisionCustomTestSuite) SetupSuite() {
 	p.client = client
 
 	p.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	require.NoError(p.T(), validate.ValidateConfig(p.cattleConfig))
 	p.rancherConfig, p.terraformConfig, p.terratestConfig, _ = config.LoadTFPConfigs(p.cattleConfig)
-
-	_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, p.terratestConfig.PathToRepo, "")
-	terraformOptions := framework.Setup(p.T(), p.terraformConfig, p.terratestConfig, keyPath)
-	p.terraformOptions = terraformOptions
 }
 
 func (p *DynamicProvisionCustomTestSuite) TestTfpProvisionCustomDynamicInput() {
@@ -73,9 +70,6 @@ func (p *DynamicProvisionCustomTestSuite) TestTfpProvisionCustomDynamicInput() {
 	}
 
 	for _, tt := range tests {
-		newFile, rootBody, file := rancher2.InitializeMainTF(p.terratestConfig)
-		defer file.Close()
-
 		configMap, err := provisioning.UniquifyTerraform([]map[string]any{p.cattleConfig})
 		require.NoError(p.T(), err)
 
@@ -84,17 +78,20 @@ func (p *DynamicProvisionCustomTestSuite) TestTfpProvisionCustomDynamicInput() {
 		rancher, terraform, terratest, _ := config.LoadTFPConfigs(configMap[0])
 
 		p.Run((tt.name), func() {
-			_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, p.terratestConfig.PathToRepo, "")
-			defer cleanup.Cleanup(p.T(), p.terraformOptions, keyPath)
+			terraformOptions, keyPath := framework.SetupWorkspace(p.T(), p.terraformConfig, p.terratestConfig)
+			defer cleanup.Cleanup(p.T(), terraformOptions, keyPath)
+
+			newFile, rootBody, file := rancher2.InitializeWorkspaceMainTF(keyPath)
+			defer file.Close()
 
 			adminClient, err := provisioning.FetchAdminClient(p.T(), p.client)
 			require.NoError(p.T(), err)
 
-			clusterIDs, customClusterNames := provisioning.Provision(p.T(), p.client, p.standardUserClient, rancher, terraform, terratest, testUser, testPassword, p.terraformOptions, configMap, newFile, rootBody, file, false, false, true, customClusterNames)
+			clusterIDs, customClusterNames := provisioning.Provision(p.T(), p.client, p.standardUserClient, rancher, terraform, terratest, testUser, testPassword, terraformOptions, configMap, newFile, rootBody, file, false, false, true, customClusterNames)
 			provisioning.VerifyClustersState(p.T(), adminClient, clusterIDs)
 
 			if strings.Contains(p.terraformConfig.Module, clustertypes.WINDOWS) {
-				clusterIDs, _ = provisioning.Provision(p.T(), p.client, p.standardUserClient, rancher, terraform, terratest, testUser, testPassword, p.terraformOptions, configMap, newFile, rootBody, file, true, true, true, customClusterNames)
+				clusterIDs, _ = provisioning.Provision(p.T(), p.client, p.standardUserClient, rancher, terraform, terratest, testUser, testPassword, terraformOptions, configMap, newFile, rootBody, file, true, true, true, customClusterNames)
 				provisioning.VerifyClustersState(p.T(), adminClient, clusterIDs)
 			}
 		})
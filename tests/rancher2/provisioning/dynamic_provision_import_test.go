@@ -6,7 +6,6 @@ import (
 	"os"
 	"testing"
 
-	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/rancher/shepherd/clients/rancher"
 	shepherdConfig "github.com/rancher/shepherd/pkg/config"
 	"github.com/rancher/shepherd/pkg/session"
@@ -14,11 +13,11 @@ import (
 	"github.com/rancher/tests/validation/provisioning/resources/standarduser"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/configs"
-	"github.com/rancher/tfp-automation/defaults/keypath"
 	"github.com/rancher/tfp-automation/framework"
 	"github.com/rancher/tfp-automation/framework/cleanup"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/imported"
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/validate"
 	tfpQase "github.com/rancher/tfp-automation/pipeline/qase"
 	"github.com/rancher/tfp-automation/pipeline/qase/results"
 	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
@@ -36,10 +35,11 @@ type DynamicUpgradeImportedClusterTestSuite struct {
 	rancherConfig      *rancher.Config
 	terraformConfig    *config.TerraformConfig
 	terratestConfig    *config.TerratestConfig
-	terraformOptions   *terraform.Options
 }
 
 func (p *DynamicUpgradeImportedClusterTestSuite) SetupSuite() {
+	require.NoError(p.T(), config.ComposeCattleConfig(p.T()))
+
 	testSession := session.NewSession()
 	p.session = testSession
 
@@ -49,11 +49,8 @@ func (p *DynamicUpgradeImportedClusterTestSuite) SetupSuite() {
 	p.client = client
 
 	p.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	require.NoError(p.T(), validate.ValidateConfig(p.cattleConfig))
 	p.rancherConfig, p.terraformConfig, p.terratestConfig, _ = config.LoadTFPConfigs(p.cattleConfig)
-
-	_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, p.terratestConfig.PathToRepo, "")
-	terraformOptions := framework.Setup(p.T(), p.terraformConfig, p.terratestConfig, keyPath)
-	p.terraformOptions = terraformOptions
 }
 
 func (p *DynamicUpgradeImportedClusterTestSuite) TestTfpUpgradeImportedClusterDynamicInput() {
@@ -70,9 +67,6 @@ func (p *DynamicUpgradeImportedClusterTestSuite) TestTfpUpgradeImportedClusterDy
 	}
 
 	for _, tt := range tests {
-		newFile, rootBody, file := rancher2.InitializeMainTF(p.terratestConfig)
-		defer file.Close()
-
 		configMap, err := provisioning.UniquifyTerraform([]map[string]any{p.cattleConfig})
 		require.NoError(p.T(), err)
 
@@ -81,13 +75,16 @@ func (p *DynamicUpgradeImportedClusterTestSuite) TestTfpUpgradeImportedClusterDy
 		rancher, terraform, terratest, _ := config.LoadTFPConfigs(configMap[0])
 
 		p.Run((tt.name), func() {
-			_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, p.terratestConfig.PathToRepo, "")
-			defer cleanup.Cleanup(p.T(), p.terraformOptions, keyPath)
+			terraformOptions, keyPath := framework.SetupWorkspace(p.T(), p.terraformConfig, p.terratestConfig)
+			defer cleanup.Cleanup(p.T(), terraformOptions, keyPath)
+
+			newFile, rootBody, file := rancher2.InitializeWorkspaceMainTF(keyPath)
+			defer file.Close()
 
 			adminClient, err := provisioning.FetchAdminClient(p.T(), p.client)
 			require.NoError(p.T(), err)
 
-			clusterIDs, _ := provisioning.Provision(p.T(), p.client, p.standardUserClient, rancher, terraform, terratest, testUser, testPassword, p.terraformOptions, configMap, newFile, rootBody, file, false, false, true, nil)
+			clusterIDs, _ := provisioning.Provision(p.T(), p.client, p.standardUserClient, rancher, terraform, terratest, testUser, testPassword, terraformOptions, configMap, newFile, rootBody, file, false, false, true, nil)
 			provisioning.VerifyClustersState(p.T(), adminClient, clusterIDs)
 
 			err = imported.SetUpgradeImportedCluster(adminClient, terraform)
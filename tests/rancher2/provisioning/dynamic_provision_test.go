@@ -6,7 +6,6 @@ import (
 	"os"
 	"testing"
 
-	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/rancher/shepherd/clients/rancher"
 	shepherdConfig "github.com/rancher/shepherd/pkg/config"
 	"github.com/rancher/shepherd/pkg/session"
@@ -14,10 +13,10 @@ import (
 	"github.com/rancher/tests/validation/provisioning/resources/standarduser"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/configs"
-	"github.com/rancher/tfp-automation/defaults/keypath"
 	"github.com/rancher/tfp-automation/framework"
 	cleanup "github.com/rancher/tfp-automation/framework/cleanup"
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/validate"
 	tfpQase "github.com/rancher/tfp-automation/pipeline/qase"
 	"github.com/rancher/tfp-automation/pipeline/qase/results"
 	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
@@ -35,10 +34,11 @@ type DynamicTfpProvisionTestSuite struct {
 	rancherConfig      *rancher.Config
 	terraformConfig    *config.TerraformConfig
 	terratestConfig    *config.TerratestConfig
-	terraformOptions   *terraform.Options
 }
 
 func (p *DynamicTfpProvisionTestSuite) SetupSuite() {
+	require.NoError(p.T(), config.ComposeCattleConfig(p.T()))
+
 	testSession := session.NewSession()
 	p.session = testSession
 
@@ -48,11 +48,8 @@ func (p *DynamicTfpProvisionTestSuite) SetupSuite() {
 	p.client = client
 
 	p.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	require.NoError(p.T(), validate.ValidateConfig(p.cattleConfig))
 	p.rancherConfig, p.terraformConfig, p.terratestConfig, _ = config.LoadTFPConfigs(p.cattleConfig)
-
-	_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, p.terratestConfig.PathToRepo, "")
-	terraformOptions := framework.Setup(p.T(), p.terraformConfig, p.terratestConfig, keyPath)
-	p.terraformOptions = terraformOptions
 }
 
 func (p *DynamicTfpProvisionTestSuite) TestTfpProvisionDynamicInput() {
@@ -69,9 +66,6 @@ func (p *DynamicTfpProvisionTestSuite) TestTfpProvisionDynamicInput() {
 	}
 
 	for _, tt := range tests {
-		newFile, rootBody, file := rancher2.InitializeMainTF(p.terratestConfig)
-		defer file.Close()
-
 		configMap, err := provisioning.UniquifyTerraform([]map[string]any{p.cattleConfig})
 		require.NoError(p.T(), err)
 
@@ -82,13 +76,16 @@ func (p *DynamicTfpProvisionTestSuite) TestTfpProvisionDynamicInput() {
 		tt.name = tt.name + " Module: " + p.terraformConfig.Module + " Kubernetes version: " + terratest.KubernetesVersion
 
 		p.Run((tt.name), func() {
-			_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, p.terratestConfig.PathToRepo, "")
-			defer cleanup.Cleanup(p.T(), p.terraformOptions, keyPath)
+			terraformOptions, keyPath := framework.SetupWorkspace(p.T(), p.terraformConfig, p.terratestConfig)
+			defer cleanup.Cleanup(p.T(), terraformOptions, keyPath)
+
+			newFile, rootBody, file := rancher2.InitializeWorkspaceMainTF(keyPath)
+			defer file.Close()
 
 			adminClient, err := provisioning.FetchAdminClient(p.T(), p.client)
 			require.NoError(p.T(), err)
 
-			clusterIDs, _ := provisioning.Provision(p.T(), p.client, p.standardUserClient, rancher, terraform, terratest, testUser, testPassword, p.terraformOptions, configMap, newFile, rootBody, file, false, false, false, nil)
+			clusterIDs, _ := provisioning.Provision(p.T(), p.client, p.standardUserClient, rancher, terraform, terratest, testUser, testPassword, terraformOptions, configMap, newFile, rootBody, file, false, false, false, nil)
 			provisioning.VerifyClustersState(p.T(), adminClient, clusterIDs)
 		})
 
@@ -0,0 +1,122 @@
+//go:build validation || recurring
+
+package provisioning
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	shepherdConfig "github.com/rancher/shepherd/pkg/config"
+	"github.com/rancher/shepherd/pkg/session"
+	"github.com/rancher/tests/actions/qase"
+	"github.com/rancher/tests/validation/provisioning/resources/standarduser"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework"
+	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/validate"
+	tfpQase "github.com/rancher/tfp-automation/pipeline/qase"
+	"github.com/rancher/tfp-automation/pipeline/qase/results"
+	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProvisionHostedImportTestSuite struct {
+	suite.Suite
+	client             *rancher.Client
+	standardUserClient *rancher.Client
+	session            *session.Session
+	cattleConfig       map[string]any
+	rancherConfig      *rancher.Config
+	terraformConfig    *config.TerraformConfig
+	terratestConfig    *config.TerratestConfig
+}
+
+func (p *ProvisionHostedImportTestSuite) SetupSuite() {
+	require.NoError(p.T(), config.ComposeCattleConfig(p.T()))
+
+	testSession := session.NewSession()
+	p.session = testSession
+
+	client, err := rancher.NewClient("", testSession)
+	require.NoError(p.T(), err)
+
+	p.client = client
+
+	p.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	require.NoError(p.T(), validate.ValidateConfig(p.cattleConfig))
+	p.rancherConfig, p.terraformConfig, p.terratestConfig, _ = config.LoadTFPConfigs(p.cattleConfig)
+}
+
+func (p *ProvisionHostedImportTestSuite) TestTfpProvisionHostedImport() {
+	var err error
+	var testUser, testPassword string
+
+	p.standardUserClient, testUser, testPassword, err = standarduser.CreateStandardUser(p.client)
+	require.NoError(p.T(), err)
+
+	// Each import module registers the existing cluster named by terraform.hostedClusterName, so only the configured module is run.
+	testNames := map[string]string{
+		modules.AKSImport: "Import_AKS_Cluster",
+		modules.EKSImport: "Import_EKS_Cluster",
+		modules.GKEImport: "Import_GKE_Cluster",
+	}
+
+	testName, ok := testNames[p.terraformConfig.Module]
+	require.Truef(p.T(), ok, "module %s is not a hosted import module", p.terraformConfig.Module)
+
+	configMap, err := provisioning.UniquifyTerraform([]map[string]any{p.cattleConfig})
+	require.NoError(p.T(), err)
+
+	rancher, terraform, terratest, _ := config.LoadTFPConfigs(configMap[0])
+	require.NotEmpty(p.T(), terratest.Nodepools, "the existing node pools of the cluster must be set in terratest.nodepools")
+
+	p.Run(testName, func() {
+		terraformOptions, keyPath := framework.SetupWorkspace(p.T(), p.terraformConfig, p.terratestConfig)
+		defer cleanup.Cleanup(p.T(), terraformOptions, keyPath)
+
+		newFile, rootBody, file := rancher2.InitializeWorkspaceMainTF(keyPath)
+		defer file.Close()
+
+		adminClient, err := provisioning.FetchAdminClient(p.T(), p.client)
+		require.NoError(p.T(), err)
+
+		importStart := time.Now()
+
+		clusterIDs, _ := provisioning.Provision(p.T(), p.client, p.standardUserClient, rancher, terraform, terratest, testUser, testPassword, terraformOptions, configMap, newFile, rootBody, file, false, false, false, nil)
+		provisioning.VerifyClustersState(p.T(), adminClient, clusterIDs)
+		provisioning.VerifyHostedImport(p.T(), adminClient, clusterIDs[0], terraform.Module, terratest.Nodepools, importStart)
+		provisioning.VerifyHostedCluster(p.T(), adminClient, clusterIDs[0], terraform, terratest)
+
+		// The first node pool is grown by one node and then set back, so the existing cluster is left as it was found.
+		scaledNodepools := append([]config.Nodepool(nil), terratest.Nodepools...)
+		scaledNodepools[0].Quantity++
+		scaledNodepools[0].DesiredSize++
+
+		if scaledNodepools[0].MaxSize != 0 && scaledNodepools[0].MaxSize < scaledNodepools[0].DesiredSize {
+			scaledNodepools[0].MaxSize = scaledNodepools[0].DesiredSize
+		}
+
+		provisioning.UpdateHostedNodepools(p.T(), adminClient, rancher, terraform, terratest, testUser, testPassword, terraformOptions, configMap, newFile, rootBody, file, scaledNodepools)
+		provisioning.UpdateHostedNodepools(p.T(), adminClient, rancher, terraform, terratest, testUser, testPassword, terraformOptions, configMap, newFile, rootBody, file, terratest.Nodepools)
+	})
+
+	params := tfpQase.GetProvisioningSchemaParams(configMap[0])
+	err = qase.UpdateSchemaParameters(testName, params)
+	if err != nil {
+		logrus.Warningf("Failed to upload schema parameters %s", err)
+	}
+
+	if p.terratestConfig.LocalQaseReporting {
+		results.ReportTest(p.terratestConfig)
+	}
+}
+
+func TestTfpProvisionHostedImportTestSuite(t *testing.T) {
+	suite.Run(t, new(ProvisionHostedImportTestSuite))
+}
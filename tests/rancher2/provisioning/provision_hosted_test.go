@@ -6,7 +6,6 @@ import (
 	"os"
 	"testing"
 
-	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/rancher/shepherd/clients/rancher"
 	shepherdConfig "github.com/rancher/shepherd/pkg/config"
 	"github.com/rancher/shepherd/pkg/config/operations"
@@ -14,11 +13,11 @@ import (
 	"github.com/rancher/tests/actions/qase"
 	"github.com/rancher/tests/validation/provisioning/resources/standarduser"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/keypath"
 	"github.com/rancher/tfp-automation/defaults/modules"
 	"github.com/rancher/tfp-automation/framework"
 	"github.com/rancher/tfp-automation/framework/cleanup"
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/validate"
 	tfpQase "github.com/rancher/tfp-automation/pipeline/qase"
 	"github.com/rancher/tfp-automation/pipeline/qase/results"
 	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
@@ -36,10 +35,11 @@ type ProvisionHostedTestSuite struct {
 	rancherConfig      *rancher.Config
 	terraformConfig    *config.TerraformConfig
 	terratestConfig    *config.TerratestConfig
-	terraformOptions   *terraform.Options
 }
 
 func (p *ProvisionHostedTestSuite) SetupSuite() {
+	require.NoError(p.T(), config.ComposeCattleConfig(p.T()))
+
 	testSession := session.NewSession()
 	p.session = testSession
 
@@ -49,62 +49,47 @@ func (p *ProvisionHostedTestSuite) SetupSuite() {
 	p.client = client
 
 	p.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	require.NoError(p.T(), validate.ValidateConfig(p.cattleConfig))
 	p.rancherConfig, p.terraformConfig, p.terratestConfig, _ = config.LoadTFPConfigs(p.cattleConfig)
-
-	_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, p.terratestConfig.PathToRepo, "")
-	terraformOptions := framework.Setup(p.T(), p.terraformConfig, p.terratestConfig, keyPath)
-	p.terraformOptions = terraformOptions
 }
 
 func (p *ProvisionHostedTestSuite) TestTfpProvisionHosted() {
+	p.provisionHosted(p.hostedClusters())
+}
+
+func (p *ProvisionHostedTestSuite) TestTfpProvisionHostedNodepools() {
+	p.provisionHosted(p.hostedNodepoolClusters())
+}
+
+// provisionHosted provisions each hosted cluster in its own workspace and verifies its upstream spec against the config.
+func (p *ProvisionHostedTestSuite) provisionHosted(hostedClusters []hostedCluster) {
 	var err error
 	var testUser, testPassword string
 
 	p.standardUserClient, testUser, testPassword, err = standarduser.CreateStandardUser(p.client)
 	require.NoError(p.T(), err)
 
-	aksNodePools := []config.Nodepool{{Quantity: 3}}
-	eksNodePools := []config.Nodepool{{DiskSize: 100, InstanceType: p.terraformConfig.AWSConfig.AWSInstanceType, DesiredSize: 3, MaxSize: 3, MinSize: 3}}
-	gkeNodePools := []config.Nodepool{{Quantity: 3, MaxPodsConstraint: 110}}
-
-	tests := []struct {
-		name              string
-		module            string
-		nodePools         []config.Nodepool
-		kubernetesVersion string
-	}{
-		{"Provision_AKS_Cluster", modules.AKS, aksNodePools, p.terratestConfig.AKSKubernetesVersion},
-		{"Provision_EKS_Cluster", modules.EKS, eksNodePools, p.terratestConfig.EKSKubernetesVersion},
-		{"Provision_GKE_Cluster", modules.GKE, gkeNodePools, p.terratestConfig.GKEKubernetesVersion},
-	}
-
-	for _, tt := range tests {
-		newFile, rootBody, file := rancher2.InitializeMainTF(p.terratestConfig)
-		defer file.Close()
-
+	for _, tt := range hostedClusters {
 		configMap, err := provisioning.UniquifyTerraform([]map[string]any{p.cattleConfig})
 		require.NoError(p.T(), err)
 
-		_, err = operations.ReplaceValue([]string{"terraform", "module"}, tt.module, configMap[0])
-		require.NoError(p.T(), err)
-
-		_, err = operations.ReplaceValue([]string{"terratest", "nodepools"}, tt.nodePools, configMap[0])
-		require.NoError(p.T(), err)
-
-		_, err = operations.ReplaceValue([]string{"terratest", "kubernetesVersion"}, tt.kubernetesVersion, configMap[0])
-		require.NoError(p.T(), err)
+		p.setHostedCluster(tt, configMap[0])
 
 		rancher, terraform, terratest, _ := config.LoadTFPConfigs(configMap[0])
 
 		p.Run((tt.name), func() {
-			_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, p.terratestConfig.PathToRepo, "")
-			defer cleanup.Cleanup(p.T(), p.terraformOptions, keyPath)
+			terraformOptions, keyPath := framework.SetupWorkspace(p.T(), p.terraformConfig, p.terratestConfig)
+			defer cleanup.Cleanup(p.T(), terraformOptions, keyPath)
+
+			newFile, rootBody, file := rancher2.InitializeWorkspaceMainTF(keyPath)
+			defer file.Close()
 
 			adminClient, err := provisioning.FetchAdminClient(p.T(), p.client)
 			require.NoError(p.T(), err)
 
-			clusterIDs, _ := provisioning.Provision(p.T(), p.client, p.standardUserClient, rancher, terraform, terratest, testUser, testPassword, p.terraformOptions, configMap, newFile, rootBody, file, false, false, false, nil)
+			clusterIDs, _ := provisioning.Provision(p.T(), p.client, p.standardUserClient, rancher, terraform, terratest, testUser, testPassword, terraformOptions, configMap, newFile, rootBody, file, false, false, false, nil)
 			provisioning.VerifyClustersState(p.T(), adminClient, clusterIDs)
+			provisioning.VerifyHostedCluster(p.T(), adminClient, clusterIDs[0], terraform, terratest)
 		})
 
 		params := tfpQase.GetProvisioningSchemaParams(configMap[0])
@@ -119,6 +104,120 @@ func (p *ProvisionHostedTestSuite) TestTfpProvisionHosted() {
 	}
 }
 
+func (p *ProvisionHostedTestSuite) TestTfpProvisionHostedMatrix() {
+	var err error
+	var testUser, testPassword string
+
+	p.standardUserClient, testUser, testPassword, err = standarduser.CreateStandardUser(p.client)
+	require.NoError(p.T(), err)
+
+	hostedClusters := p.hostedClusters()
+
+	cattleConfigs := make([]map[string]any, len(hostedClusters))
+	for i := range cattleConfigs {
+		cattleConfigs[i] = p.cattleConfig
+	}
+
+	configMap, err := provisioning.UniquifyTerraform(cattleConfigs)
+	require.NoError(p.T(), err)
+
+	for i, tt := range hostedClusters {
+		p.setHostedCluster(tt, configMap[i])
+	}
+
+	rancher, terraform, terratest, _ := config.LoadTFPConfigs(configMap[0])
+
+	testName := "Provision_Hosted_Matrix"
+	p.Run(testName, func() {
+		terraformOptions, keyPath := framework.SetupWorkspace(p.T(), p.terraformConfig, p.terratestConfig)
+		defer cleanup.Cleanup(p.T(), terraformOptions, keyPath)
+
+		newFile, rootBody, file := rancher2.InitializeWorkspaceMainTF(keyPath)
+		defer file.Close()
+
+		adminClient, err := provisioning.FetchAdminClient(p.T(), p.client)
+		require.NoError(p.T(), err)
+
+		clusterIDs, _ := provisioning.Provision(p.T(), p.client, p.standardUserClient, rancher, terraform, terratest, testUser, testPassword, terraformOptions, configMap, newFile, rootBody, file, false, false, false, nil)
+		require.Len(p.T(), clusterIDs, len(hostedClusters))
+
+		provisioning.VerifyClustersState(p.T(), adminClient, clusterIDs)
+	})
+
+	params := tfpQase.GetProvisioningSchemaParams(configMap[0])
+	err = qase.UpdateSchemaParameters(testName, params)
+	if err != nil {
+		logrus.Warningf("Failed to upload schema parameters %s", err)
+	}
+
+	if p.terratestConfig.LocalQaseReporting {
+		results.ReportTest(p.terratestConfig)
+	}
+}
+
+// hostedCluster is a hosted cluster provisioned by the hosted test suite.
+type hostedCluster struct {
+	name              string
+	module            string
+	nodePools         []config.Nodepool
+	kubernetesVersion string
+}
+
+// hostedClusters returns the AKS, EKS and GKE clusters provisioned by the hosted test suite.
+func (p *ProvisionHostedTestSuite) hostedClusters() []hostedCluster {
+	aksNodePools := []config.Nodepool{{Quantity: 3}}
+	eksNodePools := []config.Nodepool{{DiskSize: 100, InstanceType: p.terraformConfig.AWSConfig.AWSInstanceType, DesiredSize: 3, MaxSize: 3, MinSize: 3}}
+	gkeNodePools := []config.Nodepool{{Quantity: 3, MaxPodsConstraint: 110}}
+
+	return []hostedCluster{
+		{"Provision_AKS_Cluster", modules.AKS, aksNodePools, p.terratestConfig.AKSKubernetesVersion},
+		{"Provision_EKS_Cluster", modules.EKS, eksNodePools, p.terratestConfig.EKSKubernetesVersion},
+		{"Provision_GKE_Cluster", modules.GKE, gkeNodePools, p.terratestConfig.GKEKubernetesVersion},
+	}
+}
+
+// hostedNodepoolClusters returns the AKS, EKS and GKE clusters provisioned by the hosted test suite with a second node pool that is
+// labeled and tainted, autoscaled by AKS and GKE, and runs on spot instances on EKS and preemptible nodes on GKE.
+func (p *ProvisionHostedTestSuite) hostedNodepoolClusters() []hostedCluster {
+	labels := map[string]string{"tfp.rancher.io/pool": "workers"}
+	taints := []config.Taint{{Key: "dedicated", Value: "workers", Effect: "NoSchedule"}}
+	instanceType := p.terraformConfig.AWSConfig.AWSInstanceType
+
+	aksNodePools := []config.Nodepool{
+		{Quantity: 2},
+		{Name: "workers", Mode: "User", Quantity: 1, Autoscaling: true, MinSize: 1, MaxSize: 3, Labels: labels, Taints: taints},
+	}
+
+	eksNodePools := []config.Nodepool{
+		{DiskSize: 100, InstanceType: instanceType, DesiredSize: 2, MaxSize: 2, MinSize: 2},
+		{Name: "workers", DiskSize: 100, InstanceType: instanceType, DesiredSize: 1, MaxSize: 2, MinSize: 1, Spot: true, Labels: labels},
+	}
+
+	gkeNodePools := []config.Nodepool{
+		{Quantity: 2, MaxPodsConstraint: 110},
+		{Name: "workers", Quantity: 1, MaxPodsConstraint: 110, Autoscaling: true, MinSize: 1, MaxSize: 3, Spot: true, Labels: labels,
+			Taints: taints, Management: &config.NodepoolManagement{AutoRepair: true, AutoUpgrade: true}},
+	}
+
+	return []hostedCluster{
+		{"Provision_AKS_Nodepool_Settings", modules.AKS, aksNodePools, p.terratestConfig.AKSKubernetesVersion},
+		{"Provision_EKS_Nodepool_Settings", modules.EKS, eksNodePools, p.terratestConfig.EKSKubernetesVersion},
+		{"Provision_GKE_Nodepool_Settings", modules.GKE, gkeNodePools, p.terratestConfig.GKEKubernetesVersion},
+	}
+}
+
+// setHostedCluster sets the module, node pools and Kubernetes version of a hosted cluster in the cattle config.
+func (p *ProvisionHostedTestSuite) setHostedCluster(cluster hostedCluster, cattleConfig map[string]any) {
+	_, err := operations.ReplaceValue([]string{"terraform", "module"}, cluster.module, cattleConfig)
+	require.NoError(p.T(), err)
+
+	_, err = operations.ReplaceValue([]string{"terratest", "nodepools"}, cluster.nodePools, cattleConfig)
+	require.NoError(p.T(), err)
+
+	_, err = operations.ReplaceValue([]string{"terratest", "kubernetesVersion"}, cluster.kubernetesVersion, cattleConfig)
+	require.NoError(p.T(), err)
+}
+
 func TestTfpProvisionHostedTestSuite(t *testing.T) {
 	suite.Run(t, new(ProvisionHostedTestSuite))
 }
@@ -6,7 +6,6 @@ import (
 	"os"
 	"testing"
 
-	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/rancher/shepherd/clients/rancher"
 	shepherdConfig "github.com/rancher/shepherd/pkg/config"
 	"github.com/rancher/shepherd/pkg/config/operations"
@@ -15,10 +14,10 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/authproviders"
 	"github.com/rancher/tfp-automation/defaults/configs"
-	"github.com/rancher/tfp-automation/defaults/keypath"
 	"github.com/rancher/tfp-automation/framework"
 	"github.com/rancher/tfp-automation/framework/cleanup"
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/validate"
 	tfpQase "github.com/rancher/tfp-automation/pipeline/qase"
 	"github.com/rancher/tfp-automation/pipeline/qase/results"
 	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
@@ -30,16 +29,17 @@ import (
 
 type AuthConfigTestSuite struct {
 	suite.Suite
-	client           *rancher.Client
-	session          *session.Session
-	cattleConfig     map[string]any
-	rancherConfig    *rancher.Config
-	terraformConfig  *config.TerraformConfig
-	terratestConfig  *config.TerratestConfig
-	terraformOptions *terraform.Options
+	client          *rancher.Client
+	session         *session.Session
+	cattleConfig    map[string]any
+	rancherConfig   *rancher.Config
+	terraformConfig *config.TerraformConfig
+	terratestConfig *config.TerratestConfig
 }
 
 func (r *AuthConfigTestSuite) SetupSuite() {
+	require.NoError(r.T(), config.ComposeCattleConfig(r.T()))
+
 	testSession := session.NewSession()
 	r.session = testSession
 
@@ -49,11 +49,8 @@ func (r *AuthConfigTestSuite) SetupSuite() {
 	r.client = client
 
 	r.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	require.NoError(r.T(), validate.ValidateConfig(r.cattleConfig))
 	r.rancherConfig, r.terraformConfig, r.terratestConfig, _ = config.LoadTFPConfigs(r.cattleConfig)
-
-	_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, r.terratestConfig.PathToRepo, "")
-	terraformOptions := framework.Setup(r.T(), r.terraformConfig, r.terratestConfig, keyPath)
-	r.terraformOptions = terraformOptions
 }
 
 func (r *AuthConfigTestSuite) TestTfpAuthConfig() {
@@ -70,9 +67,6 @@ func (r *AuthConfigTestSuite) TestTfpAuthConfig() {
 	testUser, testPassword := configs.CreateTestCredentials()
 
 	for _, tt := range tests {
-		newFile, rootBody, file := rancher2.InitializeMainTF(r.terratestConfig)
-		defer file.Close()
-
 		configMap, err := provisioning.UniquifyTerraform([]map[string]any{r.cattleConfig})
 		require.NoError(r.T(), err)
 
@@ -82,10 +76,13 @@ func (r *AuthConfigTestSuite) TestTfpAuthConfig() {
 		rancher, terraform, _, _ := config.LoadTFPConfigs(configMap[0])
 
 		r.Run((tt.name), func() {
-			_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, r.terratestConfig.PathToRepo, "")
-			defer cleanup.Cleanup(r.T(), r.terraformOptions, keyPath)
+			terraformOptions, keyPath := framework.SetupWorkspace(r.T(), r.terraformConfig, r.terratestConfig)
+			defer cleanup.Cleanup(r.T(), terraformOptions, keyPath)
 
-			rbac.AuthConfig(r.T(), rancher, terraform, r.terraformOptions, testUser, testPassword, configMap, newFile, rootBody, file)
+			newFile, rootBody, file := rancher2.InitializeWorkspaceMainTF(keyPath)
+			defer file.Close()
+
+			rbac.AuthConfig(r.T(), rancher, terraform, terraformOptions, testUser, testPassword, configMap, newFile, rootBody, file)
 		})
 
 		params := tfpQase.GetProvisioningSchemaParams(configMap[0])
@@ -114,9 +111,6 @@ func (r *AuthConfigTestSuite) TestTfpAuthConfigDynamicInput() {
 	testUser, testPassword := configs.CreateTestCredentials()
 
 	for _, tt := range tests {
-		newFile, rootBody, file := rancher2.InitializeMainTF(r.terratestConfig)
-		defer file.Close()
-
 		configMap, err := provisioning.UniquifyTerraform([]map[string]any{r.cattleConfig})
 		require.NoError(r.T(), err)
 
@@ -126,10 +120,13 @@ func (r *AuthConfigTestSuite) TestTfpAuthConfigDynamicInput() {
 		rancher, terraform, _, _ := config.LoadTFPConfigs(configMap[0])
 
 		r.Run((tt.name), func() {
-			_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, r.terratestConfig.PathToRepo, "")
-			defer cleanup.Cleanup(r.T(), r.terraformOptions, keyPath)
+			terraformOptions, keyPath := framework.SetupWorkspace(r.T(), r.terraformConfig, r.terratestConfig)
+			defer cleanup.Cleanup(r.T(), terraformOptions, keyPath)
+
+			newFile, rootBody, file := rancher2.InitializeWorkspaceMainTF(keyPath)
+			defer file.Close()
 
-			rbac.AuthConfig(r.T(), rancher, terraform, r.terraformOptions, testUser, testPassword, configMap, newFile, rootBody, file)
+			rbac.AuthConfig(r.T(), rancher, terraform, terraformOptions, testUser, testPassword, configMap, newFile, rootBody, file)
 		})
 
 		params := tfpQase.GetProvisioningSchemaParams(configMap[0])
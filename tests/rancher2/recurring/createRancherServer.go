@@ -6,7 +6,6 @@ import (
 
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/rancher/shepherd/clients/rancher"
-	"github.com/rancher/shepherd/pkg/config"
 	shepherdConfig "github.com/rancher/shepherd/pkg/config"
 	"github.com/rancher/shepherd/pkg/config/operations"
 	"github.com/rancher/shepherd/pkg/session"
@@ -22,7 +21,9 @@ func main() {
 
 	t := &testing.T{}
 
-	cattleConfig := shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	// Setting up Rancher may point CATTLE_TEST_CONFIG at the composed config, so the token is written back to the original file.
+	configPath := os.Getenv(shepherdConfig.ConfigEnvironmentKey)
+	cattleConfig := shepherdConfig.LoadConfigFromFile(configPath)
 
 	client, _, _, _, _, err = setupRancher(t)
 	if err != nil {
@@ -34,7 +35,7 @@ func main() {
 		logrus.Fatalf("Failed to replace admin token: %v", err)
 	}
 
-	infraConfig.WriteConfigToFile(os.Getenv(config.ConfigEnvironmentKey), cattleConfig)
+	infraConfig.WriteConfigToFile(configPath, cattleConfig)
 }
 
 func setupRancher(t *testing.T) (*rancher.Client, string, *terraform.Options, *terraform.Options, map[string]any, error) {
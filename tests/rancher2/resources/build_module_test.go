@@ -10,6 +10,7 @@ import (
 	"github.com/rancher/tfp-automation/defaults/keypath"
 	cleanup "github.com/rancher/tfp-automation/framework/cleanup"
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/validate"
 	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -24,12 +25,15 @@ type BuildModuleTestSuite struct {
 }
 
 func (r *BuildModuleTestSuite) TestBuildModule() {
-	_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, r.terratestConfig.PathToRepo, "")
-	defer cleanup.TFFilesCleanup(keyPath)
+	require.NoError(r.T(), config.ComposeCattleConfig(r.T()))
 
 	r.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	require.NoError(r.T(), validate.ValidateConfig(r.cattleConfig))
 	r.rancherConfig, r.terraformConfig, r.terratestConfig, _ = config.LoadTFPConfigs(r.cattleConfig)
 
+	_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, r.terratestConfig.PathToRepo, "")
+	defer cleanup.TFFilesCleanup(keyPath)
+
 	configMap := []map[string]any{r.cattleConfig}
 
 	err := provisioning.BuildModule(r.T(), r.rancherConfig, r.terraformConfig, r.terratestConfig, configMap)
@@ -0,0 +1,173 @@
+package snapshot
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/shepherd/clients/rancher"
+	management "github.com/rancher/shepherd/clients/rancher/generated/management/v3"
+	"github.com/rancher/shepherd/extensions/clusters"
+	"github.com/rancher/shepherd/extensions/etcdsnapshot"
+	"github.com/rancher/shepherd/extensions/workloads"
+	namegen "github.com/rancher/shepherd/pkg/namegenerator"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/rancher/tfp-automation/defaults/stevetypes"
+	"github.com/rancher/tfp-automation/framework/plan"
+	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// RestoreUpgradedSnapshot creates workloads, takes a snapshot of the cluster, upgrades the Kubernetes version of the cluster and
+// restores the snapshot with the restore mode of the snapshot input. It verifies the Kubernetes version and config of the restored
+// cluster match the restore mode and that the workloads created after the snapshot no longer are present in the cluster. RKE1 clusters
+// take and restore the snapshot through the Rancher API, as the rancher2_cluster resource can not restore etcd backups.
+func RestoreUpgradedSnapshot(t *testing.T, client, standardUserClient *rancher.Client, rancherConfig *rancher.Config, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig, testUser, testPassword string, terraformOptions *terraform.Options, configMap []map[string]any,
+	newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File) {
+	initialWorkloadName := namegen.AppendRandomString(initialWorkload)
+	restoreMode := terratestConfig.SnapshotInput.SnapshotRestore
+
+	clusterID, err := clusters.GetClusterIDByName(client, terraformConfig.ResourcePrefix)
+	require.NoError(t, err)
+
+	steveclient, err := client.Steve.ProxyDownstream(clusterID)
+	require.NoError(t, err)
+
+	containerTemplate := workloads.NewContainer(containerName, containerImage, corev1.PullAlways, []corev1.VolumeMount{}, []corev1.EnvFromSource{}, nil, nil, nil)
+	podTemplate := workloads.NewPodTemplate([]corev1.Container{containerTemplate}, []corev1.Volume{}, []corev1.LocalObjectReference{}, nil, nil)
+
+	deploymentResp, serviceResp := createWorkloads(t, client, clusterID, podTemplate, initialWorkloadName, isCattleLabeled, DeploymentSteveType)
+
+	upgrade := func() {
+		clusterIDs, _ := provisioning.KubernetesUpgrade(t, client, standardUserClient, rancherConfig, terraformConfig, terratestConfig, testUser, testPassword,
+			terraformOptions, configMap, newFile, rootBody, file, false, false, false, nil)
+		provisioning.VerifyClustersState(t, client, clusterIDs)
+	}
+
+	if strings.Contains(terraformConfig.Module, clustertypes.RKE1) {
+		restoreUpgradedRKE1Snapshot(t, client, terraformConfig, terratestConfig, restoreMode, clusterID, podTemplate, terraformOptions, upgrade)
+	} else {
+		restoreUpgradedV2ProvSnapshot(t, client, rancherConfig, terraformConfig, terratestConfig, restoreMode, testUser, testPassword, clusterID, podTemplate,
+			terraformOptions, configMap, newFile, rootBody, file, upgrade)
+	}
+
+	logrus.Infof("Deleting created workloads...")
+	err = steveclient.SteveType(stevetypes.Deployment).Delete(deploymentResp)
+	require.NoError(t, err)
+
+	err = steveclient.SteveType(stevetypes.Service).Delete(serviceResp)
+	require.NoError(t, err)
+}
+
+// restoreUpgradedV2ProvSnapshot takes a snapshot of a RKE2/K3s cluster, upgrades the cluster and restores the snapshot through the
+// etcd_snapshot_restore block of the rancher2_cluster_v2 resource.
+func restoreUpgradedV2ProvSnapshot(t *testing.T, client *rancher.Client, rancherConfig *rancher.Config, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig, restoreMode, testUser, testPassword, clusterID string, podTemplate corev1.PodTemplateSpec,
+	terraformOptions *terraform.Options, configMap []map[string]any, newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File, upgrade func()) {
+	snapshotName, postDeploymentResp, postServiceResp, err := snapshotV2Prov(t, client, rancherConfig, terraformConfig, terratestConfig, podTemplate, testUser, testPassword, clusterID, terraformOptions, configMap, newFile, rootBody, file)
+	require.NoError(t, err)
+
+	snapshotCluster, _, err := clusters.GetProvisioningClusterByName(client, terraformConfig.ResourcePrefix, namespace)
+	require.NoError(t, err)
+
+	upgrade()
+
+	upgradedCluster, _, err := clusters.GetProvisioningClusterByName(client, terraformConfig.ResourcePrefix, namespace)
+	require.NoError(t, err)
+	require.NotEqual(t, snapshotCluster.Spec.KubernetesVersion, upgradedCluster.Spec.KubernetesVersion, "the Kubernetes version was not upgraded")
+
+	logrus.Infof("Restoring snapshot %s with restore mode %s...", snapshotName, restoreMode)
+	restoreV2Prov(t, client, rancherConfig, terraformConfig, terratestConfig, snapshotName, testUser, testPassword, clusterID, terraformOptions, configMap, newFile, rootBody, file)
+
+	restoredCluster, _, err := clusters.GetProvisioningClusterByName(client, terraformConfig.ResourcePrefix, namespace)
+	require.NoError(t, err)
+
+	expectedVersion, expectedConfig := upgradedCluster.Spec.KubernetesVersion, upgradedCluster.Spec.RKEConfig.ClusterConfiguration
+	switch restoreMode {
+	case config.RestoreKubernetesVersion:
+		expectedVersion = snapshotCluster.Spec.KubernetesVersion
+	case config.RestoreAll:
+		expectedVersion, expectedConfig = snapshotCluster.Spec.KubernetesVersion, snapshotCluster.Spec.RKEConfig.ClusterConfiguration
+	}
+
+	assert.Equal(t, expectedVersion, restoredCluster.Spec.KubernetesVersion, "Kubernetes version after restoring with restore mode %s", restoreMode)
+	assert.Equal(t, expectedConfig, restoredCluster.Spec.RKEConfig.ClusterConfiguration, "cluster config after restoring with restore mode %s", restoreMode)
+
+	verifyWorkloadsRemoved(t, client, clusterID, postDeploymentResp.ID, postServiceResp.ID)
+}
+
+// restoreUpgradedRKE1Snapshot takes an etcd backup of a RKE1 cluster, upgrades the cluster and restores the backup. The restore is not
+// done by Terraform, so the rancher2_cluster resource keeps the upgraded Kubernetes version and the idempotency check is skipped when
+// the Kubernetes version is restored.
+func restoreUpgradedRKE1Snapshot(t *testing.T, client *rancher.Client, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
+	restoreMode, clusterID string, podTemplate corev1.PodTemplateSpec, terraformOptions *terraform.Options, upgrade func()) {
+	snapshotCluster, err := client.Management.Cluster.ByID(clusterID)
+	require.NoError(t, err)
+
+	backups, err := etcdsnapshot.CreateRKE1Snapshot(client, terraformConfig.ResourcePrefix)
+	require.NoError(t, err)
+	require.NotEmpty(t, backups)
+
+	postWorkloadName := namegen.AppendRandomString(postWorkload)
+	postDeploymentResp, postServiceResp := createWorkloads(t, client, clusterID, podTemplate, postWorkloadName, isCattleLabeled, DeploymentSteveType)
+
+	upgrade()
+
+	upgradedCluster, err := client.Management.Cluster.ByID(clusterID)
+	require.NoError(t, err)
+	require.NotEqual(t, snapshotCluster.RancherKubernetesEngineConfig.Version, upgradedCluster.RancherKubernetesEngineConfig.Version, "the Kubernetes version was not upgraded")
+
+	snapshotRestore := &management.RestoreFromEtcdBackupInput{
+		EtcdBackupID:     backups[0].ID,
+		RestoreRkeConfig: restoreMode,
+	}
+
+	err = etcdsnapshot.RestoreRKE1Snapshot(client, terraformConfig.ResourcePrefix, snapshotRestore)
+	require.NoError(t, err)
+
+	restoredCluster, err := client.Management.Cluster.ByID(clusterID)
+	require.NoError(t, err)
+
+	expectedVersion, expectedServices := upgradedCluster.RancherKubernetesEngineConfig.Version, upgradedCluster.RancherKubernetesEngineConfig.Services
+	switch restoreMode {
+	case config.RestoreKubernetesVersion:
+		expectedVersion = snapshotCluster.RancherKubernetesEngineConfig.Version
+	case config.RestoreAll:
+		expectedVersion, expectedServices = snapshotCluster.RancherKubernetesEngineConfig.Version, snapshotCluster.RancherKubernetesEngineConfig.Services
+	}
+
+	logrus.Infof("Cluster version is restored to: %s", restoredCluster.RancherKubernetesEngineConfig.Version)
+
+	assert.Equal(t, expectedVersion, restoredCluster.RancherKubernetesEngineConfig.Version, "Kubernetes version after restoring with restore mode %s", restoreMode)
+	assert.Equal(t, expectedServices, restoredCluster.RancherKubernetesEngineConfig.Services, "services config after restoring with restore mode %s", restoreMode)
+
+	if terratestConfig.IdempotencyCheck {
+		if restoreMode == config.RestoreNone {
+			err = plan.CheckIdempotency(t, terraformOptions)
+			require.NoError(t, err)
+		} else {
+			logrus.Infof("Skipping the idempotency check of cluster %s, as the rancher2_cluster resource can not restore etcd backups", terraformConfig.ResourcePrefix)
+		}
+	}
+
+	verifyWorkloadsRemoved(t, client, clusterID, postDeploymentResp.ID, postServiceResp.ID)
+}
+
+// verifyWorkloadsRemoved verifies the deployment and service created after the snapshot no longer are present in the cluster.
+func verifyWorkloadsRemoved(t *testing.T, client *rancher.Client, clusterID, deploymentID, serviceID string) {
+	steveclient, err := client.Steve.ProxyDownstream(clusterID)
+	require.NoError(t, err)
+
+	_, err = steveclient.SteveType(DeploymentSteveType).ByID(deploymentID)
+	require.Error(t, err)
+
+	_, err = steveclient.SteveType(serviceType).ByID(serviceID)
+	require.Error(t, err)
+}
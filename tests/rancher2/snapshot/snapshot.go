@@ -21,6 +21,7 @@ import (
 	deploy "github.com/rancher/tests/actions/workloads/deployment"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/stevetypes"
+	"github.com/rancher/tfp-automation/framework/plan"
 	framework "github.com/rancher/tfp-automation/framework/set"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -43,6 +44,7 @@ const (
 	DeploymentSteveType = "apps.deployment"
 	initialWorkload     = "wload-before-restore"
 	isCattleLabeled     = true
+	local               = "local"
 	localCluster        = "local"
 	kubernetesVersion   = "kubernetesVersion"
 	namespace           = "fleet-default"
@@ -101,7 +103,8 @@ func snapshotV2Prov(t *testing.T, client *rancher.Client, rancherConfig *rancher
 	_, _, err = framework.ConfigTF(client, rancherConfig, terratestConfig, testUser, testPassword, "", configMap, newFile, rootBody, file, false, false, false, nil)
 	require.NoError(t, err)
 
-	terraform.Apply(t, terraformOptions)
+	err = plan.PlanAndApply(t, terraformOptions, plan.UpdateOnly)
+	require.NoError(t, err)
 
 	err = clusters.WaitClusterToBeUpgraded(client, clusterID)
 	require.NoError(t, err)
@@ -134,7 +137,8 @@ func restoreV2Prov(t *testing.T, client *rancher.Client, rancherConfig *rancher.
 	_, _, err = framework.ConfigTF(client, rancherConfig, terratestConfig, testUser, testPassword, "", configMap, newFile, rootBody, file, false, false, false, nil)
 	require.NoError(t, err)
 
-	terraform.Apply(t, terraformOptions)
+	err = plan.PlanAndApply(t, terraformOptions, plan.UpdateOnly)
+	require.NoError(t, err)
 
 	err = clusters.WaitClusterToBeUpgraded(client, clusterID)
 	require.NoError(t, err)
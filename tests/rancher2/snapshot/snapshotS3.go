@@ -0,0 +1,173 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/shepherd/clients/rancher"
+	steveV1 "github.com/rancher/shepherd/clients/rancher/v1"
+	"github.com/rancher/shepherd/extensions/clusters"
+	timeouts "github.com/rancher/shepherd/extensions/defaults"
+	"github.com/rancher/shepherd/extensions/workloads"
+	namegen "github.com/rancher/shepherd/pkg/namegenerator"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/stevetypes"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+// RestoreS3Snapshot creates workloads, takes a snapshot of the cluster that is stored in S3, deletes the local copies of the snapshots,
+// restores the cluster from S3 and verifies the workloads created after the snapshot no longer are present in the cluster
+func RestoreS3Snapshot(t *testing.T, client *rancher.Client, rancherConfig *rancher.Config, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig, testUser, testPassword string, terraformOptions *terraform.Options, configMap []map[string]any,
+	newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File) {
+	initialWorkloadName := namegen.AppendRandomString(initialWorkload)
+
+	clusterID, err := clusters.GetClusterIDByName(client, terraformConfig.ResourcePrefix)
+	require.NoError(t, err)
+
+	steveclient, err := client.Steve.ProxyDownstream(clusterID)
+	require.NoError(t, err)
+
+	containerTemplate := workloads.NewContainer(containerName, containerImage, corev1.PullAlways, []corev1.VolumeMount{}, []corev1.EnvFromSource{}, nil, nil, nil)
+	podTemplate := workloads.NewPodTemplate([]corev1.Container{containerTemplate}, []corev1.Volume{}, []corev1.LocalObjectReference{}, nil, nil)
+
+	deploymentResp, serviceResp := createWorkloads(t, client, clusterID, podTemplate, initialWorkloadName, isCattleLabeled, DeploymentSteveType)
+
+	existingSnapshots, err := snapshotsByStorage(client, terraformConfig.ResourcePrefix, S3)
+	require.NoError(t, err)
+
+	_, postDeploymentResp, postServiceResp, err := snapshotV2Prov(t, client, rancherConfig, terraformConfig, terratestConfig, podTemplate, testUser, testPassword, clusterID, terraformOptions, configMap, newFile, rootBody, file)
+	require.NoError(t, err)
+
+	snapshotName, err := waitForS3Snapshot(client, terraformConfig.ResourcePrefix, existingSnapshots)
+	require.NoError(t, err)
+
+	logrus.Infof("Snapshot %s is stored in S3", snapshotName)
+
+	err = deleteLocalSnapshots(client, terraformConfig.ResourcePrefix)
+	require.NoError(t, err)
+
+	restoreV2Prov(t, client, rancherConfig, terraformConfig, terratestConfig, snapshotName, testUser, testPassword, clusterID, terraformOptions, configMap, newFile, rootBody, file)
+
+	_, err = steveclient.SteveType(DeploymentSteveType).ByID(postDeploymentResp.ID)
+	require.Error(t, err)
+
+	_, err = steveclient.SteveType(serviceType).ByID(postServiceResp.ID)
+	require.Error(t, err)
+
+	logrus.Infof("Deleting created workloads...")
+	err = steveclient.SteveType(stevetypes.Deployment).Delete(deploymentResp)
+	require.NoError(t, err)
+
+	err = steveclient.SteveType(stevetypes.Service).Delete(serviceResp)
+	require.NoError(t, err)
+}
+
+// waitForS3Snapshot waits for the on-demand snapshot of the cluster taken after the existing snapshots to be stored in S3 and returns
+// its name. Scheduled snapshots are skipped, as they may have been taken after the workloads that the restore must remove.
+func waitForS3Snapshot(client *rancher.Client, clusterName string, existingSnapshots []steveV1.SteveAPIObject) (string, error) {
+	existing := map[string]bool{}
+	for _, snapshot := range existingSnapshots {
+		existing[snapshot.Name] = true
+	}
+
+	var snapshotName string
+
+	err := kwait.PollUntilContextTimeout(context.TODO(), timeouts.FiveSecondTimeout, timeouts.TenMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		snapshots, err := snapshotsByStorage(client, clusterName, S3)
+		if err != nil {
+			return false, nil
+		}
+
+		for _, snapshot := range snapshots {
+			if existing[snapshot.Name] {
+				continue
+			}
+
+			etcdSnapshot := rkev1.ETCDSnapshot{}
+			err = steveV1.ConvertToK8sType(snapshot.JSONResp, &etcdSnapshot)
+			if err != nil {
+				return false, err
+			}
+
+			file := etcdSnapshot.SnapshotFile
+			if !strings.Contains(file.Name, onDemand) || file.Status == failedSnapshot {
+				continue
+			}
+
+			snapshotName = snapshot.Name
+
+			return true, nil
+		}
+
+		return false, nil
+	})
+	if err != nil {
+		return "", errors.New("no new on-demand snapshot of cluster " + clusterName + " was stored in S3: " + err.Error())
+	}
+
+	return snapshotName, nil
+}
+
+// deleteLocalSnapshots deletes the snapshots of the cluster that are stored on its nodes and waits for them to be removed, so that
+// a restore can only succeed from S3.
+func deleteLocalSnapshots(client *rancher.Client, clusterName string) error {
+	snapshots, err := snapshotsByStorage(client, clusterName, local)
+	if err != nil {
+		return err
+	}
+
+	localclusterID, err := clusters.GetClusterIDByName(client, localCluster)
+	if err != nil {
+		return err
+	}
+
+	steveclient, err := client.Steve.ProxyDownstream(localclusterID)
+	if err != nil {
+		return err
+	}
+
+	for _, snapshot := range snapshots {
+		logrus.Infof("Deleting local snapshot %s...", snapshot.Name)
+
+		err = steveclient.SteveType(SnapshotAnnotation).Delete(&snapshot)
+		if err != nil {
+			return err
+		}
+	}
+
+	return kwait.PollUntilContextTimeout(context.TODO(), timeouts.FiveSecondTimeout, timeouts.FiveMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		snapshots, err := snapshotsByStorage(client, clusterName, local)
+		if err != nil {
+			return false, nil
+		}
+
+		return len(snapshots) == 0, nil
+	})
+}
+
+// snapshotsByStorage retrieves the snapshots of a given cluster that are kept in the given storage, oldest first.
+func snapshotsByStorage(client *rancher.Client, clusterName, storage string) ([]steveV1.SteveAPIObject, error) {
+	snapshots, err := getSnapshots(client, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := []steveV1.SteveAPIObject{}
+	for _, snapshot := range snapshots {
+		if snapshot.ObjectMeta.Annotations[StorageAnnotation] == storage {
+			stored = append(stored, snapshot)
+		}
+	}
+
+	return stored, nil
+}
@@ -0,0 +1,197 @@
+package snapshot
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/shepherd/clients/rancher"
+	steveV1 "github.com/rancher/shepherd/clients/rancher/v1"
+	"github.com/robfig/cron/v3"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	defaultSnapshotRetention = 5
+	failedSnapshot           = "failed"
+	onDemand                 = "on-demand"
+	snapshotPollInterval     = 30 * time.Second
+	snapshotTolerance        = 5 * time.Minute
+)
+
+// scheduledSnapshot is a scheduled snapshot of a cluster, as listed by its rke.cattle.io.etcdsnapshot object.
+type scheduledSnapshot struct {
+	name      string
+	storage   string
+	nodeName  string
+	createdAt time.Time
+}
+
+// VerifySnapshotSchedule polls the scheduled snapshots of the cluster for one more run of the snapshot cron than the snapshot
+// retention. It verifies that every node takes a snapshot on each run, that no snapshot is taken off the schedule and that older
+// snapshots are pruned to the retention. When s3 is true, the snapshots stored in S3 are verified as well as the local ones.
+func VerifySnapshotSchedule(t *testing.T, client *rancher.Client, clusterName string, etcd *rkev1.ETCD, s3 bool) {
+	require.NotNil(t, etcd, "the etcd config of the cluster is not set")
+	require.False(t, etcd.DisableSnapshots, "scheduled snapshots are disabled")
+
+	schedule, err := cron.ParseStandard(etcd.SnapshotScheduleCron)
+	require.NoError(t, err)
+
+	retention := etcd.SnapshotRetention
+	if retention == 0 {
+		retention = defaultSnapshotRetention
+	}
+
+	storages := []string{local}
+	if s3 {
+		storages = append(storages, S3)
+	}
+
+	start := time.Now().UTC()
+	runs := []time.Time{}
+
+	for run := schedule.Next(start); len(runs) <= retention; run = schedule.Next(run) {
+		runs = append(runs, run)
+	}
+
+	deadline := runs[len(runs)-1].Add(snapshotTolerance)
+	logrus.Infof("Verifying %d scheduled snapshot runs of cluster %s until %s...", len(runs), clusterName, deadline.Format(time.RFC3339))
+
+	seen := map[string]scheduledSnapshot{}
+	var current []scheduledSnapshot
+
+	err = kwait.PollUntilContextTimeout(context.TODO(), snapshotPollInterval, time.Until(deadline)+snapshotPollInterval, true, func(ctx context.Context) (bool, error) {
+		snapshots, err := scheduledSnapshots(client, clusterName)
+		if err != nil {
+			logrus.Warnf("Failed to list the snapshots of cluster %s: %v", clusterName, err)
+			return false, nil
+		}
+
+		current = snapshots
+		for _, snapshot := range snapshots {
+			seen[snapshot.name] = snapshot
+		}
+
+		// A new snapshot may be listed briefly before the oldest one is pruned, so one extra snapshot is tolerated while polling.
+		for key, count := range countByNode(snapshots) {
+			assert.LessOrEqualf(t, count, retention+1, "%s has %d scheduled snapshots, retention is %d", key, count, retention)
+		}
+
+		return !time.Now().Before(deadline), nil
+	})
+	require.NoError(t, err)
+
+	for _, snapshot := range seen {
+		if snapshot.createdAt.Before(start) {
+			continue
+		}
+
+		onSchedule := !schedule.Next(snapshot.createdAt.Add(-snapshotTolerance)).After(snapshot.createdAt)
+		assert.Truef(t, onSchedule, "snapshot %s was created at %s, off the schedule %q", snapshot.name, snapshot.createdAt.Format(time.RFC3339), etcd.SnapshotScheduleCron)
+	}
+
+	nodes := map[string][]string{}
+	for _, snapshot := range seen {
+		if !slices.Contains(nodes[snapshot.storage], snapshot.nodeName) {
+			nodes[snapshot.storage] = append(nodes[snapshot.storage], snapshot.nodeName)
+		}
+	}
+
+	for _, storage := range storages {
+		require.NotEmptyf(t, nodes[storage], "no scheduled %s snapshots of cluster %s were found", storage, clusterName)
+
+		for _, nodeName := range nodes[storage] {
+			for _, run := range runs {
+				assert.Truef(t, hasSnapshot(seen, storage, nodeName, run), "no %s snapshot of node %s was taken for the run at %s", storage, nodeName, run.Format(time.RFC3339))
+			}
+		}
+	}
+
+	// Every snapshot older than the retained runs must have been pruned by the end of the last run.
+	oldestRetained := runs[len(runs)-retention]
+	for _, snapshot := range current {
+		assert.Falsef(t, snapshot.createdAt.Before(oldestRetained), "%s snapshot %s of %s was not pruned", snapshot.storage, snapshot.name, snapshot.createdAt.Format(time.RFC3339))
+	}
+
+	for key, count := range countByNode(current) {
+		assert.Equalf(t, retention, count, "%s has %d scheduled snapshots after the last run, retention is %d", key, count, retention)
+		logrus.Infof("%s keeps %d scheduled snapshots", key, count)
+	}
+}
+
+// scheduledSnapshots retrieves the scheduled snapshots of a given cluster, skipping on-demand and failed snapshots.
+func scheduledSnapshots(client *rancher.Client, clusterName string) ([]scheduledSnapshot, error) {
+	snapshotObjects, err := getSnapshots(client, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := []scheduledSnapshot{}
+	for _, snapshotObject := range snapshotObjects {
+		etcdSnapshot := rkev1.ETCDSnapshot{}
+		err = steveV1.ConvertToK8sType(snapshotObject.JSONResp, &etcdSnapshot)
+		if err != nil {
+			return nil, err
+		}
+
+		file := etcdSnapshot.SnapshotFile
+		if strings.Contains(file.Name, onDemand) || file.Status == failedSnapshot {
+			continue
+		}
+
+		createdAt := snapshotObject.ObjectMeta.CreationTimestamp.Time
+		if file.CreatedAt != nil {
+			createdAt = file.CreatedAt.Time
+		}
+
+		storage := local
+		if file.S3 != nil || snapshotObject.ObjectMeta.Annotations[StorageAnnotation] == S3 {
+			storage = S3
+		}
+
+		snapshots = append(snapshots, scheduledSnapshot{
+			name:      snapshotObject.Name,
+			storage:   storage,
+			nodeName:  file.NodeName,
+			createdAt: createdAt.UTC(),
+		})
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].createdAt.Before(snapshots[j].createdAt)
+	})
+
+	return snapshots, nil
+}
+
+// countByNode counts the snapshots that each node keeps in each storage.
+func countByNode(snapshots []scheduledSnapshot) map[string]int {
+	counts := map[string]int{}
+	for _, snapshot := range snapshots {
+		counts["node "+snapshot.nodeName+" ("+snapshot.storage+")"]++
+	}
+
+	return counts
+}
+
+// hasSnapshot returns whether a node took a snapshot in the given storage for the snapshot run at the given time.
+func hasSnapshot(snapshots map[string]scheduledSnapshot, storage, nodeName string, run time.Time) bool {
+	for _, snapshot := range snapshots {
+		if snapshot.storage != storage || snapshot.nodeName != nodeName {
+			continue
+		}
+
+		if !snapshot.createdAt.Before(run) && snapshot.createdAt.Before(run.Add(snapshotTolerance)) {
+			return true
+		}
+	}
+
+	return false
+}
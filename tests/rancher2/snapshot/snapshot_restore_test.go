@@ -7,8 +7,8 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/rancher/shepherd/clients/rancher"
+	management "github.com/rancher/shepherd/clients/rancher/generated/management/v3"
 	shepherdConfig "github.com/rancher/shepherd/pkg/config"
 	"github.com/rancher/shepherd/pkg/config/operations"
 	"github.com/rancher/shepherd/pkg/session"
@@ -16,11 +16,11 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/clustertypes"
 	"github.com/rancher/tfp-automation/defaults/configs"
-	"github.com/rancher/tfp-automation/defaults/keypath"
 	"github.com/rancher/tfp-automation/defaults/modules"
 	"github.com/rancher/tfp-automation/framework"
 	"github.com/rancher/tfp-automation/framework/cleanup"
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/validate"
 	qase "github.com/rancher/tfp-automation/pipeline/qase/results"
 	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
 	"github.com/stretchr/testify/require"
@@ -36,10 +36,11 @@ type SnapshotRestoreTestSuite struct {
 	rancherConfig      *rancher.Config
 	terraformConfig    *config.TerraformConfig
 	terratestConfig    *config.TerratestConfig
-	terraformOptions   *terraform.Options
 }
 
 func (s *SnapshotRestoreTestSuite) SetupSuite() {
+	require.NoError(s.T(), config.ComposeCattleConfig(s.T()))
+
 	testSession := session.NewSession()
 	s.session = testSession
 
@@ -49,11 +50,8 @@ func (s *SnapshotRestoreTestSuite) SetupSuite() {
 	s.client = client
 
 	s.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	require.NoError(s.T(), validate.ValidateConfig(s.cattleConfig))
 	s.rancherConfig, s.terraformConfig, s.terratestConfig, _ = config.LoadTFPConfigs(s.cattleConfig)
-
-	_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, s.terratestConfig.PathToRepo, "")
-	terraformOptions := framework.Setup(s.T(), s.terraformConfig, s.terratestConfig, keyPath)
-	s.terraformOptions = terraformOptions
 }
 
 func (s *SnapshotRestoreTestSuite) TestTfpSnapshotRestore() {
@@ -82,9 +80,6 @@ func (s *SnapshotRestoreTestSuite) TestTfpSnapshotRestore() {
 	}
 
 	for _, tt := range tests {
-		newFile, rootBody, file := rancher2.InitializeMainTF(s.terratestConfig)
-		defer file.Close()
-
 		configMap, err := provisioning.UniquifyTerraform([]map[string]any{s.cattleConfig})
 		require.NoError(s.T(), err)
 
@@ -106,16 +101,98 @@ func (s *SnapshotRestoreTestSuite) TestTfpSnapshotRestore() {
 		}
 
 		s.Run(tt.name, func() {
-			_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, s.terratestConfig.PathToRepo, "")
-			defer cleanup.Cleanup(s.T(), s.terraformOptions, keyPath)
+			terraformOptions, keyPath := framework.SetupWorkspace(s.T(), s.terraformConfig, s.terratestConfig)
+			defer cleanup.Cleanup(s.T(), terraformOptions, keyPath)
+
+			newFile, rootBody, file := rancher2.InitializeWorkspaceMainTF(keyPath)
+			defer file.Close()
+
+			adminClient, err := provisioning.FetchAdminClient(s.T(), s.client)
+			require.NoError(s.T(), err)
+
+			clusterIDs, _ := provisioning.Provision(s.T(), s.client, s.standardUserClient, rancher, terraform, terratest, testUser, testPassword, terraformOptions, configMap, newFile, rootBody, file, false, false, false, nil)
+			provisioning.VerifyClustersState(s.T(), adminClient, clusterIDs)
+
+			RestoreSnapshot(s.T(), adminClient, rancher, terraform, terratest, testUser, testPassword, terraformOptions, configMap, newFile, rootBody, file)
+			provisioning.VerifyClustersState(s.T(), adminClient, clusterIDs)
+		})
+	}
+
+	if s.terratestConfig.LocalQaseReporting {
+		qase.ReportTest(s.terratestConfig)
+	}
+}
+
+func (s *SnapshotRestoreTestSuite) TestTfpSnapshotRestoreUpgrade() {
+	var err error
+	var testUser, testPassword string
+
+	s.standardUserClient, testUser, testPassword, err = standarduser.CreateStandardUser(s.client)
+	require.NoError(s.T(), err)
+
+	nodeRolesDedicated := []config.Nodepool{config.EtcdNodePool, config.ControlPlaneNodePool, config.WorkerNodePool}
+
+	etcdRKE1 := s.terraformConfig.ETCDRKE1
+	if etcdRKE1 == nil {
+		etcdRKE1 = &management.ETCDService{
+			BackupConfig: &management.BackupConfig{IntervalHours: 12, Timeout: 300},
+			Retention:    "72h",
+		}
+	}
+
+	tests := []struct {
+		name        string
+		module      string
+		nodeRoles   []config.Nodepool
+		restoreMode string
+	}{
+		{"RKE2_Snapshot_Restore_ETCD", modules.EC2RKE2, nodeRolesDedicated, config.RestoreNone},
+		{"RKE2_Snapshot_Restore_K8s_Version", modules.EC2RKE2, nodeRolesDedicated, config.RestoreKubernetesVersion},
+		{"RKE2_Snapshot_Restore_All", modules.EC2RKE2, nodeRolesDedicated, config.RestoreAll},
+		{"K3S_Snapshot_Restore_ETCD", modules.EC2K3s, nodeRolesDedicated, config.RestoreNone},
+		{"K3S_Snapshot_Restore_K8s_Version", modules.EC2K3s, nodeRolesDedicated, config.RestoreKubernetesVersion},
+		{"K3S_Snapshot_Restore_All", modules.EC2K3s, nodeRolesDedicated, config.RestoreAll},
+		{"RKE1_Snapshot_Restore_ETCD", modules.EC2RKE1, nodeRolesDedicated, config.RestoreNone},
+		{"RKE1_Snapshot_Restore_K8s_Version", modules.EC2RKE1, nodeRolesDedicated, config.RestoreKubernetesVersion},
+		{"RKE1_Snapshot_Restore_All", modules.EC2RKE1, nodeRolesDedicated, config.RestoreAll},
+	}
+
+	for _, tt := range tests {
+		configMap, err := provisioning.UniquifyTerraform([]map[string]any{s.cattleConfig})
+		require.NoError(s.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terraform", "module"}, tt.module, configMap[0])
+		require.NoError(s.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terratest", "nodepools"}, tt.nodeRoles, configMap[0])
+		require.NoError(s.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terratest", "snapshotInput", "snapshotRestore"}, tt.restoreMode, configMap[0])
+		require.NoError(s.T(), err)
+
+		if strings.Contains(tt.module, clustertypes.RKE1) {
+			_, err = operations.ReplaceValue([]string{"terraform", "etcdRKE1"}, etcdRKE1, configMap[0])
+			require.NoError(s.T(), err)
+		}
+
+		provisioning.GetK8sVersion(s.T(), s.client, s.terratestConfig, s.terraformConfig, configs.SecondHighestVersion, configMap)
+
+		rancher, terraform, terratest, _ := config.LoadTFPConfigs(configMap[0])
+
+		s.Run(tt.name, func() {
+			terraformOptions, keyPath := framework.SetupWorkspace(s.T(), s.terraformConfig, s.terratestConfig)
+			defer cleanup.Cleanup(s.T(), terraformOptions, keyPath)
+
+			newFile, rootBody, file := rancher2.InitializeWorkspaceMainTF(keyPath)
+			defer file.Close()
 
 			adminClient, err := provisioning.FetchAdminClient(s.T(), s.client)
 			require.NoError(s.T(), err)
 
-			clusterIDs, _ := provisioning.Provision(s.T(), s.client, s.standardUserClient, rancher, terraform, terratest, testUser, testPassword, s.terraformOptions, configMap, newFile, rootBody, file, false, false, false, nil)
+			clusterIDs, _ := provisioning.Provision(s.T(), s.client, s.standardUserClient, rancher, terraform, terratest, testUser, testPassword, terraformOptions, configMap, newFile, rootBody, file, false, false, false, nil)
 			provisioning.VerifyClustersState(s.T(), adminClient, clusterIDs)
 
-			RestoreSnapshot(s.T(), adminClient, rancher, terraform, terratest, testUser, testPassword, s.terraformOptions, configMap, newFile, rootBody, file)
+			RestoreUpgradedSnapshot(s.T(), adminClient, s.standardUserClient, rancher, terraform, terratest, testUser, testPassword, terraformOptions, configMap, newFile, rootBody, file)
 			provisioning.VerifyClustersState(s.T(), adminClient, clusterIDs)
 		})
 	}
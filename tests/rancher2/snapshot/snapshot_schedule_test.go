@@ -0,0 +1,141 @@
+//go:build validation || recurring
+
+package snapshot
+
+import (
+	"os"
+	"testing"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/shepherd/clients/rancher"
+	shepherdConfig "github.com/rancher/shepherd/pkg/config"
+	"github.com/rancher/shepherd/pkg/config/operations"
+	"github.com/rancher/shepherd/pkg/session"
+	"github.com/rancher/tests/validation/provisioning/resources/standarduser"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework"
+	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/validate"
+	qase "github.com/rancher/tfp-automation/pipeline/qase/results"
+	"github.com/rancher/tfp-automation/tests/extensions/minio"
+	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	scheduleCron      = "*/5 * * * *"
+	scheduleRetention = 2
+)
+
+type SnapshotScheduleTestSuite struct {
+	suite.Suite
+	client             *rancher.Client
+	standardUserClient *rancher.Client
+	session            *session.Session
+	cattleConfig       map[string]any
+	rancherConfig      *rancher.Config
+	terraformConfig    *config.TerraformConfig
+	terratestConfig    *config.TerratestConfig
+}
+
+func (s *SnapshotScheduleTestSuite) SetupSuite() {
+	require.NoError(s.T(), config.ComposeCattleConfig(s.T()))
+
+	testSession := session.NewSession()
+	s.session = testSession
+
+	client, err := rancher.NewClient("", testSession)
+	require.NoError(s.T(), err)
+
+	s.client = client
+
+	s.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	require.NoError(s.T(), validate.ValidateConfig(s.cattleConfig))
+	s.rancherConfig, s.terraformConfig, s.terratestConfig, _ = config.LoadTFPConfigs(s.cattleConfig)
+}
+
+func (s *SnapshotScheduleTestSuite) TestTfpSnapshotSchedule() {
+	var err error
+	var testUser, testPassword string
+
+	s.standardUserClient, testUser, testPassword, err = standarduser.CreateStandardUser(s.client)
+	require.NoError(s.T(), err)
+
+	etcd := s.terraformConfig.ETCD
+	if etcd == nil || etcd.SnapshotScheduleCron == "" {
+		etcd = &rkev1.ETCD{SnapshotScheduleCron: scheduleCron, SnapshotRetention: scheduleRetention}
+	}
+
+	snapshotS3 := s.terratestConfig.SnapshotInput.S3
+	if snapshotS3 != nil && snapshotS3.MinIO {
+		namespace, err := minio.Deploy(s.client, snapshotS3)
+		require.NoError(s.T(), err)
+
+		defer func() {
+			require.NoError(s.T(), minio.Delete(s.client, namespace))
+		}()
+	}
+
+	nodeRolesDedicated := []config.Nodepool{config.EtcdNodePool, config.ControlPlaneNodePool, config.WorkerNodePool}
+
+	tests := []struct {
+		name      string
+		module    string
+		nodeRoles []config.Nodepool
+	}{
+		{"RKE2_Snapshot_Schedule", modules.EC2RKE2, nodeRolesDedicated},
+		{"K3S_Snapshot_Schedule", modules.EC2K3s, nodeRolesDedicated},
+	}
+
+	for _, tt := range tests {
+		configMap, err := provisioning.UniquifyTerraform([]map[string]any{s.cattleConfig})
+		require.NoError(s.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terraform", "module"}, tt.module, configMap[0])
+		require.NoError(s.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terraform", "etcd"}, etcd, configMap[0])
+		require.NoError(s.T(), err)
+
+		_, err = operations.ReplaceValue([]string{"terratest", "nodepools"}, tt.nodeRoles, configMap[0])
+		require.NoError(s.T(), err)
+
+		if snapshotS3 != nil {
+			_, err = operations.ReplaceValue([]string{"terratest", "snapshotInput", "s3"}, snapshotS3, configMap[0])
+			require.NoError(s.T(), err)
+		}
+
+		provisioning.GetK8sVersion(s.T(), s.client, s.terratestConfig, s.terraformConfig, configs.DefaultK8sVersion, configMap)
+
+		rancher, terraform, terratest, _ := config.LoadTFPConfigs(configMap[0])
+
+		s.Run(tt.name, func() {
+			terraformOptions, keyPath := framework.SetupWorkspace(s.T(), s.terraformConfig, s.terratestConfig)
+			defer cleanup.Cleanup(s.T(), terraformOptions, keyPath)
+
+			newFile, rootBody, file := rancher2.InitializeWorkspaceMainTF(keyPath)
+			defer file.Close()
+
+			adminClient, err := provisioning.FetchAdminClient(s.T(), s.client)
+			require.NoError(s.T(), err)
+
+			clusterIDs, _ := provisioning.Provision(s.T(), s.client, s.standardUserClient, rancher, terraform, terratest, testUser, testPassword, terraformOptions, configMap, newFile, rootBody, file, false, false, false, nil)
+			provisioning.VerifyClustersState(s.T(), adminClient, clusterIDs)
+
+			s3 := terratest.SnapshotInput.S3 != nil || terraform.ETCD.S3 != nil
+			VerifySnapshotSchedule(s.T(), adminClient, terraform.ResourcePrefix, terraform.ETCD, s3)
+		})
+	}
+
+	if s.terratestConfig.LocalQaseReporting {
+		qase.ReportTest(s.terratestConfig)
+	}
+}
+
+func TestTfpSnapshotScheduleTestSuite(t *testing.T) {
+	suite.Run(t, new(SnapshotScheduleTestSuite))
+}